@@ -0,0 +1,90 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Command schemagen generates Go types and conversion functions for the
+// schemas of a provider, from the output of the `terraform providers schema
+// -json` command. See the schemagen package for the generated code.
+//
+// Usage:
+//
+//	schemagen -package NAME [-provider ADDRESS] [-output FILE] [SCHEMAS_JSON]
+//
+// The schemas are read from SCHEMAS_JSON, or from standard input if it is
+// omitted or "-". The generated code is written to FILE, or to standard output
+// if it is omitted.
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/hashicorp/terraform-plugin-go/schemagen"
+)
+
+func main() {
+	if err := run(os.Args[1:], os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintf(os.Stderr, "schemagen: %s\n", err)
+		os.Exit(1)
+	}
+}
+
+func run(args []string, stdin io.Reader, stdout io.Writer) error {
+	flags := flag.NewFlagSet("schemagen", flag.ContinueOnError)
+
+	packageName := flags.String("package", "", "name of the generated package (required)")
+	provider := flags.String("provider", "", "address of the provider, if the JSON has schemas for several providers")
+	output := flags.String("output", "", "file to write the generated code to, instead of standard output")
+
+	flags.Usage = func() {
+		fmt.Fprintf(flags.Output(), "Usage: schemagen -package NAME [-provider ADDRESS] [-output FILE] [SCHEMAS_JSON]\n\n")
+		flags.PrintDefaults()
+	}
+
+	if err := flags.Parse(args); err != nil {
+		return err
+	}
+
+	if flags.NArg() > 1 {
+		flags.Usage()
+
+		return fmt.Errorf("expected at most one schemas JSON file, got %d", flags.NArg())
+	}
+
+	input := stdin
+
+	if path := flags.Arg(0); path != "" && path != "-" {
+		f, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+
+		defer f.Close()
+
+		input = f
+	}
+
+	data, err := io.ReadAll(input)
+	if err != nil {
+		return fmt.Errorf("error reading schemas JSON: %w", err)
+	}
+
+	schemas, err := schemagen.SchemasFromJSON(data, *provider)
+	if err != nil {
+		return err
+	}
+
+	src, err := schemagen.Generate(schemas, schemagen.Options{PackageName: *packageName})
+	if err != nil {
+		return err
+	}
+
+	if *output == "" {
+		_, err = stdout.Write(src)
+
+		return err
+	}
+
+	return os.WriteFile(*output, src, 0o644)
+}
@@ -38,6 +38,12 @@ const (
 	// Message of the function error.
 	KeyFunctionErrorText = "function_error_text"
 
+	// Number of function calls answered from the function result cache
+	KeyFunctionResultCacheHits = "tf_function_result_cache_hits"
+
+	// Number of function calls not found in the function result cache
+	KeyFunctionResultCacheMisses = "tf_function_result_cache_misses"
+
 	// Duration in milliseconds for the RPC request
 	KeyRequestDurationMs = "tf_req_duration_ms"
 
@@ -0,0 +1,108 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package lru
+
+import (
+	"container/list"
+	"sync"
+)
+
+// Cache is a cache of at most a fixed number of entries, which evicts the
+// least recently used entry when it is full. It is safe for concurrent use.
+type Cache[K comparable, V any] struct {
+	mu sync.Mutex
+
+	size    int
+	entries map[K]*list.Element
+
+	// order has the entries from the most to the least recently used.
+	order *list.List
+
+	hits   int64
+	misses int64
+}
+
+// entry is an element of the order list of a Cache.
+type entry[K comparable, V any] struct {
+	key   K
+	value V
+}
+
+// New returns an empty Cache of at most `size` entries, which must be
+// positive.
+func New[K comparable, V any](size int) *Cache[K, V] {
+	return &Cache[K, V]{
+		size:    size,
+		entries: make(map[K]*list.Element, size),
+		order:   list.New(),
+	}
+}
+
+// Get returns the value of `key` and true, or false if the Cache has no such
+// entry, counting a hit or a miss.
+func (c *Cache[K, V]) Get(key K) (V, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	elem, ok := c.entries[key]
+	if !ok {
+		c.misses++
+
+		var zero V
+
+		return zero, false
+	}
+
+	c.hits++
+	c.order.MoveToFront(elem)
+
+	return entryOf[K, V](elem).value, true
+}
+
+// Add sets the value of `key`, evicting the least recently used entry if the
+// Cache is full.
+func (c *Cache[K, V]) Add(key K, value V) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if elem, ok := c.entries[key]; ok {
+		entryOf[K, V](elem).value = value
+		c.order.MoveToFront(elem)
+
+		return
+	}
+
+	if c.order.Len() >= c.size {
+		oldest := c.order.Back()
+
+		if oldest != nil {
+			c.order.Remove(oldest)
+			delete(c.entries, entryOf[K, V](oldest).key)
+		}
+	}
+
+	c.entries[key] = c.order.PushFront(&entry[K, V]{key: key, value: value})
+}
+
+// Len returns the number of entries of the Cache.
+func (c *Cache[K, V]) Len() int {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.order.Len()
+}
+
+// Stats returns the number of hits and misses of Get so far.
+func (c *Cache[K, V]) Stats() (hits, misses int64) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	return c.hits, c.misses
+}
+
+// entryOf returns the entry of an element of the order list of a Cache.
+func entryOf[K comparable, V any](elem *list.Element) *entry[K, V] {
+	//nolint:forcetypeassert // The order list only has entries.
+	return elem.Value.(*entry[K, V])
+}
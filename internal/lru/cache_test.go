@@ -0,0 +1,127 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package lru_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-go/internal/lru"
+)
+
+func TestCache(t *testing.T) {
+	t.Parallel()
+
+	type lookup struct {
+		key      string
+		expected int
+		found    bool
+	}
+
+	testCases := map[string]struct {
+		size           int
+		adds           []string
+		lookups        []lookup
+		expectedLen    int
+		expectedHits   int64
+		expectedMisses int64
+	}{
+		"empty": {
+			size: 2,
+			lookups: []lookup{
+				{key: "a"},
+			},
+			expectedMisses: 1,
+		},
+		"within-size": {
+			size: 2,
+			adds: []string{"a", "b"},
+			lookups: []lookup{
+				{key: "a", expected: 1, found: true},
+				{key: "b", expected: 2, found: true},
+			},
+			expectedLen:  2,
+			expectedHits: 2,
+		},
+		"eviction": {
+			size: 2,
+			adds: []string{"a", "b", "c"},
+			lookups: []lookup{
+				{key: "a"},
+				{key: "b", expected: 2, found: true},
+				{key: "c", expected: 3, found: true},
+			},
+			expectedLen:    2,
+			expectedHits:   2,
+			expectedMisses: 1,
+		},
+		"update": {
+			size: 2,
+			adds: []string{"a", "b", "a", "c"},
+			lookups: []lookup{
+				{key: "a", expected: 3, found: true},
+				{key: "b"},
+				{key: "c", expected: 4, found: true},
+			},
+			expectedLen:    2,
+			expectedHits:   2,
+			expectedMisses: 1,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			cache := lru.New[string, int](testCase.size)
+
+			for pos, key := range testCase.adds {
+				cache.Add(key, pos+1)
+			}
+
+			for _, l := range testCase.lookups {
+				got, found := cache.Get(l.key)
+
+				if found != l.found || got != l.expected {
+					t.Errorf("expected %q to be %d (found: %t), got %d (found: %t)", l.key, l.expected, l.found, got, found)
+				}
+			}
+
+			if diff := cmp.Diff(testCase.expectedLen, cache.Len()); diff != "" {
+				t.Errorf("unexpected length difference: %s", diff)
+			}
+
+			hits, misses := cache.Stats()
+
+			if diff := cmp.Diff([]int64{testCase.expectedHits, testCase.expectedMisses}, []int64{hits, misses}); diff != "" {
+				t.Errorf("unexpected stats difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestCacheRecentlyUsed(t *testing.T) {
+	t.Parallel()
+
+	cache := lru.New[string, int](2)
+
+	cache.Add("a", 1)
+	cache.Add("b", 2)
+
+	// Using "a" makes "b" the least recently used entry.
+	if _, found := cache.Get("a"); !found {
+		t.Fatal("expected a to be found")
+	}
+
+	cache.Add("c", 3)
+
+	if _, found := cache.Get("b"); found {
+		t.Error("expected b to be evicted")
+	}
+
+	if _, found := cache.Get("a"); !found {
+		t.Error("expected a to be found")
+	}
+}
@@ -0,0 +1,7 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package lru implements a size-bounded cache that evicts the least recently
+// used entries. These implementations are intentionally outside the public
+// API.
+package lru
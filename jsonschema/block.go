@@ -0,0 +1,201 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package jsonschema
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// FromBlock returns the JSON Schema document of the body of a block of the
+// schema `block` in the JSON configuration syntax, such as the body of a
+// resource block for the schema of the resource.
+func FromBlock(block *tfprotov6.SchemaBlock) (*Schema, error) {
+	res, err := blockSchema(block)
+	if err != nil {
+		return nil, err
+	}
+
+	res.Schema = Draft
+
+	return res, nil
+}
+
+// blockSchema returns the schema of the body of a block. Bodies only accept
+// the attributes and nested blocks of the block, and "//" for comments.
+func blockSchema(block *tfprotov6.SchemaBlock) (*Schema, error) {
+	if block == nil {
+		block = &tfprotov6.SchemaBlock{}
+	}
+
+	res := &Schema{
+		Type:        []string{"object"},
+		Description: block.Description,
+		Deprecated:  block.Deprecated,
+		Properties: map[string]*Schema{
+			"//": {},
+		},
+		AdditionalProperties: &Schema{False: true},
+	}
+
+	for _, attr := range block.Attributes {
+		if attr == nil || !configurable(attr) {
+			continue
+		}
+
+		property, err := attributeSchema(attr)
+		if err != nil {
+			return nil, fmt.Errorf("attribute %q: %w", attr.Name, err)
+		}
+
+		if attr.Required {
+			res.Required = append(res.Required, attr.Name)
+		}
+
+		res.Properties[attr.Name] = property
+	}
+
+	for _, blockType := range block.BlockTypes {
+		if blockType == nil {
+			continue
+		}
+
+		property, err := nestedBlockSchema(blockType)
+		if err != nil {
+			return nil, fmt.Errorf("block %q: %w", blockType.TypeName, err)
+		}
+
+		if blockType.MinItems > 0 && blockType.Nesting != tfprotov6.SchemaNestedBlockNestingModeGroup {
+			res.Required = append(res.Required, blockType.TypeName)
+		}
+
+		res.Properties[blockType.TypeName] = property
+	}
+
+	sort.Strings(res.Required)
+
+	return res, nil
+}
+
+// nestedBlockSchema returns the schema of the nested blocks of a block type.
+// Blocks that are not in a map are either a single body or an array of
+// bodies, while blocks in a map are an object with a body for each key.
+func nestedBlockSchema(blockType *tfprotov6.SchemaNestedBlock) (*Schema, error) {
+	body, err := blockSchema(blockType.Block)
+	if err != nil {
+		return nil, err
+	}
+
+	var res *Schema
+
+	switch blockType.Nesting {
+	case tfprotov6.SchemaNestedBlockNestingModeSingle, tfprotov6.SchemaNestedBlockNestingModeGroup:
+		res = &Schema{
+			AnyOf: []*Schema{
+				body,
+				{Type: []string{"array"}, Items: body, MaxItems: count(1)},
+			},
+		}
+	case tfprotov6.SchemaNestedBlockNestingModeList, tfprotov6.SchemaNestedBlockNestingModeSet:
+		bodies := &Schema{Type: []string{"array"}, Items: body}
+
+		if blockType.MinItems > 0 {
+			bodies.MinItems = count(int(blockType.MinItems))
+		}
+
+		if blockType.MaxItems > 0 {
+			bodies.MaxItems = count(int(blockType.MaxItems))
+		}
+
+		res = bodies
+
+		if blockType.MinItems <= 1 {
+			res = &Schema{AnyOf: []*Schema{body, bodies}}
+		}
+	case tfprotov6.SchemaNestedBlockNestingModeMap:
+		res = &Schema{Type: []string{"object"}, AdditionalProperties: body}
+	default:
+		return nil, fmt.Errorf("invalid nesting mode %s", blockType.Nesting)
+	}
+
+	if res != body && blockType.Block != nil {
+		res.Description = blockType.Block.Description
+		res.Deprecated = blockType.Block.Deprecated
+	}
+
+	return res, nil
+}
+
+// attributeSchema returns the schema of the values of an attribute.
+func attributeSchema(attr *tfprotov6.SchemaAttribute) (*Schema, error) {
+	var res *Schema
+	var err error
+
+	if attr.NestedType != nil {
+		res, err = nestedAttributeSchema(attr.NestedType)
+	} else {
+		res, err = valueSchema(attr.Type)
+	}
+
+	if err != nil {
+		return nil, err
+	}
+
+	if !attr.Required {
+		nullable(res)
+	}
+
+	res.Description = attr.Description
+	res.Deprecated = attr.Deprecated
+
+	return res, nil
+}
+
+// nestedAttributeSchema returns the schema of the values of a nested
+// attribute. Like values of object types, they accept properties that are
+// not attributes, as Terraform discards them.
+func nestedAttributeSchema(object *tfprotov6.SchemaObject) (*Schema, error) {
+	elem := &Schema{
+		Type:       []string{"object", "string"},
+		Properties: map[string]*Schema{},
+	}
+
+	for _, attr := range object.Attributes {
+		if attr == nil || !configurable(attr) {
+			continue
+		}
+
+		property, err := attributeSchema(attr)
+		if err != nil {
+			return nil, fmt.Errorf("attribute %q: %w", attr.Name, err)
+		}
+
+		if attr.Required {
+			elem.Required = append(elem.Required, attr.Name)
+		}
+
+		elem.Properties[attr.Name] = property
+	}
+
+	sort.Strings(elem.Required)
+
+	switch object.Nesting {
+	case tfprotov6.SchemaObjectNestingModeSingle:
+		return elem, nil
+	case tfprotov6.SchemaObjectNestingModeList, tfprotov6.SchemaObjectNestingModeSet:
+		return &Schema{Type: []string{"array", "string"}, Items: elem}, nil
+	case tfprotov6.SchemaObjectNestingModeMap:
+		return &Schema{Type: []string{"object", "string"}, AdditionalProperties: elem}, nil
+	}
+
+	return nil, fmt.Errorf("invalid nesting mode %s", object.Nesting)
+}
+
+// configurable returns whether an attribute can be set in configuration,
+// which excludes attributes that are only computed.
+func configurable(attr *tfprotov6.SchemaAttribute) bool {
+	return attr.Required || attr.Optional || !attr.Computed
+}
@@ -0,0 +1,317 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-go/jsonschema"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestFromBlock(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		block         *tfprotov6.SchemaBlock
+		expected      string
+		expectedError string
+	}{
+		"nil": {
+			expected: `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "//": {}
+  },
+  "additionalProperties": false
+}`,
+		},
+		"attributes": {
+			block: &tfprotov6.SchemaBlock{
+				Description: "An example thing.",
+				Attributes: []*tfprotov6.SchemaAttribute{
+					{
+						Name:     "id",
+						Type:     tftypes.String,
+						Computed: true,
+					},
+					{
+						Name:        "name",
+						Type:        tftypes.String,
+						Required:    true,
+						Description: "The name.",
+					},
+					{
+						Name:       "size",
+						Type:       tftypes.Number,
+						Optional:   true,
+						Computed:   true,
+						Deprecated: true,
+					},
+					{
+						Name:     "settings",
+						Type:     tftypes.DynamicPseudoType,
+						Optional: true,
+					},
+					nil,
+				},
+			},
+			expected: `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "description": "An example thing.",
+  "properties": {
+    "//": {},
+    "name": {
+      "type": [
+        "string",
+        "number",
+        "boolean"
+      ],
+      "description": "The name."
+    },
+    "settings": {},
+    "size": {
+      "type": [
+        "number",
+        "string",
+        "null"
+      ],
+      "deprecated": true
+    }
+  },
+  "required": [
+    "name"
+  ],
+  "additionalProperties": false
+}`,
+		},
+		"nested-attribute": {
+			block: &tfprotov6.SchemaBlock{
+				Attributes: []*tfprotov6.SchemaAttribute{
+					{
+						Name: "listeners",
+						NestedType: &tfprotov6.SchemaObject{
+							Nesting: tfprotov6.SchemaObjectNestingModeList,
+							Attributes: []*tfprotov6.SchemaAttribute{
+								{
+									Name:     "port",
+									Type:     tftypes.Number,
+									Required: true,
+								},
+								{
+									Name:     "arn",
+									Type:     tftypes.String,
+									Computed: true,
+								},
+							},
+						},
+						Required: true,
+					},
+				},
+			},
+			expected: `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "//": {},
+    "listeners": {
+      "type": [
+        "array",
+        "string"
+      ],
+      "items": {
+        "type": [
+          "object",
+          "string"
+        ],
+        "properties": {
+          "port": {
+            "type": [
+              "number",
+              "string"
+            ]
+          }
+        },
+        "required": [
+          "port"
+        ]
+      }
+    }
+  },
+  "required": [
+    "listeners"
+  ],
+  "additionalProperties": false
+}`,
+		},
+		"nested-blocks": {
+			block: &tfprotov6.SchemaBlock{
+				BlockTypes: []*tfprotov6.SchemaNestedBlock{
+					{
+						TypeName: "rule",
+						Nesting:  tfprotov6.SchemaNestedBlockNestingModeList,
+						MinItems: 2,
+						MaxItems: 5,
+						Block: &tfprotov6.SchemaBlock{
+							Description: "A rule.",
+						},
+					},
+					{
+						TypeName: "timeouts",
+						Nesting:  tfprotov6.SchemaNestedBlockNestingModeSingle,
+					},
+					{
+						TypeName: "tag",
+						Nesting:  tfprotov6.SchemaNestedBlockNestingModeSet,
+						MinItems: 1,
+					},
+					{
+						TypeName: "setting",
+						Nesting:  tfprotov6.SchemaNestedBlockNestingModeMap,
+					},
+				},
+			},
+			expected: `{
+  "$schema": "https://json-schema.org/draft/2020-12/schema",
+  "type": "object",
+  "properties": {
+    "//": {},
+    "rule": {
+      "type": "array",
+      "description": "A rule.",
+      "items": {
+        "type": "object",
+        "description": "A rule.",
+        "properties": {
+          "//": {}
+        },
+        "additionalProperties": false
+      },
+      "minItems": 2,
+      "maxItems": 5
+    },
+    "setting": {
+      "type": "object",
+      "additionalProperties": {
+        "type": "object",
+        "properties": {
+          "//": {}
+        },
+        "additionalProperties": false
+      }
+    },
+    "tag": {
+      "anyOf": [
+        {
+          "type": "object",
+          "properties": {
+            "//": {}
+          },
+          "additionalProperties": false
+        },
+        {
+          "type": "array",
+          "items": {
+            "type": "object",
+            "properties": {
+              "//": {}
+            },
+            "additionalProperties": false
+          },
+          "minItems": 1
+        }
+      ]
+    },
+    "timeouts": {
+      "anyOf": [
+        {
+          "type": "object",
+          "properties": {
+            "//": {}
+          },
+          "additionalProperties": false
+        },
+        {
+          "type": "array",
+          "items": {
+            "type": "object",
+            "properties": {
+              "//": {}
+            },
+            "additionalProperties": false
+          },
+          "maxItems": 1
+        }
+      ]
+    }
+  },
+  "required": [
+    "rule",
+    "tag"
+  ],
+  "additionalProperties": false
+}`,
+		},
+		"invalid-nesting-mode": {
+			block: &tfprotov6.SchemaBlock{
+				BlockTypes: []*tfprotov6.SchemaNestedBlock{
+					{
+						TypeName: "outer",
+						Nesting:  tfprotov6.SchemaNestedBlockNestingModeSingle,
+						Block: &tfprotov6.SchemaBlock{
+							Attributes: []*tfprotov6.SchemaAttribute{
+								{
+									Name:       "inner",
+									NestedType: &tfprotov6.SchemaObject{},
+									Optional:   true,
+								},
+							},
+						},
+					},
+				},
+			},
+			expectedError: `block "outer": attribute "inner": invalid nesting mode INVALID`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := jsonschema.FromBlock(testCase.block)
+
+			if err != nil {
+				if testCase.expectedError == "" {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if diff := cmp.Diff(testCase.expectedError, err.Error()); diff != "" {
+					t.Fatalf("unexpected error difference: %s", diff)
+				}
+
+				return
+			}
+
+			if testCase.expectedError != "" {
+				t.Fatalf("expected error %q, got none", testCase.expectedError)
+			}
+
+			gotJSON, err := json.MarshalIndent(got, "", "  ")
+
+			if err != nil {
+				t.Fatalf("unexpected error marshaling schema: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expected, string(gotJSON)); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,25 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package jsonschema converts Terraform types and provider schemas into JSON
+// Schema (draft 2020-12) documents, describing the values that Terraform
+// accepts in the JSON configuration syntax, such as in .tf.json files.
+//
+// FromType returns the document of the values of a tftypes.Type, and
+// FromBlock the document of the body of a block of a provider schema, such as
+// the body of a resource block. The documents follow the rules of the JSON
+// configuration syntax rather than only the JSON encoding of the values:
+//
+//   - any value can be a string, as strings are templates that can contain
+//     expressions, such as "${var.name}"
+//   - numbers and booleans are accepted for strings, as Terraform converts
+//     them to strings
+//   - attributes that are not required can be null
+//   - nested blocks can be written as a single object or as an array of
+//     objects, and block bodies can have a "//" property for comments
+//   - attributes that are only computed cannot be set, and are left out
+//
+// The documents do not describe meta-arguments, such as count or lifecycle,
+// or dynamic blocks, which callers can add to the properties of the schema
+// of a block if they need to.
+package jsonschema
@@ -0,0 +1,107 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package jsonschema
+
+import (
+	"encoding/json"
+)
+
+// Draft is the URI of the JSON Schema dialect of the documents returned by
+// FromType and FromBlock.
+const Draft = "https://json-schema.org/draft/2020-12/schema"
+
+// Schema is a JSON Schema. Its zero value is the empty schema, which accepts
+// any value. Schemas are marshaled into JSON Schema documents with the
+// encoding/json package.
+type Schema struct {
+	// Schema is the URI of the dialect of the schema. It is only set on the
+	// root of a document.
+	Schema string
+
+	// Type is the list of the JSON types the schema accepts, such as
+	// "string" or "object". If it is empty, the schema accepts any type.
+	Type []string
+
+	// Description is the description of the value.
+	Description string
+
+	// Deprecated is true if the value is deprecated.
+	Deprecated bool
+
+	// Properties are the schemas of the properties of objects.
+	Properties map[string]*Schema
+
+	// Required are the names of the properties objects must have.
+	Required []string
+
+	// AdditionalProperties is the schema of the properties of objects that
+	// are not in Properties.
+	AdditionalProperties *Schema
+
+	// PrefixItems are the schemas of the first elements of arrays.
+	PrefixItems []*Schema
+
+	// Items is the schema of the elements of arrays that are not covered
+	// by PrefixItems.
+	Items *Schema
+
+	// MinItems is the minimum number of elements of arrays, if set.
+	MinItems *int64
+
+	// MaxItems is the maximum number of elements of arrays, if set.
+	MaxItems *int64
+
+	// AnyOf are schemas of which values must match at least one.
+	AnyOf []*Schema
+
+	// False makes the schema the boolean schema false, which accepts no
+	// value. The other fields are then ignored.
+	False bool
+}
+
+// MarshalJSON returns the JSON Schema document of the schema.
+func (s *Schema) MarshalJSON() ([]byte, error) {
+	if s.False {
+		return []byte("false"), nil
+	}
+
+	doc := struct {
+		Schema               string             `json:"$schema,omitempty"`
+		Type                 any                `json:"type,omitempty"`
+		Description          string             `json:"description,omitempty"`
+		Deprecated           bool               `json:"deprecated,omitempty"`
+		Properties           map[string]*Schema `json:"properties,omitempty"`
+		Required             []string           `json:"required,omitempty"`
+		AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+		PrefixItems          []*Schema          `json:"prefixItems,omitempty"`
+		Items                *Schema            `json:"items,omitempty"`
+		MinItems             *int64             `json:"minItems,omitempty"`
+		MaxItems             *int64             `json:"maxItems,omitempty"`
+		AnyOf                []*Schema          `json:"anyOf,omitempty"`
+	}{
+		Schema:               s.Schema,
+		Description:          s.Description,
+		Deprecated:           s.Deprecated,
+		Properties:           s.Properties,
+		Required:             s.Required,
+		AdditionalProperties: s.AdditionalProperties,
+		PrefixItems:          s.PrefixItems,
+		Items:                s.Items,
+		MinItems:             s.MinItems,
+		MaxItems:             s.MaxItems,
+		AnyOf:                s.AnyOf,
+	}
+
+	// A single type is written as a string rather than as a list, as is
+	// customary in JSON Schema documents.
+	switch len(s.Type) {
+	case 0:
+	case 1:
+		doc.Type = s.Type[0]
+	default:
+		doc.Type = s.Type
+	}
+
+	return json.Marshal(doc)
+}
@@ -0,0 +1,86 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-go/jsonschema"
+)
+
+func TestSchemaMarshalJSON(t *testing.T) {
+	t.Parallel()
+
+	one := int64(1)
+
+	testCases := map[string]struct {
+		schema   *jsonschema.Schema
+		expected string
+	}{
+		"empty": {
+			schema:   &jsonschema.Schema{},
+			expected: `{}`,
+		},
+		"false": {
+			schema:   &jsonschema.Schema{False: true, Type: []string{"string"}},
+			expected: `false`,
+		},
+		"single-type": {
+			schema:   &jsonschema.Schema{Type: []string{"string"}},
+			expected: `{"type":"string"}`,
+		},
+		"types": {
+			schema:   &jsonschema.Schema{Type: []string{"number", "string"}},
+			expected: `{"type":["number","string"]}`,
+		},
+		"object": {
+			schema: &jsonschema.Schema{
+				Schema:      jsonschema.Draft,
+				Type:        []string{"object"},
+				Description: "An object.",
+				Deprecated:  true,
+				Properties: map[string]*jsonschema.Schema{
+					"name": {Type: []string{"string"}},
+				},
+				Required:             []string{"name"},
+				AdditionalProperties: &jsonschema.Schema{False: true},
+			},
+			expected: `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":"object","description":"An object.","deprecated":true,"properties":{"name":{"type":"string"}},"required":["name"],"additionalProperties":false}`,
+		},
+		"array": {
+			schema: &jsonschema.Schema{
+				AnyOf: []*jsonschema.Schema{
+					{Type: []string{"object"}},
+					{
+						Type:        []string{"array"},
+						PrefixItems: []*jsonschema.Schema{{Type: []string{"boolean"}}},
+						Items:       &jsonschema.Schema{Type: []string{"object"}},
+						MinItems:    &one,
+						MaxItems:    &one,
+					},
+				},
+			},
+			expected: `{"anyOf":[{"type":"object"},{"type":"array","prefixItems":[{"type":"boolean"}],"items":{"type":"object"},"minItems":1,"maxItems":1}]}`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := json.Marshal(testCase.schema)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expected, string(got)); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
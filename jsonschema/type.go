@@ -0,0 +1,124 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package jsonschema
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// FromType returns the JSON Schema document of the values of type `typ` in
+// the JSON configuration syntax. tftypes.DynamicPseudoType is converted into
+// the empty schema, which accepts any value.
+func FromType(typ tftypes.Type) (*Schema, error) {
+	res, err := valueSchema(typ)
+	if err != nil {
+		return nil, err
+	}
+
+	res.Schema = Draft
+
+	return res, nil
+}
+
+// valueSchema returns the schema of the values of type `typ`. Apart from
+// strings, which also accept numbers and booleans, every type also accepts a
+// string for a template.
+func valueSchema(typ tftypes.Type) (*Schema, error) {
+	if typ == nil {
+		return nil, errors.New("missing type")
+	}
+
+	switch typ := typ.(type) {
+	case tftypes.List:
+		items, err := valueSchema(typ.ElementType)
+		if err != nil {
+			return nil, fmt.Errorf("list element: %w", err)
+		}
+
+		return &Schema{Type: []string{"array", "string"}, Items: items}, nil
+	case tftypes.Set:
+		items, err := valueSchema(typ.ElementType)
+		if err != nil {
+			return nil, fmt.Errorf("set element: %w", err)
+		}
+
+		return &Schema{Type: []string{"array", "string"}, Items: items}, nil
+	case tftypes.Map:
+		elem, err := valueSchema(typ.ElementType)
+		if err != nil {
+			return nil, fmt.Errorf("map element: %w", err)
+		}
+
+		return &Schema{Type: []string{"object", "string"}, AdditionalProperties: elem}, nil
+	case tftypes.Object:
+		res := &Schema{
+			Type:       []string{"object", "string"},
+			Properties: make(map[string]*Schema, len(typ.AttributeTypes)),
+		}
+
+		for name, attrType := range typ.AttributeTypes {
+			property, err := valueSchema(attrType)
+			if err != nil {
+				return nil, fmt.Errorf("attribute %q: %w", name, err)
+			}
+
+			if _, ok := typ.OptionalAttributes[name]; !ok {
+				res.Required = append(res.Required, name)
+			}
+
+			res.Properties[name] = property
+		}
+
+		sort.Strings(res.Required)
+
+		return res, nil
+	case tftypes.Tuple:
+		res := &Schema{
+			Type:     []string{"array", "string"},
+			MinItems: count(len(typ.ElementTypes)),
+			MaxItems: count(len(typ.ElementTypes)),
+		}
+
+		for i, elemType := range typ.ElementTypes {
+			item, err := valueSchema(elemType)
+			if err != nil {
+				return nil, fmt.Errorf("tuple element %d: %w", i, err)
+			}
+
+			res.PrefixItems = append(res.PrefixItems, item)
+		}
+
+		return res, nil
+	}
+
+	switch {
+	case typ.Is(tftypes.DynamicPseudoType):
+		return &Schema{}, nil
+	case typ.Is(tftypes.String):
+		return &Schema{Type: []string{"string", "number", "boolean"}}, nil
+	case typ.Is(tftypes.Number):
+		return &Schema{Type: []string{"number", "string"}}, nil
+	case typ.Is(tftypes.Bool):
+		return &Schema{Type: []string{"boolean", "string"}}, nil
+	}
+
+	return nil, fmt.Errorf("unsupported type %s", typ)
+}
+
+// nullable makes `s` also accept null. The empty schema already does.
+func nullable(s *Schema) {
+	if len(s.Type) > 0 {
+		s.Type = append(s.Type, "null")
+	}
+}
+
+func count(n int) *int64 {
+	res := int64(n)
+
+	return &res
+}
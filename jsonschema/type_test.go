@@ -0,0 +1,115 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package jsonschema_test
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-go/jsonschema"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestFromType(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		typ           tftypes.Type
+		expected      string
+		expectedError string
+	}{
+		"string": {
+			typ:      tftypes.String,
+			expected: `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":["string","number","boolean"]}`,
+		},
+		"number": {
+			typ:      tftypes.Number,
+			expected: `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":["number","string"]}`,
+		},
+		"bool": {
+			typ:      tftypes.Bool,
+			expected: `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":["boolean","string"]}`,
+		},
+		"dynamic": {
+			typ:      tftypes.DynamicPseudoType,
+			expected: `{"$schema":"https://json-schema.org/draft/2020-12/schema"}`,
+		},
+		"list": {
+			typ:      tftypes.List{ElementType: tftypes.Number},
+			expected: `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":["array","string"],"items":{"type":["number","string"]}}`,
+		},
+		"set": {
+			typ:      tftypes.Set{ElementType: tftypes.Bool},
+			expected: `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":["array","string"],"items":{"type":["boolean","string"]}}`,
+		},
+		"map": {
+			typ:      tftypes.Map{ElementType: tftypes.DynamicPseudoType},
+			expected: `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":["object","string"],"additionalProperties":{}}`,
+		},
+		"object": {
+			typ: tftypes.Object{
+				AttributeTypes: map[string]tftypes.Type{
+					"name": tftypes.String,
+					"port": tftypes.Number,
+					"tls":  tftypes.Bool,
+				},
+				OptionalAttributes: map[string]struct{}{
+					"tls": {},
+				},
+			},
+			expected: `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":["object","string"],"properties":{"name":{"type":["string","number","boolean"]},"port":{"type":["number","string"]},"tls":{"type":["boolean","string"]}},"required":["name","port"]}`,
+		},
+		"tuple": {
+			typ:      tftypes.Tuple{ElementTypes: []tftypes.Type{tftypes.String, tftypes.Number}},
+			expected: `{"$schema":"https://json-schema.org/draft/2020-12/schema","type":["array","string"],"prefixItems":[{"type":["string","number","boolean"]},{"type":["number","string"]}],"minItems":2,"maxItems":2}`,
+		},
+		"missing-type": {
+			expectedError: "missing type",
+		},
+		"missing-element-type": {
+			typ: tftypes.Object{
+				AttributeTypes: map[string]tftypes.Type{
+					"rules": tftypes.List{},
+				},
+			},
+			expectedError: `attribute "rules": list element: missing type`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := jsonschema.FromType(testCase.typ)
+
+			if err != nil {
+				if testCase.expectedError == "" {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if diff := cmp.Diff(testCase.expectedError, err.Error()); diff != "" {
+					t.Fatalf("unexpected error difference: %s", diff)
+				}
+
+				return
+			}
+
+			if testCase.expectedError != "" {
+				t.Fatalf("expected error %q, got none", testCase.expectedError)
+			}
+
+			gotJSON, err := json.Marshal(got)
+
+			if err != nil {
+				t.Fatalf("unexpected error marshaling schema: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expected, string(gotJSON)); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
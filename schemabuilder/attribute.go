@@ -0,0 +1,200 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package schemabuilder
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// AttributeBuilder builds an attribute of a schema. Use NewAttribute for
+// attributes with a type, or one of the nested attribute constructors, such
+// as NewListNestedAttribute, for nested attributes.
+type AttributeBuilder struct {
+	name string
+	typ  tftypes.Type
+
+	// nesting and attributes describe nested attributes. nesting is
+	// nestingModeInvalid for attributes with a type.
+	nesting    nestingMode
+	attributes []*AttributeBuilder
+
+	required  bool
+	optional  bool
+	computed  bool
+	sensitive bool
+	writeOnly bool
+
+	docs docs
+	errs []error
+}
+
+// NewAttribute returns an AttributeBuilder for an attribute named `name` with
+// the type `typ`. Exactly one of Required, Optional, or Computed, or both
+// Optional and Computed, must be called on it.
+func NewAttribute(name string, typ tftypes.Type) *AttributeBuilder {
+	a := &AttributeBuilder{
+		name: name,
+		typ:  typ,
+	}
+
+	if typ == nil {
+		a.errs = append(a.errs, errors.New("missing type"))
+	}
+
+	return a
+}
+
+// NewSingleNestedAttribute returns an AttributeBuilder for a nested attribute
+// that is a single object with the attributes. Nested attributes are only
+// supported by protocol version 6.
+func NewSingleNestedAttribute(name string, attributes ...*AttributeBuilder) *AttributeBuilder {
+	return newNestedAttribute(name, nestingModeSingle, attributes)
+}
+
+// NewListNestedAttribute returns an AttributeBuilder for a nested attribute
+// that is a list of objects with the attributes. Nested attributes are only
+// supported by protocol version 6.
+func NewListNestedAttribute(name string, attributes ...*AttributeBuilder) *AttributeBuilder {
+	return newNestedAttribute(name, nestingModeList, attributes)
+}
+
+// NewSetNestedAttribute returns an AttributeBuilder for a nested attribute
+// that is a set of objects with the attributes. Nested attributes are only
+// supported by protocol version 6.
+func NewSetNestedAttribute(name string, attributes ...*AttributeBuilder) *AttributeBuilder {
+	return newNestedAttribute(name, nestingModeSet, attributes)
+}
+
+// NewMapNestedAttribute returns an AttributeBuilder for a nested attribute
+// that is a map of objects with the attributes. Nested attributes are only
+// supported by protocol version 6.
+func NewMapNestedAttribute(name string, attributes ...*AttributeBuilder) *AttributeBuilder {
+	return newNestedAttribute(name, nestingModeMap, attributes)
+}
+
+func newNestedAttribute(name string, nesting nestingMode, attributes []*AttributeBuilder) *AttributeBuilder {
+	a := &AttributeBuilder{
+		name:    name,
+		nesting: nesting,
+	}
+
+	for _, attr := range attributes {
+		if err := checkNewAttribute(attr, a.attributes, nil); err != nil {
+			a.errs = append(a.errs, err)
+
+			continue
+		}
+
+		a.attributes = append(a.attributes, attr)
+	}
+
+	return a
+}
+
+// Required marks the attribute as required in configuration. It cannot be
+// combined with Optional or Computed.
+func (a *AttributeBuilder) Required() *AttributeBuilder {
+	if a.optional || a.computed {
+		a.errs = append(a.errs, errors.New("cannot combine Required with Optional or Computed"))
+	}
+
+	a.required = true
+
+	return a
+}
+
+// Optional marks the attribute as optional in configuration. It cannot be
+// combined with Required, but can be combined with Computed.
+func (a *AttributeBuilder) Optional() *AttributeBuilder {
+	if a.required {
+		a.errs = append(a.errs, errors.New("cannot combine Optional with Required"))
+	}
+
+	a.optional = true
+
+	return a
+}
+
+// Computed marks the attribute as set by the provider. It cannot be combined
+// with Required or WriteOnly.
+func (a *AttributeBuilder) Computed() *AttributeBuilder {
+	if a.required {
+		a.errs = append(a.errs, errors.New("cannot combine Computed with Required"))
+	}
+
+	if a.writeOnly {
+		a.errs = append(a.errs, errors.New("cannot combine Computed with WriteOnly"))
+	}
+
+	a.computed = true
+
+	return a
+}
+
+// Sensitive marks the attribute's value as sensitive, so Terraform does not
+// display it.
+func (a *AttributeBuilder) Sensitive() *AttributeBuilder {
+	a.sensitive = true
+
+	return a
+}
+
+// WriteOnly marks the attribute as write-only, so Terraform does not persist
+// its value in the plan or state. It cannot be combined with Computed.
+func (a *AttributeBuilder) WriteOnly() *AttributeBuilder {
+	if a.computed {
+		a.errs = append(a.errs, errors.New("cannot combine WriteOnly with Computed"))
+	}
+
+	a.writeOnly = true
+
+	return a
+}
+
+// Description sets a plain text description of the attribute.
+func (a *AttributeBuilder) Description(description string) *AttributeBuilder {
+	a.docs.setDescription(description, false)
+
+	return a
+}
+
+// MarkdownDescription sets a Markdown formatted description of the
+// attribute.
+func (a *AttributeBuilder) MarkdownDescription(description string) *AttributeBuilder {
+	a.docs.setDescription(description, true)
+
+	return a
+}
+
+// Deprecated marks the attribute as deprecated, with a message explaining why
+// and what to use instead. The message may be empty.
+func (a *AttributeBuilder) Deprecated(message string) *AttributeBuilder {
+	a.docs.setDeprecated(message)
+
+	return a
+}
+
+// validate returns the errors of the attribute and its nested attributes.
+func (a *AttributeBuilder) validate() []error {
+	errs := append([]error(nil), a.errs...)
+
+	if !validName(a.name) {
+		errs = append(errs, errInvalidName)
+	}
+
+	if !a.required && !a.optional && !a.computed {
+		errs = append(errs, errors.New("one of Required, Optional, or Computed must be set"))
+	}
+
+	for _, attr := range a.attributes {
+		for _, err := range attr.validate() {
+			errs = append(errs, fmt.Errorf("attribute %q: %w", attr.name, err))
+		}
+	}
+
+	return errs
+}
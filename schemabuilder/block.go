@@ -0,0 +1,287 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package schemabuilder
+
+import (
+	"errors"
+	"fmt"
+)
+
+// nestingMode is the nesting mode of a nested block or nested attribute.
+type nestingMode int
+
+const (
+	nestingModeInvalid nestingMode = iota
+	nestingModeSingle
+	nestingModeList
+	nestingModeSet
+	nestingModeMap
+	nestingModeGroup
+)
+
+// NestedBlockBuilder builds a nested block of a schema. Use one of the
+// constructors, such as NewListBlock, to create one.
+type NestedBlockBuilder struct {
+	typeName string
+	nesting  nestingMode
+	minItems int64
+	maxItems int64
+	block    block
+}
+
+// NewSingleBlock returns a NestedBlockBuilder for a nested block that appears
+// at most once in configuration, and is null when it does not appear.
+func NewSingleBlock(typeName string) *NestedBlockBuilder {
+	return &NestedBlockBuilder{typeName: typeName, nesting: nestingModeSingle}
+}
+
+// NewGroupBlock returns a NestedBlockBuilder for a nested block that appears
+// at most once in configuration, and whose attributes are null when it does
+// not appear.
+func NewGroupBlock(typeName string) *NestedBlockBuilder {
+	return &NestedBlockBuilder{typeName: typeName, nesting: nestingModeGroup}
+}
+
+// NewListBlock returns a NestedBlockBuilder for a nested block that may
+// appear multiple times in configuration, as a list.
+func NewListBlock(typeName string) *NestedBlockBuilder {
+	return &NestedBlockBuilder{typeName: typeName, nesting: nestingModeList}
+}
+
+// NewSetBlock returns a NestedBlockBuilder for a nested block that may appear
+// multiple times in configuration, as a set.
+func NewSetBlock(typeName string) *NestedBlockBuilder {
+	return &NestedBlockBuilder{typeName: typeName, nesting: nestingModeSet}
+}
+
+// NewMapBlock returns a NestedBlockBuilder for a nested block that may appear
+// multiple times in configuration, each with a label, as a map.
+func NewMapBlock(typeName string) *NestedBlockBuilder {
+	return &NestedBlockBuilder{typeName: typeName, nesting: nestingModeMap}
+}
+
+// Attributes adds attributes to the nested block.
+func (b *NestedBlockBuilder) Attributes(attributes ...*AttributeBuilder) *NestedBlockBuilder {
+	b.block.addAttributes(attributes)
+
+	return b
+}
+
+// Blocks adds nested blocks to the nested block.
+func (b *NestedBlockBuilder) Blocks(blocks ...*NestedBlockBuilder) *NestedBlockBuilder {
+	b.block.addBlocks(blocks)
+
+	return b
+}
+
+// MinItems sets the minimum number of times the nested block must appear in
+// configuration. It can only be used with list and set blocks, or with single
+// blocks to make them required by setting both MinItems and MaxItems to 1.
+func (b *NestedBlockBuilder) MinItems(minItems int64) *NestedBlockBuilder {
+	if err := b.checkItems("MinItems", minItems); err != nil {
+		b.block.errs = append(b.block.errs, err)
+	}
+
+	b.minItems = minItems
+
+	return b
+}
+
+// MaxItems sets the maximum number of times the nested block may appear in
+// configuration, where zero means no limit. It can only be used with list and
+// set blocks, or with single blocks to make them required by setting both
+// MinItems and MaxItems to 1.
+func (b *NestedBlockBuilder) MaxItems(maxItems int64) *NestedBlockBuilder {
+	if err := b.checkItems("MaxItems", maxItems); err != nil {
+		b.block.errs = append(b.block.errs, err)
+	}
+
+	b.maxItems = maxItems
+
+	return b
+}
+
+// Description sets a plain text description of the nested block.
+func (b *NestedBlockBuilder) Description(description string) *NestedBlockBuilder {
+	b.block.docs.setDescription(description, false)
+
+	return b
+}
+
+// MarkdownDescription sets a Markdown formatted description of the nested
+// block.
+func (b *NestedBlockBuilder) MarkdownDescription(description string) *NestedBlockBuilder {
+	b.block.docs.setDescription(description, true)
+
+	return b
+}
+
+// Deprecated marks the nested block as deprecated, with a message explaining
+// why and what to use instead. The message may be empty.
+func (b *NestedBlockBuilder) Deprecated(message string) *NestedBlockBuilder {
+	b.block.docs.setDeprecated(message)
+
+	return b
+}
+
+func (b *NestedBlockBuilder) checkItems(field string, n int64) error {
+	switch b.nesting {
+	case nestingModeList, nestingModeSet:
+		if n < 0 {
+			return fmt.Errorf("%s cannot be negative", field)
+		}
+	case nestingModeSingle:
+		if n != 0 && n != 1 {
+			return fmt.Errorf("%s must be 0 or 1 for single blocks", field)
+		}
+	default:
+		return fmt.Errorf("%s can only be used with list, set, and single blocks", field)
+	}
+
+	return nil
+}
+
+// validate returns the errors of the nested block and its contents.
+func (b *NestedBlockBuilder) validate() []error {
+	var errs []error
+
+	if !validName(b.typeName) {
+		errs = append(errs, errInvalidName)
+	}
+
+	if b.nesting == nestingModeSingle && b.minItems != b.maxItems {
+		errs = append(errs, errors.New("MinItems and MaxItems must both be 0 or both be 1 for single blocks"))
+	}
+
+	if b.maxItems > 0 && b.minItems > b.maxItems {
+		errs = append(errs, fmt.Errorf("MinItems (%d) cannot be greater than MaxItems (%d)", b.minItems, b.maxItems))
+	}
+
+	return append(errs, b.block.validate()...)
+}
+
+// block holds the contents shared by the root block of a schema and nested
+// blocks.
+type block struct {
+	attributes []*AttributeBuilder
+	blocks     []*NestedBlockBuilder
+	docs       docs
+	errs       []error
+}
+
+func (b *block) addAttributes(attributes []*AttributeBuilder) {
+	for _, attr := range attributes {
+		if err := checkNewAttribute(attr, b.attributes, b.blocks); err != nil {
+			b.errs = append(b.errs, err)
+
+			continue
+		}
+
+		b.attributes = append(b.attributes, attr)
+	}
+}
+
+func (b *block) addBlocks(blocks []*NestedBlockBuilder) {
+	for _, nb := range blocks {
+		if nb == nil {
+			b.errs = append(b.errs, errors.New("nil nested block"))
+
+			continue
+		}
+
+		if nameInUse(nb.typeName, b.attributes, b.blocks) {
+			b.errs = append(b.errs, fmt.Errorf("block %q: duplicate name", nb.typeName))
+
+			continue
+		}
+
+		b.blocks = append(b.blocks, nb)
+	}
+}
+
+// validate returns the errors of the block, its attributes, and its nested
+// blocks.
+func (b *block) validate() []error {
+	errs := append([]error(nil), b.errs...)
+
+	for _, attr := range b.attributes {
+		for _, err := range attr.validate() {
+			errs = append(errs, fmt.Errorf("attribute %q: %w", attr.name, err))
+		}
+	}
+
+	for _, nb := range b.blocks {
+		for _, err := range nb.validate() {
+			errs = append(errs, fmt.Errorf("block %q: %w", nb.typeName, err))
+		}
+	}
+
+	return errs
+}
+
+// checkNewAttribute returns an error if the attribute cannot be added
+// alongside the existing attributes and blocks.
+func checkNewAttribute(attr *AttributeBuilder, attributes []*AttributeBuilder, blocks []*NestedBlockBuilder) error {
+	if attr == nil {
+		return errors.New("nil attribute")
+	}
+
+	if nameInUse(attr.name, attributes, blocks) {
+		return fmt.Errorf("attribute %q: duplicate name", attr.name)
+	}
+
+	return nil
+}
+
+func nameInUse(name string, attributes []*AttributeBuilder, blocks []*NestedBlockBuilder) bool {
+	for _, attr := range attributes {
+		if attr.name == name {
+			return true
+		}
+	}
+
+	for _, nb := range blocks {
+		if nb.typeName == name {
+			return true
+		}
+	}
+
+	return false
+}
+
+var errInvalidName = errors.New("names must be non-empty and contain only lowercase letters, digits, and underscores")
+
+// validName returns true if the name is a valid attribute or block name, as
+// required by Terraform.
+func validName(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for _, r := range name {
+		if (r < 'a' || r > 'z') && (r < '0' || r > '9') && r != '_' {
+			return false
+		}
+	}
+
+	return true
+}
+
+// docs holds the documentation shared by attributes and blocks.
+type docs struct {
+	description        string
+	markdown           bool
+	deprecated         bool
+	deprecationMessage string
+}
+
+func (d *docs) setDescription(description string, markdown bool) {
+	d.description = description
+	d.markdown = markdown
+}
+
+func (d *docs) setDeprecated(message string) {
+	d.deprecated = true
+	d.deprecationMessage = message
+}
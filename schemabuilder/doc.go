@@ -0,0 +1,34 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package schemabuilder builds provider schemas with chained calls, as an
+// alternative to writing tfprotov5.Schema and tfprotov6.Schema literals by
+// hand.
+//
+// A schema is started with NewSchema, and attributes and nested blocks are
+// added to it with the Attributes and Blocks methods:
+//
+//	schema, err := schemabuilder.NewSchema().
+//		Version(1).
+//		Attributes(
+//			schemabuilder.NewAttribute("id", tftypes.String).Computed(),
+//			schemabuilder.NewAttribute("name", tftypes.String).Required(),
+//		).
+//		Blocks(
+//			schemabuilder.NewListBlock("rule").
+//				MaxItems(10).
+//				Attributes(
+//					schemabuilder.NewAttribute("port", tftypes.Number).Required(),
+//				),
+//		).
+//		ProtoV6()
+//
+// Invalid combinations, such as an attribute that is both required and
+// computed or a duplicate attribute name, are recorded as they are made and
+// returned as a single error by ProtoV5 and ProtoV6. Attributes and nested
+// blocks are sorted by name in the resulting schema, so the result does not
+// depend on the order of the calls.
+//
+// The same builder can produce both protocol versions, except that nested
+// attributes are only supported by protocol version 6.
+package schemabuilder
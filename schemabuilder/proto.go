@@ -0,0 +1,247 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package schemabuilder
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// ProtoV6 returns the tfprotov6.Schema built by the SchemaBuilder, or an
+// error describing every invalid combination recorded while building it.
+// Attributes and nested blocks are sorted by name.
+func (b *SchemaBuilder) ProtoV6() (*tfprotov6.Schema, error) {
+	if err := b.validate(); err != nil {
+		return nil, err
+	}
+
+	return &tfprotov6.Schema{
+		Version: b.version,
+		Block:   b.block.protoV6(),
+	}, nil
+}
+
+// ProtoV5 returns the tfprotov5.Schema built by the SchemaBuilder, or an
+// error describing every invalid combination recorded while building it.
+// Attributes and nested blocks are sorted by name.
+//
+// Protocol version 5 does not support nested attributes, so ProtoV5 returns
+// an error if the schema has any.
+func (b *SchemaBuilder) ProtoV5() (*tfprotov5.Schema, error) {
+	if err := b.validate(); err != nil {
+		return nil, err
+	}
+
+	if err := errors.Join(b.block.checkProtoV5()...); err != nil {
+		return nil, err
+	}
+
+	return &tfprotov5.Schema{
+		Version: b.version,
+		Block:   b.block.protoV5(),
+	}, nil
+}
+
+// checkProtoV5 returns an error for each nested attribute in the block, as
+// protocol version 5 does not support them.
+func (b *block) checkProtoV5() []error {
+	var errs []error
+
+	for _, attr := range b.attributes {
+		if attr.nesting != nestingModeInvalid {
+			errs = append(errs, fmt.Errorf("attribute %q: nested attributes require protocol version 6", attr.name))
+		}
+	}
+
+	for _, nb := range b.blocks {
+		for _, err := range nb.block.checkProtoV5() {
+			errs = append(errs, fmt.Errorf("block %q: %w", nb.typeName, err))
+		}
+	}
+
+	return errs
+}
+
+func (b *block) protoV6() *tfprotov6.SchemaBlock {
+	res := &tfprotov6.SchemaBlock{
+		Description:        b.docs.description,
+		DescriptionKind:    stringKindV6(b.docs.markdown),
+		Deprecated:         b.docs.deprecated,
+		DeprecationMessage: b.docs.deprecationMessage,
+	}
+
+	for _, attr := range sortedAttributes(b.attributes) {
+		res.Attributes = append(res.Attributes, attr.protoV6())
+	}
+
+	for _, nb := range sortedBlocks(b.blocks) {
+		res.BlockTypes = append(res.BlockTypes, &tfprotov6.SchemaNestedBlock{
+			TypeName: nb.typeName,
+			Block:    nb.block.protoV6(),
+			Nesting:  blockNestingModeV6(nb.nesting),
+			MinItems: nb.minItems,
+			MaxItems: nb.maxItems,
+		})
+	}
+
+	return res
+}
+
+func (b *block) protoV5() *tfprotov5.SchemaBlock {
+	res := &tfprotov5.SchemaBlock{
+		Description:        b.docs.description,
+		DescriptionKind:    stringKindV5(b.docs.markdown),
+		Deprecated:         b.docs.deprecated,
+		DeprecationMessage: b.docs.deprecationMessage,
+	}
+
+	for _, attr := range sortedAttributes(b.attributes) {
+		res.Attributes = append(res.Attributes, attr.protoV5())
+	}
+
+	for _, nb := range sortedBlocks(b.blocks) {
+		res.BlockTypes = append(res.BlockTypes, &tfprotov5.SchemaNestedBlock{
+			TypeName: nb.typeName,
+			Block:    nb.block.protoV5(),
+			Nesting:  blockNestingModeV5(nb.nesting),
+			MinItems: nb.minItems,
+			MaxItems: nb.maxItems,
+		})
+	}
+
+	return res
+}
+
+func (a *AttributeBuilder) protoV6() *tfprotov6.SchemaAttribute {
+	res := &tfprotov6.SchemaAttribute{
+		Name:               a.name,
+		Type:               a.typ,
+		Description:        a.docs.description,
+		Required:           a.required,
+		Optional:           a.optional,
+		Computed:           a.computed,
+		Sensitive:          a.sensitive,
+		DescriptionKind:    stringKindV6(a.docs.markdown),
+		Deprecated:         a.docs.deprecated,
+		WriteOnly:          a.writeOnly,
+		DeprecationMessage: a.docs.deprecationMessage,
+	}
+
+	if a.nesting != nestingModeInvalid {
+		res.NestedType = &tfprotov6.SchemaObject{
+			Nesting: objectNestingModeV6(a.nesting),
+		}
+
+		for _, attr := range sortedAttributes(a.attributes) {
+			res.NestedType.Attributes = append(res.NestedType.Attributes, attr.protoV6())
+		}
+	}
+
+	return res
+}
+
+func (a *AttributeBuilder) protoV5() *tfprotov5.SchemaAttribute {
+	return &tfprotov5.SchemaAttribute{
+		Name:               a.name,
+		Type:               a.typ,
+		Description:        a.docs.description,
+		Required:           a.required,
+		Optional:           a.optional,
+		Computed:           a.computed,
+		Sensitive:          a.sensitive,
+		DescriptionKind:    stringKindV5(a.docs.markdown),
+		Deprecated:         a.docs.deprecated,
+		WriteOnly:          a.writeOnly,
+		DeprecationMessage: a.docs.deprecationMessage,
+	}
+}
+
+func sortedAttributes(attributes []*AttributeBuilder) []*AttributeBuilder {
+	res := append([]*AttributeBuilder(nil), attributes...)
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].name < res[j].name
+	})
+
+	return res
+}
+
+func sortedBlocks(blocks []*NestedBlockBuilder) []*NestedBlockBuilder {
+	res := append([]*NestedBlockBuilder(nil), blocks...)
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].typeName < res[j].typeName
+	})
+
+	return res
+}
+
+func stringKindV6(markdown bool) tfprotov6.StringKind {
+	if markdown {
+		return tfprotov6.StringKindMarkdown
+	}
+
+	return tfprotov6.StringKindPlain
+}
+
+func stringKindV5(markdown bool) tfprotov5.StringKind {
+	if markdown {
+		return tfprotov5.StringKindMarkdown
+	}
+
+	return tfprotov5.StringKindPlain
+}
+
+func blockNestingModeV6(nesting nestingMode) tfprotov6.SchemaNestedBlockNestingMode {
+	switch nesting {
+	case nestingModeSingle:
+		return tfprotov6.SchemaNestedBlockNestingModeSingle
+	case nestingModeList:
+		return tfprotov6.SchemaNestedBlockNestingModeList
+	case nestingModeSet:
+		return tfprotov6.SchemaNestedBlockNestingModeSet
+	case nestingModeMap:
+		return tfprotov6.SchemaNestedBlockNestingModeMap
+	case nestingModeGroup:
+		return tfprotov6.SchemaNestedBlockNestingModeGroup
+	}
+
+	return tfprotov6.SchemaNestedBlockNestingModeInvalid
+}
+
+func blockNestingModeV5(nesting nestingMode) tfprotov5.SchemaNestedBlockNestingMode {
+	switch nesting {
+	case nestingModeSingle:
+		return tfprotov5.SchemaNestedBlockNestingModeSingle
+	case nestingModeList:
+		return tfprotov5.SchemaNestedBlockNestingModeList
+	case nestingModeSet:
+		return tfprotov5.SchemaNestedBlockNestingModeSet
+	case nestingModeMap:
+		return tfprotov5.SchemaNestedBlockNestingModeMap
+	case nestingModeGroup:
+		return tfprotov5.SchemaNestedBlockNestingModeGroup
+	}
+
+	return tfprotov5.SchemaNestedBlockNestingModeInvalid
+}
+
+func objectNestingModeV6(nesting nestingMode) tfprotov6.SchemaObjectNestingMode {
+	switch nesting {
+	case nestingModeSingle:
+		return tfprotov6.SchemaObjectNestingModeSingle
+	case nestingModeList:
+		return tfprotov6.SchemaObjectNestingModeList
+	case nestingModeSet:
+		return tfprotov6.SchemaObjectNestingModeSet
+	case nestingModeMap:
+		return tfprotov6.SchemaObjectNestingModeMap
+	}
+
+	return tfprotov6.SchemaObjectNestingModeInvalid
+}
@@ -0,0 +1,78 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package schemabuilder
+
+import (
+	"errors"
+	"fmt"
+)
+
+// SchemaBuilder builds a schema. Use NewSchema to create one, and ProtoV5 or
+// ProtoV6 to build the schema.
+type SchemaBuilder struct {
+	version int64
+	block   block
+}
+
+// NewSchema returns an empty SchemaBuilder.
+func NewSchema() *SchemaBuilder {
+	return &SchemaBuilder{}
+}
+
+// Version sets the version of the schema, which is used by providers to
+// upgrade state stored with earlier versions. It cannot be negative.
+func (b *SchemaBuilder) Version(version int64) *SchemaBuilder {
+	if version < 0 {
+		b.block.errs = append(b.block.errs, fmt.Errorf("version cannot be negative, got %d", version))
+	}
+
+	b.version = version
+
+	return b
+}
+
+// Attributes adds attributes to the root block of the schema.
+func (b *SchemaBuilder) Attributes(attributes ...*AttributeBuilder) *SchemaBuilder {
+	b.block.addAttributes(attributes)
+
+	return b
+}
+
+// Blocks adds nested blocks to the root block of the schema.
+func (b *SchemaBuilder) Blocks(blocks ...*NestedBlockBuilder) *SchemaBuilder {
+	b.block.addBlocks(blocks)
+
+	return b
+}
+
+// Description sets a plain text description of the schema.
+func (b *SchemaBuilder) Description(description string) *SchemaBuilder {
+	b.block.docs.setDescription(description, false)
+
+	return b
+}
+
+// MarkdownDescription sets a Markdown formatted description of the schema.
+func (b *SchemaBuilder) MarkdownDescription(description string) *SchemaBuilder {
+	b.block.docs.setDescription(description, true)
+
+	return b
+}
+
+// Deprecated marks the schema as deprecated, with a message explaining why
+// and what to use instead. The message may be empty.
+func (b *SchemaBuilder) Deprecated(message string) *SchemaBuilder {
+	b.block.docs.setDeprecated(message)
+
+	return b
+}
+
+// validate returns the errors of the schema joined into one, or nil.
+func (b *SchemaBuilder) validate() error {
+	if b == nil {
+		return errors.New("nil schema builder")
+	}
+
+	return errors.Join(b.block.validate()...)
+}
@@ -0,0 +1,325 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package schemabuilder_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-go/schemabuilder"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSchemaBuilderProtoV6(t *testing.T) {
+	t.Parallel()
+
+	got, err := schemabuilder.NewSchema().
+		Version(2).
+		MarkdownDescription("An *example* resource.").
+		Attributes(
+			schemabuilder.NewAttribute("name", tftypes.String).Required(),
+			schemabuilder.NewAttribute("id", tftypes.String).Computed(),
+			schemabuilder.NewSetNestedAttribute("listeners",
+				schemabuilder.NewAttribute("port", tftypes.Number).Required(),
+				schemabuilder.NewAttribute("certificate", tftypes.String).Optional().Sensitive(),
+			).Optional().Computed(),
+		).
+		Blocks(
+			schemabuilder.NewSingleBlock("timeouts").
+				Attributes(schemabuilder.NewAttribute("create", tftypes.String).Optional()),
+			schemabuilder.NewListBlock("rule").
+				MinItems(1).
+				MaxItems(3).
+				Description("A rule.").
+				Deprecated("Use listeners instead.").
+				Attributes(schemabuilder.NewAttribute("password", tftypes.String).Optional().WriteOnly()),
+		).
+		ProtoV6()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := &tfprotov6.Schema{
+		Version: 2,
+		Block: &tfprotov6.SchemaBlock{
+			Description:     "An *example* resource.",
+			DescriptionKind: tfprotov6.StringKindMarkdown,
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name:     "id",
+					Type:     tftypes.String,
+					Computed: true,
+				},
+				{
+					Name: "listeners",
+					NestedType: &tfprotov6.SchemaObject{
+						Nesting: tfprotov6.SchemaObjectNestingModeSet,
+						Attributes: []*tfprotov6.SchemaAttribute{
+							{
+								Name:      "certificate",
+								Type:      tftypes.String,
+								Optional:  true,
+								Sensitive: true,
+							},
+							{
+								Name:     "port",
+								Type:     tftypes.Number,
+								Required: true,
+							},
+						},
+					},
+					Optional: true,
+					Computed: true,
+				},
+				{
+					Name:     "name",
+					Type:     tftypes.String,
+					Required: true,
+				},
+			},
+			BlockTypes: []*tfprotov6.SchemaNestedBlock{
+				{
+					TypeName: "rule",
+					Nesting:  tfprotov6.SchemaNestedBlockNestingModeList,
+					MinItems: 1,
+					MaxItems: 3,
+					Block: &tfprotov6.SchemaBlock{
+						Description:        "A rule.",
+						Deprecated:         true,
+						DeprecationMessage: "Use listeners instead.",
+						Attributes: []*tfprotov6.SchemaAttribute{
+							{
+								Name:      "password",
+								Type:      tftypes.String,
+								Optional:  true,
+								WriteOnly: true,
+							},
+						},
+					},
+				},
+				{
+					TypeName: "timeouts",
+					Nesting:  tfprotov6.SchemaNestedBlockNestingModeSingle,
+					Block: &tfprotov6.SchemaBlock{
+						Attributes: []*tfprotov6.SchemaAttribute{
+							{
+								Name:     "create",
+								Type:     tftypes.String,
+								Optional: true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+func TestSchemaBuilderProtoV5(t *testing.T) {
+	t.Parallel()
+
+	got, err := schemabuilder.NewSchema().
+		Attributes(
+			schemabuilder.NewAttribute("tags", tftypes.Map{ElementType: tftypes.String}).Optional(),
+			schemabuilder.NewAttribute("id", tftypes.String).Computed().Deprecated(""),
+		).
+		Blocks(
+			schemabuilder.NewMapBlock("profile").
+				Blocks(schemabuilder.NewGroupBlock("settings").
+					Attributes(schemabuilder.NewAttribute("enabled", tftypes.Bool).Optional())),
+		).
+		ProtoV5()
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := &tfprotov5.Schema{
+		Block: &tfprotov5.SchemaBlock{
+			Attributes: []*tfprotov5.SchemaAttribute{
+				{
+					Name:       "id",
+					Type:       tftypes.String,
+					Computed:   true,
+					Deprecated: true,
+				},
+				{
+					Name:     "tags",
+					Type:     tftypes.Map{ElementType: tftypes.String},
+					Optional: true,
+				},
+			},
+			BlockTypes: []*tfprotov5.SchemaNestedBlock{
+				{
+					TypeName: "profile",
+					Nesting:  tfprotov5.SchemaNestedBlockNestingModeMap,
+					Block: &tfprotov5.SchemaBlock{
+						BlockTypes: []*tfprotov5.SchemaNestedBlock{
+							{
+								TypeName: "settings",
+								Nesting:  tfprotov5.SchemaNestedBlockNestingModeGroup,
+								Block: &tfprotov5.SchemaBlock{
+									Attributes: []*tfprotov5.SchemaAttribute{
+										{
+											Name:     "enabled",
+											Type:     tftypes.Bool,
+											Optional: true,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+func TestSchemaBuilderErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		builder         *schemabuilder.SchemaBuilder
+		expectedErrorV6 string
+		expectedErrorV5 string
+	}{
+		"required-computed": {
+			builder: schemabuilder.NewSchema().Attributes(
+				schemabuilder.NewAttribute("id", tftypes.String).Required().Computed(),
+			),
+			expectedErrorV6: `attribute "id": cannot combine Computed with Required`,
+		},
+		"optional-required": {
+			builder: schemabuilder.NewSchema().Attributes(
+				schemabuilder.NewAttribute("id", tftypes.String).Optional().Required(),
+			),
+			expectedErrorV6: `attribute "id": cannot combine Required with Optional or Computed`,
+		},
+		"write-only-computed": {
+			builder: schemabuilder.NewSchema().Attributes(
+				schemabuilder.NewAttribute("secret", tftypes.String).Optional().WriteOnly().Computed(),
+			),
+			expectedErrorV6: `attribute "secret": cannot combine Computed with WriteOnly`,
+		},
+		"missing-required-optional-computed": {
+			builder: schemabuilder.NewSchema().Attributes(
+				schemabuilder.NewAttribute("id", tftypes.String),
+			),
+			expectedErrorV6: `attribute "id": one of Required, Optional, or Computed must be set`,
+		},
+		"missing-type": {
+			builder: schemabuilder.NewSchema().Attributes(
+				schemabuilder.NewAttribute("id", nil).Computed(),
+			),
+			expectedErrorV6: `attribute "id": missing type`,
+		},
+		"invalid-name": {
+			builder: schemabuilder.NewSchema().Blocks(
+				schemabuilder.NewListBlock("Rule"),
+			),
+			expectedErrorV6: `block "Rule": names must be non-empty and contain only lowercase letters, digits, and underscores`,
+		},
+		"duplicate-name": {
+			builder: schemabuilder.NewSchema().
+				Attributes(schemabuilder.NewAttribute("rule", tftypes.String).Optional()).
+				Blocks(schemabuilder.NewListBlock("rule")),
+			expectedErrorV6: `block "rule": duplicate name`,
+		},
+		"duplicate-nested-attribute-name": {
+			builder: schemabuilder.NewSchema().Attributes(
+				schemabuilder.NewListNestedAttribute("listeners",
+					schemabuilder.NewAttribute("port", tftypes.Number).Required(),
+					schemabuilder.NewAttribute("port", tftypes.String).Required(),
+				).Optional(),
+			),
+			expectedErrorV6: `attribute "listeners": attribute "port": duplicate name`,
+		},
+		"nested-block-errors": {
+			builder: schemabuilder.NewSchema().Blocks(
+				schemabuilder.NewSetBlock("rule").
+					MinItems(3).
+					MaxItems(2).
+					Attributes(schemabuilder.NewAttribute("port", tftypes.Number).Required().Optional()),
+			),
+			expectedErrorV6: `block "rule": MinItems (3) cannot be greater than MaxItems (2)` + "\n" +
+				`block "rule": attribute "port": cannot combine Optional with Required`,
+		},
+		"map-block-max-items": {
+			builder: schemabuilder.NewSchema().Blocks(
+				schemabuilder.NewMapBlock("profile").MaxItems(1),
+			),
+			expectedErrorV6: `block "profile": MaxItems can only be used with list, set, and single blocks`,
+		},
+		"single-block-items": {
+			builder: schemabuilder.NewSchema().Blocks(
+				schemabuilder.NewSingleBlock("timeouts").MaxItems(1),
+			),
+			expectedErrorV6: `block "timeouts": MinItems and MaxItems must both be 0 or both be 1 for single blocks`,
+		},
+		"single-block-required": {
+			builder: schemabuilder.NewSchema().Blocks(
+				schemabuilder.NewSingleBlock("timeouts").MinItems(1).MaxItems(1),
+			),
+		},
+		"negative-version": {
+			builder:         schemabuilder.NewSchema().Version(-1),
+			expectedErrorV6: `version cannot be negative, got -1`,
+		},
+		"nested-attribute-v5": {
+			builder: schemabuilder.NewSchema().Blocks(
+				schemabuilder.NewListBlock("rule").Attributes(
+					schemabuilder.NewSingleNestedAttribute("target",
+						schemabuilder.NewAttribute("address", tftypes.String).Required(),
+					).Required(),
+				),
+			),
+			expectedErrorV5: `block "rule": attribute "target": nested attributes require protocol version 6`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := testCase.builder.ProtoV6()
+
+			if diff := cmp.Diff(testCase.expectedErrorV6, errorString(err)); diff != "" {
+				t.Errorf("unexpected ProtoV6 error difference: %s", diff)
+			}
+
+			expectedErrorV5 := testCase.expectedErrorV5
+
+			if expectedErrorV5 == "" {
+				expectedErrorV5 = testCase.expectedErrorV6
+			}
+
+			_, err = testCase.builder.ProtoV5()
+
+			if diff := cmp.Diff(expectedErrorV5, errorString(err)); diff != "" {
+				t.Errorf("unexpected ProtoV5 error difference: %s", diff)
+			}
+		})
+	}
+}
+
+func errorString(err error) string {
+	if err == nil {
+		return ""
+	}
+
+	return err.Error()
+}
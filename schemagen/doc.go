@@ -0,0 +1,31 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package schemagen generates Go types and conversion functions from
+// provider schemas, such as those of a GetProviderSchemaResponse or the
+// output of the `terraform providers schema -json` command.
+//
+// For each resource, data source, ephemeral resource, and action schema,
+// Generate declares a struct type named after the schema and its kind, such
+// as ExampleThingResource for the example_thing resource, with a field for
+// each attribute and nested block. The fields have the `tf` struct tags of the
+// structschema package. Alongside it, Generate declares:
+//
+//   - a function returning the schema, such as ExampleThingResourceSchema
+//   - a function converting a tftypes.Value of the schema into the struct,
+//     such as ExampleThingResourceFromValue
+//   - a ToValue method converting the struct into a tftypes.Value
+//
+// Nested attributes, nested blocks, and object types are declared as further
+// struct types, named after the struct and field they belong to. Resource
+// identity schemas are generated in the same way, with an Identity suffix.
+//
+// Attributes that are not required are pointers, or slices or maps, so that
+// they can be null, as are the elements of lists, sets, and maps. Numbers are *big.Float, to represent them exactly, while
+// dynamic and tuple values are tftypes.Value. The generated types cannot
+// represent unknown values, so they are suited to values that are known, such
+// as state values and configuration values during apply.
+//
+// The cmd/schemagen command generates a file from the output of the
+// `terraform providers schema -json` command.
+package schemagen
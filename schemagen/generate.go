@@ -0,0 +1,860 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package schemagen
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"go/format"
+	"go/token"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Options are the options for Generate.
+type Options struct {
+	// PackageName is the name of the package of the generated code. It is
+	// required.
+	PackageName string
+}
+
+// Generate returns the formatted Go source code of a file with the Go types
+// and conversion functions for `schemas`. See the package documentation for
+// the code generated for each schema.
+func Generate(schemas Schemas, opts Options) ([]byte, error) {
+	if !token.IsIdentifier(opts.PackageName) {
+		return nil, fmt.Errorf("invalid package name %q", opts.PackageName)
+	}
+
+	g := &generator{
+		names:   map[string]string{},
+		imports: map[string]bool{},
+	}
+
+	kinds := []struct {
+		suffix  string
+		kind    string
+		schemas map[string]*tfprotov6.Schema
+	}{
+		{suffix: "Resource", kind: "resource", schemas: schemas.Resources},
+		{suffix: "DataSource", kind: "data source", schemas: schemas.DataSources},
+		{suffix: "EphemeralResource", kind: "ephemeral resource", schemas: schemas.EphemeralResources},
+		{suffix: "Action", kind: "action", schemas: schemas.Actions},
+	}
+
+	for _, k := range kinds {
+		for _, name := range sortedKeys(k.schemas) {
+			if err := g.schema(goName(name)+k.suffix, name+" "+k.kind, k.schemas[name]); err != nil {
+				return nil, fmt.Errorf("%s %s: %w", k.kind, name, err)
+			}
+		}
+	}
+
+	for _, name := range sortedKeys(schemas.Identities) {
+		if err := g.identitySchema(goName(name)+"Identity", name+" resource", schemas.Identities[name]); err != nil {
+			return nil, fmt.Errorf("identity schema of %s: %w", name, err)
+		}
+	}
+
+	var out bytes.Buffer
+
+	out.WriteString("// Code generated by schemagen. DO NOT EDIT.\n\n")
+	fmt.Fprintf(&out, "package %s\n", opts.PackageName)
+
+	if len(g.imports) > 0 {
+		out.WriteString("\nimport (\n")
+
+		// Standard library packages are grouped before other packages.
+		for _, std := range []bool{true, false} {
+			for _, path := range sortedKeys(g.imports) {
+				if !strings.Contains(path, ".") == std {
+					fmt.Fprintf(&out, "\t%q\n", path)
+				}
+			}
+
+			if std {
+				out.WriteString("\n")
+			}
+		}
+
+		out.WriteString(")\n")
+	}
+
+	out.Write(g.out.Bytes())
+
+	src, err := format.Source(out.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("error formatting generated code: %w", err)
+	}
+
+	return src, nil
+}
+
+// generator accumulates the declarations of a generated file.
+type generator struct {
+	out bytes.Buffer
+
+	// names are the declared identifiers, with a description of what they
+	// were declared for, to report conflicts.
+	names map[string]string
+
+	imports map[string]bool
+}
+
+// declare records the identifier `name`, returning an error if it has already
+// been declared.
+func (g *generator) declare(name, what string) error {
+	if name == "" {
+		return fmt.Errorf("%s has no valid Go name", what)
+	}
+
+	if other, ok := g.names[name]; ok {
+		return fmt.Errorf("the Go name %s of %s conflicts with %s", name, what, other)
+	}
+
+	g.names[name] = what
+
+	return nil
+}
+
+func (g *generator) use(path string) string {
+	g.imports[path] = true
+
+	return path[strings.LastIndex(path, "/")+1:]
+}
+
+// schema generates the model struct, schema function, and conversion
+// functions for the schema described by `what`.
+func (g *generator) schema(model, what string, schema *tfprotov6.Schema) error {
+	if schema == nil {
+		schema = &tfprotov6.Schema{}
+	}
+
+	block := schema.Block
+
+	if block == nil {
+		block = &tfprotov6.SchemaBlock{}
+	}
+
+	for _, name := range []string{model + "Schema", model + "FromValue", lowerFirst(model) + "Codec"} {
+		if err := g.declare(name, what); err != nil {
+			return err
+		}
+	}
+
+	var doc strings.Builder
+
+	fmt.Fprintf(&doc, "%s is the value of the %s.", model, what)
+	writeDescription(&doc, block.Description, block.Deprecated, block.DeprecationMessage)
+
+	if err := g.blockStruct(model, what, doc.String(), block.Attributes, block.BlockTypes); err != nil {
+		return err
+	}
+
+	codec := lowerFirst(model) + "Codec"
+
+	fmt.Fprintf(&g.out, "\n// %sSchema returns the schema of the %s.\n", model, what)
+	fmt.Fprintf(&g.out, "func %sSchema() *%s.Schema {\n\treturn ", model, g.use("github.com/hashicorp/terraform-plugin-go/tfprotov6"))
+
+	if err := g.schemaLiteral(schema); err != nil {
+		return err
+	}
+
+	fmt.Fprintf(&g.out, "\n}\n")
+
+	fmt.Fprintf(&g.out, "\nvar %s = %s.NewCodec[%s](%sSchema())\n", codec, g.use("github.com/hashicorp/terraform-plugin-go/structschema"), model, model)
+
+	tftypesPkg := g.use("github.com/hashicorp/terraform-plugin-go/tftypes")
+
+	fmt.Fprintf(&g.out, "\n// %sFromValue returns the %s of `val`, a value of the %s.\n", model, model, what)
+	fmt.Fprintf(&g.out, "func %sFromValue(val %s.Value) (%s, error) {\n", model, tftypesPkg, model)
+	fmt.Fprintf(&g.out, "\tvar res %s\n\n\terr := %s.FromValue(val, &res)\n\n\treturn res, err\n}\n", model, codec)
+
+	fmt.Fprintf(&g.out, "\n// ToValue returns the value of the %s for `m`.\n", what)
+	fmt.Fprintf(&g.out, "func (m %s) ToValue() (%s.Value, error) {\n\treturn %s.ToValue(m)\n}\n", model, tftypesPkg, codec)
+
+	return nil
+}
+
+// identitySchema generates the model struct, schema function, and conversion
+// functions for the identity of the resource described by `what`.
+func (g *generator) identitySchema(model, what string, schema *tfprotov6.ResourceIdentitySchema) error {
+	if schema == nil {
+		schema = &tfprotov6.ResourceIdentitySchema{}
+	}
+
+	for _, name := range []string{model, model + "Schema", model + "FromValue"} {
+		if err := g.declare(name, "the identity of the "+what); err != nil {
+			return err
+		}
+	}
+
+	attributes := make([]*tfprotov6.ResourceIdentitySchemaAttribute, 0, len(schema.IdentityAttributes))
+
+	for _, attr := range schema.IdentityAttributes {
+		if attr != nil {
+			attributes = append(attributes, attr)
+		}
+	}
+
+	sort.Slice(attributes, func(i, j int) bool {
+		return attributes[i].Name < attributes[j].Name
+	})
+
+	var fields bytes.Buffer
+	var nested []func() error
+	fieldNames := map[string]bool{}
+
+	for _, attr := range attributes {
+		field, err := structField(fieldNames, attr.Name)
+		if err != nil {
+			return err
+		}
+
+		goType, next, err := g.goType(attr.Type, !attr.RequiredForImport, model+field)
+		if err != nil {
+			return fmt.Errorf("attribute %q: %w", attr.Name, err)
+		}
+
+		nested = append(nested, next...)
+
+		writeComment(&fields, "\t", strings.TrimSpace(descriptionText(attr.Description, false, "")))
+		fmt.Fprintf(&fields, "\t%s %s `tf:%q`\n", field, goType, attr.Name)
+	}
+
+	fmt.Fprintf(&g.out, "\n// %s is the identity of the %s.\n", model, what)
+	fmt.Fprintf(&g.out, "type %s struct {\n%s}\n", model, fields.String())
+
+	for _, next := range nested {
+		if err := next(); err != nil {
+			return err
+		}
+	}
+
+	tfprotov6Pkg := g.use("github.com/hashicorp/terraform-plugin-go/tfprotov6")
+	tftypesPkg := g.use("github.com/hashicorp/terraform-plugin-go/tftypes")
+
+	fmt.Fprintf(&g.out, "\n// %sSchema returns the identity schema of the %s.\n", model, what)
+	fmt.Fprintf(&g.out, "func %sSchema() *%s.ResourceIdentitySchema {\n\treturn &%s.ResourceIdentitySchema{\n", model, tfprotov6Pkg, tfprotov6Pkg)
+
+	if schema.Version != 0 {
+		fmt.Fprintf(&g.out, "Version: %d,\n", schema.Version)
+	}
+
+	if len(attributes) > 0 {
+		fmt.Fprintf(&g.out, "IdentityAttributes: []*%s.ResourceIdentitySchemaAttribute{\n", tfprotov6Pkg)
+
+		for _, attr := range attributes {
+			typ, err := g.typeLiteral(attr.Type)
+			if err != nil {
+				return fmt.Errorf("attribute %q: %w", attr.Name, err)
+			}
+
+			fmt.Fprintf(&g.out, "{\nName: %q,\nType: %s,\n", attr.Name, typ)
+			writeBoolField(&g.out, "RequiredForImport", attr.RequiredForImport)
+			writeBoolField(&g.out, "OptionalForImport", attr.OptionalForImport)
+			writeStringField(&g.out, "Description", attr.Description)
+			g.out.WriteString("},\n")
+		}
+
+		g.out.WriteString("},\n")
+	}
+
+	g.out.WriteString("}\n}\n")
+
+	fmt.Fprintf(&g.out, "\n// %sFromValue returns the %s of `val`, an identity of the %s.\n", model, model, what)
+	fmt.Fprintf(&g.out, "func %sFromValue(val %s.Value) (%s, error) {\n\treturn %s.GetAs[%s](val, nil)\n}\n", model, tftypesPkg, model, tftypesPkg, model)
+
+	fmt.Fprintf(&g.out, "\n// ToValue returns the identity of the %s for `m`.\n", what)
+	fmt.Fprintf(&g.out, "func (m %s) ToValue() (%s.Value, error) {\n\treturn %s.NewValueFrom(%sSchema().ValueType(), m)\n}\n", model, tftypesPkg, tftypesPkg, model)
+
+	return nil
+}
+
+// blockStruct generates the struct `name` for a block or nested attribute
+// with the attributes and block types, followed by the structs of its nested
+// attributes and blocks.
+func (g *generator) blockStruct(name, what, doc string, attributes []*tfprotov6.SchemaAttribute, blockTypes []*tfprotov6.SchemaNestedBlock) error {
+	if err := g.declare(name, what); err != nil {
+		return err
+	}
+
+	var fields bytes.Buffer
+	var nested []func() error
+	fieldNames := map[string]bool{}
+
+	for _, attr := range sortedAttributes(attributes) {
+		field, err := structField(fieldNames, attr.Name)
+		if err != nil {
+			return err
+		}
+
+		var goType string
+		var options []string
+		attrWhat := fmt.Sprintf("attribute %q of the %s", attr.Name, what)
+
+		if attr.NestedType != nil {
+			elem := name + field
+
+			nested = append(nested, func() error {
+				return g.blockStruct(elem, attrWhat, fmt.Sprintf("%s is an element of the %s.", elem, attrWhat), attr.NestedType.Attributes, nil)
+			})
+
+			options = append(options, "nested")
+
+			switch attr.NestedType.Nesting {
+			case tfprotov6.SchemaObjectNestingModeSingle:
+				goType = "*" + elem
+
+				if attr.Required {
+					goType = elem
+				}
+			case tfprotov6.SchemaObjectNestingModeList:
+				goType = "[]" + elem
+			case tfprotov6.SchemaObjectNestingModeSet:
+				goType = "[]" + elem
+				options = append(options, "set")
+			case tfprotov6.SchemaObjectNestingModeMap:
+				goType = "map[string]" + elem
+			default:
+				return fmt.Errorf("attribute %q: invalid nesting mode %s", attr.Name, attr.NestedType.Nesting)
+			}
+		} else {
+			var next []func() error
+
+			goType, next, err = g.goType(attr.Type, !attr.Required, name+field)
+			if err != nil {
+				return fmt.Errorf("attribute %q: %w", attr.Name, err)
+			}
+
+			nested = append(nested, next...)
+
+			if attr.Type.Is(tftypes.Set{}) {
+				options = append(options, "set")
+			}
+		}
+
+		for _, option := range []struct {
+			name string
+			set  bool
+		}{
+			{name: "required", set: attr.Required},
+			{name: "optional", set: attr.Optional},
+			{name: "computed", set: attr.Computed},
+			{name: "sensitive", set: attr.Sensitive},
+			{name: "write_only", set: attr.WriteOnly},
+		} {
+			if option.set {
+				options = append(options, option.name)
+			}
+		}
+
+		writeComment(&fields, "\t", strings.TrimSpace(descriptionText(attr.Description, attr.Deprecated, attr.DeprecationMessage)))
+		fmt.Fprintf(&fields, "\t%s %s `tf:%q`\n", field, goType, tagValue(attr.Name, options))
+	}
+
+	for _, blockType := range sortedBlockTypes(blockTypes) {
+		field, err := structField(fieldNames, blockType.TypeName)
+		if err != nil {
+			return err
+		}
+
+		elem := name + field
+		options := []string{"block"}
+		blockWhat := fmt.Sprintf("block %q of the %s", blockType.TypeName, what)
+
+		var goType string
+
+		switch blockType.Nesting {
+		case tfprotov6.SchemaNestedBlockNestingModeSingle:
+			goType = "*" + elem
+		case tfprotov6.SchemaNestedBlockNestingModeGroup:
+			goType = elem
+		case tfprotov6.SchemaNestedBlockNestingModeList:
+			goType = "[]" + elem
+		case tfprotov6.SchemaNestedBlockNestingModeSet:
+			goType = "[]" + elem
+			options = append(options, "set")
+		case tfprotov6.SchemaNestedBlockNestingModeMap:
+			goType = "map[string]" + elem
+		default:
+			return fmt.Errorf("block %q: invalid nesting mode %s", blockType.TypeName, blockType.Nesting)
+		}
+
+		if blockType.MinItems != 0 {
+			options = append(options, "min_items="+strconv.FormatInt(blockType.MinItems, 10))
+		}
+
+		if blockType.MaxItems != 0 {
+			options = append(options, "max_items="+strconv.FormatInt(blockType.MaxItems, 10))
+		}
+
+		block := blockType.Block
+
+		if block == nil {
+			block = &tfprotov6.SchemaBlock{}
+		}
+
+		nested = append(nested, func() error {
+			var doc strings.Builder
+
+			fmt.Fprintf(&doc, "%s is an element of the %s.", elem, blockWhat)
+			writeDescription(&doc, block.Description, block.Deprecated, block.DeprecationMessage)
+
+			return g.blockStruct(elem, blockWhat, doc.String(), block.Attributes, block.BlockTypes)
+		})
+
+		writeComment(&fields, "\t", strings.TrimSpace(descriptionText(block.Description, block.Deprecated, block.DeprecationMessage)))
+		fmt.Fprintf(&fields, "\t%s %s `tf:%q`\n", field, goType, tagValue(blockType.TypeName, options))
+	}
+
+	g.out.WriteString("\n")
+	writeComment(&g.out, "", doc)
+	fmt.Fprintf(&g.out, "type %s struct {\n%s}\n", name, fields.String())
+
+	for _, next := range nested {
+		if err := next(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// goType returns the Go type of values of `typ`, which is a pointer if the
+// value is `nullable` and the Go type cannot otherwise be nil. Objects are
+// generated as structs named `name`, by the returned functions.
+func (g *generator) goType(typ tftypes.Type, nullable bool, name string) (string, []func() error, error) {
+	pointer := ""
+
+	if nullable {
+		pointer = "*"
+	}
+
+	switch {
+	case typ == nil:
+		return "", nil, errors.New("missing type")
+	case typ.Is(tftypes.String):
+		return pointer + "string", nil, nil
+	case typ.Is(tftypes.Bool):
+		return pointer + "bool", nil, nil
+	case typ.Is(tftypes.Number):
+		return "*" + g.use("math/big") + ".Float", nil, nil
+	case typ.Is(tftypes.DynamicPseudoType), typ.Is(tftypes.Tuple{}):
+		return g.use("github.com/hashicorp/terraform-plugin-go/tftypes") + ".Value", nil, nil
+	}
+
+	// Elements of collections may be null, as in ["a", null].
+	switch typ := typ.(type) {
+	case tftypes.List:
+		elem, next, err := g.goType(typ.ElementType, true, name)
+		return "[]" + elem, next, err
+	case tftypes.Set:
+		elem, next, err := g.goType(typ.ElementType, true, name)
+		return "[]" + elem, next, err
+	case tftypes.Map:
+		elem, next, err := g.goType(typ.ElementType, true, name)
+		return "map[string]" + elem, next, err
+	case tftypes.Object:
+		next := func() error {
+			return g.objectStruct(name, typ)
+		}
+
+		return pointer + name, []func() error{next}, nil
+	}
+
+	return "", nil, fmt.Errorf("unsupported type %s", typ)
+}
+
+// objectStruct generates the struct `name` for values of the object type
+// `typ`, followed by the structs of any objects within it.
+func (g *generator) objectStruct(name string, typ tftypes.Object) error {
+	if err := g.declare(name, "an object type"); err != nil {
+		return err
+	}
+
+	var fields bytes.Buffer
+	var nested []func() error
+	fieldNames := map[string]bool{}
+
+	for _, attrName := range sortedKeys(typ.AttributeTypes) {
+		field, err := structField(fieldNames, attrName)
+		if err != nil {
+			return err
+		}
+
+		goType, next, err := g.goType(typ.AttributeTypes[attrName], true, name+field)
+		if err != nil {
+			return fmt.Errorf("attribute %q: %w", attrName, err)
+		}
+
+		nested = append(nested, next...)
+
+		fmt.Fprintf(&fields, "\t%s %s `tf:%q`\n", field, goType, attrName)
+	}
+
+	fmt.Fprintf(&g.out, "\n// %s is an object value.\n", name)
+	fmt.Fprintf(&g.out, "type %s struct {\n%s}\n", name, fields.String())
+
+	for _, next := range nested {
+		if err := next(); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// schemaLiteral writes the Go expression of `schema`.
+func (g *generator) schemaLiteral(schema *tfprotov6.Schema) error {
+	pkg := g.use("github.com/hashicorp/terraform-plugin-go/tfprotov6")
+
+	fmt.Fprintf(&g.out, "&%s.Schema{\n", pkg)
+
+	if schema.Version != 0 {
+		fmt.Fprintf(&g.out, "Version: %d,\n", schema.Version)
+	}
+
+	g.out.WriteString("Block: ")
+
+	block := schema.Block
+
+	if block == nil {
+		block = &tfprotov6.SchemaBlock{}
+	}
+
+	if err := g.blockLiteral(block); err != nil {
+		return err
+	}
+
+	g.out.WriteString(",\n}")
+
+	return nil
+}
+
+func (g *generator) blockLiteral(block *tfprotov6.SchemaBlock) error {
+	pkg := g.use("github.com/hashicorp/terraform-plugin-go/tfprotov6")
+
+	fmt.Fprintf(&g.out, "&%s.SchemaBlock{\n", pkg)
+
+	if block.Version != 0 {
+		fmt.Fprintf(&g.out, "Version: %d,\n", block.Version)
+	}
+
+	if attributes := sortedAttributes(block.Attributes); len(attributes) > 0 {
+		fmt.Fprintf(&g.out, "Attributes: []*%s.SchemaAttribute{\n", pkg)
+
+		for _, attr := range attributes {
+			if err := g.attributeLiteral(attr); err != nil {
+				return fmt.Errorf("attribute %q: %w", attr.Name, err)
+			}
+		}
+
+		g.out.WriteString("},\n")
+	}
+
+	if blockTypes := sortedBlockTypes(block.BlockTypes); len(blockTypes) > 0 {
+		fmt.Fprintf(&g.out, "BlockTypes: []*%s.SchemaNestedBlock{\n", pkg)
+
+		for _, blockType := range blockTypes {
+			fmt.Fprintf(&g.out, "{\nTypeName: %q,\nNesting: %s.SchemaNestedBlockNestingMode%s,\n", blockType.TypeName, pkg, titleCase(blockType.Nesting.String()))
+
+			if blockType.MinItems != 0 {
+				fmt.Fprintf(&g.out, "MinItems: %d,\n", blockType.MinItems)
+			}
+
+			if blockType.MaxItems != 0 {
+				fmt.Fprintf(&g.out, "MaxItems: %d,\n", blockType.MaxItems)
+			}
+
+			if blockType.Block != nil {
+				g.out.WriteString("Block: ")
+
+				if err := g.blockLiteral(blockType.Block); err != nil {
+					return fmt.Errorf("block %q: %w", blockType.TypeName, err)
+				}
+
+				g.out.WriteString(",\n")
+			}
+
+			g.out.WriteString("},\n")
+		}
+
+		g.out.WriteString("},\n")
+	}
+
+	writeStringField(&g.out, "Description", block.Description)
+	g.stringKindField(block.Description, block.DescriptionKind)
+	writeBoolField(&g.out, "Deprecated", block.Deprecated)
+	writeStringField(&g.out, "DeprecationMessage", block.DeprecationMessage)
+
+	g.out.WriteString("}")
+
+	return nil
+}
+
+func (g *generator) attributeLiteral(attr *tfprotov6.SchemaAttribute) error {
+	pkg := g.use("github.com/hashicorp/terraform-plugin-go/tfprotov6")
+
+	fmt.Fprintf(&g.out, "{\nName: %q,\n", attr.Name)
+
+	if attr.Type != nil {
+		typ, err := g.typeLiteral(attr.Type)
+		if err != nil {
+			return err
+		}
+
+		fmt.Fprintf(&g.out, "Type: %s,\n", typ)
+	}
+
+	if attr.NestedType != nil {
+		fmt.Fprintf(&g.out, "NestedType: &%s.SchemaObject{\nNesting: %s.SchemaObjectNestingMode%s,\n", pkg, pkg, titleCase(attr.NestedType.Nesting.String()))
+
+		if attributes := sortedAttributes(attr.NestedType.Attributes); len(attributes) > 0 {
+			fmt.Fprintf(&g.out, "Attributes: []*%s.SchemaAttribute{\n", pkg)
+
+			for _, nestedAttr := range attributes {
+				if err := g.attributeLiteral(nestedAttr); err != nil {
+					return fmt.Errorf("attribute %q: %w", nestedAttr.Name, err)
+				}
+			}
+
+			g.out.WriteString("},\n")
+		}
+
+		g.out.WriteString("},\n")
+	}
+
+	writeStringField(&g.out, "Description", attr.Description)
+	g.stringKindField(attr.Description, attr.DescriptionKind)
+	writeBoolField(&g.out, "Required", attr.Required)
+	writeBoolField(&g.out, "Optional", attr.Optional)
+	writeBoolField(&g.out, "Computed", attr.Computed)
+	writeBoolField(&g.out, "Sensitive", attr.Sensitive)
+	writeBoolField(&g.out, "WriteOnly", attr.WriteOnly)
+	writeBoolField(&g.out, "Deprecated", attr.Deprecated)
+	writeStringField(&g.out, "DeprecationMessage", attr.DeprecationMessage)
+
+	g.out.WriteString("},\n")
+
+	return nil
+}
+
+func (g *generator) stringKindField(description string, kind tfprotov6.StringKind) {
+	if description != "" && kind == tfprotov6.StringKindMarkdown {
+		fmt.Fprintf(&g.out, "DescriptionKind: %s.StringKindMarkdown,\n", g.use("github.com/hashicorp/terraform-plugin-go/tfprotov6"))
+	}
+}
+
+// typeLiteral returns the Go expression of `typ`.
+func (g *generator) typeLiteral(typ tftypes.Type) (string, error) {
+	pkg := g.use("github.com/hashicorp/terraform-plugin-go/tftypes")
+
+	switch {
+	case typ == nil:
+		return "", errors.New("missing type")
+	case typ.Is(tftypes.String):
+		return pkg + ".String", nil
+	case typ.Is(tftypes.Number):
+		return pkg + ".Number", nil
+	case typ.Is(tftypes.Bool):
+		return pkg + ".Bool", nil
+	case typ.Is(tftypes.DynamicPseudoType):
+		return pkg + ".DynamicPseudoType", nil
+	}
+
+	switch typ := typ.(type) {
+	case tftypes.List:
+		elem, err := g.typeLiteral(typ.ElementType)
+		return fmt.Sprintf("%s.List{ElementType: %s}", pkg, elem), err
+	case tftypes.Set:
+		elem, err := g.typeLiteral(typ.ElementType)
+		return fmt.Sprintf("%s.Set{ElementType: %s}", pkg, elem), err
+	case tftypes.Map:
+		elem, err := g.typeLiteral(typ.ElementType)
+		return fmt.Sprintf("%s.Map{ElementType: %s}", pkg, elem), err
+	case tftypes.Tuple:
+		elems := make([]string, 0, len(typ.ElementTypes))
+
+		for _, elemType := range typ.ElementTypes {
+			elem, err := g.typeLiteral(elemType)
+			if err != nil {
+				return "", err
+			}
+
+			elems = append(elems, elem)
+		}
+
+		return fmt.Sprintf("%s.Tuple{ElementTypes: []%s.Type{%s}}", pkg, pkg, strings.Join(elems, ", ")), nil
+	case tftypes.Object:
+		attrs := make([]string, 0, len(typ.AttributeTypes))
+
+		for _, name := range sortedKeys(typ.AttributeTypes) {
+			attr, err := g.typeLiteral(typ.AttributeTypes[name])
+			if err != nil {
+				return "", err
+			}
+
+			attrs = append(attrs, fmt.Sprintf("%q: %s", name, attr))
+		}
+
+		res := fmt.Sprintf("%s.Object{AttributeTypes: map[string]%s.Type{%s}", pkg, pkg, strings.Join(attrs, ", "))
+
+		if len(typ.OptionalAttributes) > 0 {
+			optional := make([]string, 0, len(typ.OptionalAttributes))
+
+			for _, name := range sortedKeys(typ.OptionalAttributes) {
+				optional = append(optional, fmt.Sprintf("%q: {}", name))
+			}
+
+			res += fmt.Sprintf(", OptionalAttributes: map[string]struct{}{%s}", strings.Join(optional, ", "))
+		}
+
+		return res + "}", nil
+	}
+
+	return "", fmt.Errorf("unsupported type %s", typ)
+}
+
+// structField returns the Go field name for the attribute or block `name`,
+// recording it in `fieldNames` to detect conflicts.
+func structField(fieldNames map[string]bool, name string) (string, error) {
+	field := goName(name)
+
+	if field == "" {
+		return "", fmt.Errorf("%q has no valid Go name", name)
+	}
+
+	if fieldNames[field] {
+		return "", fmt.Errorf("the Go field name %s of %q conflicts with another attribute or block", field, name)
+	}
+
+	fieldNames[field] = true
+
+	return field, nil
+}
+
+// tagValue returns the `tf` struct tag of a field for the attribute or block
+// `name`, with the structschema options.
+func tagValue(name string, options []string) string {
+	return strings.Join(append([]string{name}, options...), ",")
+}
+
+func sortedAttributes(attributes []*tfprotov6.SchemaAttribute) []*tfprotov6.SchemaAttribute {
+	res := make([]*tfprotov6.SchemaAttribute, 0, len(attributes))
+
+	for _, attr := range attributes {
+		if attr != nil {
+			res = append(res, attr)
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Name < res[j].Name
+	})
+
+	return res
+}
+
+func sortedBlockTypes(blockTypes []*tfprotov6.SchemaNestedBlock) []*tfprotov6.SchemaNestedBlock {
+	res := make([]*tfprotov6.SchemaNestedBlock, 0, len(blockTypes))
+
+	for _, blockType := range blockTypes {
+		if blockType != nil {
+			res = append(res, blockType)
+		}
+	}
+
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].TypeName < res[j].TypeName
+	})
+
+	return res
+}
+
+// descriptionText returns the documentation of an attribute or block for a
+// Go comment, with a Deprecated paragraph if it is deprecated.
+func descriptionText(description string, deprecated bool, deprecationMessage string) string {
+	var b strings.Builder
+
+	writeDescription(&b, description, deprecated, deprecationMessage)
+
+	return b.String()
+}
+
+func writeDescription(b *strings.Builder, description string, deprecated bool, deprecationMessage string) {
+	if description = strings.TrimSpace(description); description != "" {
+		b.WriteString("\n\n")
+		b.WriteString(description)
+	}
+
+	if deprecated {
+		if deprecationMessage == "" {
+			deprecationMessage = "this is deprecated by the provider."
+		}
+
+		b.WriteString("\n\nDeprecated: ")
+		b.WriteString(strings.TrimSpace(deprecationMessage))
+	}
+}
+
+// writeComment writes `text` as a Go line comment, with each line prefixed
+// by `indent`.
+func writeComment(b *bytes.Buffer, indent, text string) {
+	if text == "" {
+		return
+	}
+
+	for _, line := range strings.Split(text, "\n") {
+		line = strings.TrimRightFunc(line, unicode.IsSpace)
+
+		if line == "" {
+			fmt.Fprintf(b, "%s//\n", indent)
+
+			continue
+		}
+
+		fmt.Fprintf(b, "%s// %s\n", indent, line)
+	}
+}
+
+func writeStringField(b *bytes.Buffer, name, value string) {
+	if value != "" {
+		fmt.Fprintf(b, "%s: %s,\n", name, strconv.Quote(value))
+	}
+}
+
+func writeBoolField(b *bytes.Buffer, name string, value bool) {
+	if value {
+		fmt.Fprintf(b, "%s: true,\n", name)
+	}
+}
+
+// titleCase returns the nesting mode name `s`, such as "LIST", as it appears
+// in Go identifiers, such as "List".
+func titleCase(s string) string {
+	if s == "" {
+		return s
+	}
+
+	return s[:1] + strings.ToLower(s[1:])
+}
+
+func lowerFirst(s string) string {
+	runes := []rune(s)
+
+	if len(runes) == 0 {
+		return s
+	}
+
+	runes[0] = unicode.ToLower(runes[0])
+
+	return string(runes)
+}
@@ -0,0 +1,241 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package schemagen_test
+
+import (
+	"flag"
+	"os"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-go/schemagen"
+	"github.com/hashicorp/terraform-plugin-go/schemagen/internal/example"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var update = flag.Bool("update", false, "update the generated code of the example package")
+
+// TestGenerate_example checks that the code in the example package, which is
+// compiled and round trips values in its own tests, is up to date.
+func TestGenerate_example(t *testing.T) {
+	t.Parallel()
+
+	const path = "internal/example/example_generated.go"
+
+	got, err := schemagen.Generate(example.Schemas(), schemagen.Options{PackageName: "example"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if *update {
+		if err := os.WriteFile(path, got, 0o644); err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+	}
+
+	expected, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff(string(expected), string(got)); diff != "" {
+		t.Errorf("%s is out of date, update it with go test -update: %s", path, diff)
+	}
+}
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+
+	schemas := schemagen.Schemas{
+		Resources: map[string]*tfprotov6.Schema{
+			"example_thing": {
+				Block: &tfprotov6.SchemaBlock{
+					Attributes: []*tfprotov6.SchemaAttribute{
+						{
+							Name:        "id",
+							Type:        tftypes.String,
+							Computed:    true,
+							Description: "The ID.",
+						},
+						{
+							Name:     "size",
+							Type:     tftypes.Number,
+							Optional: true,
+						},
+					},
+					BlockTypes: []*tfprotov6.SchemaNestedBlock{
+						{
+							TypeName: "rule",
+							Nesting:  tfprotov6.SchemaNestedBlockNestingModeList,
+							Block: &tfprotov6.SchemaBlock{
+								Attributes: []*tfprotov6.SchemaAttribute{
+									{
+										Name:     "action",
+										Type:     tftypes.String,
+										Required: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	expected := `// Code generated by schemagen. DO NOT EDIT.
+
+package example
+
+import (
+	"math/big"
+
+	"github.com/hashicorp/terraform-plugin-go/structschema"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// ExampleThingResource is the value of the example_thing resource.
+type ExampleThingResource struct {
+	// The ID.
+	ID   *string                    ` + "`" + `tf:"id,computed"` + "`" + `
+	Size *big.Float                 ` + "`" + `tf:"size,optional"` + "`" + `
+	Rule []ExampleThingResourceRule ` + "`" + `tf:"rule,block"` + "`" + `
+}
+
+// ExampleThingResourceRule is an element of the block "rule" of the example_thing resource.
+type ExampleThingResourceRule struct {
+	Action string ` + "`" + `tf:"action,required"` + "`" + `
+}
+
+// ExampleThingResourceSchema returns the schema of the example_thing resource.
+func ExampleThingResourceSchema() *tfprotov6.Schema {
+	return &tfprotov6.Schema{
+		Block: &tfprotov6.SchemaBlock{
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name:        "id",
+					Type:        tftypes.String,
+					Description: "The ID.",
+					Computed:    true,
+				},
+				{
+					Name:     "size",
+					Type:     tftypes.Number,
+					Optional: true,
+				},
+			},
+			BlockTypes: []*tfprotov6.SchemaNestedBlock{
+				{
+					TypeName: "rule",
+					Nesting:  tfprotov6.SchemaNestedBlockNestingModeList,
+					Block: &tfprotov6.SchemaBlock{
+						Attributes: []*tfprotov6.SchemaAttribute{
+							{
+								Name:     "action",
+								Type:     tftypes.String,
+								Required: true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+var exampleThingResourceCodec = structschema.NewCodec[ExampleThingResource](ExampleThingResourceSchema())
+
+// ExampleThingResourceFromValue returns the ExampleThingResource of ` + "`" + `val` + "`" + `, a value of the example_thing resource.
+func ExampleThingResourceFromValue(val tftypes.Value) (ExampleThingResource, error) {
+	var res ExampleThingResource
+
+	err := exampleThingResourceCodec.FromValue(val, &res)
+
+	return res, err
+}
+
+// ToValue returns the value of the example_thing resource for ` + "`" + `m` + "`" + `.
+func (m ExampleThingResource) ToValue() (tftypes.Value, error) {
+	return exampleThingResourceCodec.ToValue(m)
+}
+`
+
+	got, err := schemagen.Generate(schemas, schemagen.Options{PackageName: "example"})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff(expected, string(got)); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+func TestGenerateErrors(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		schemas       schemagen.Schemas
+		opts          schemagen.Options
+		expectedError string
+	}{
+		"invalid-package-name": {
+			opts:          schemagen.Options{PackageName: "example-provider"},
+			expectedError: `invalid package name "example-provider"`,
+		},
+		"model-name-conflict": {
+			schemas: schemagen.Schemas{
+				Resources: map[string]*tfprotov6.Schema{
+					"example_thing":  {Block: &tfprotov6.SchemaBlock{}},
+					"example__thing": {Block: &tfprotov6.SchemaBlock{}},
+				},
+			},
+			opts:          schemagen.Options{PackageName: "example"},
+			expectedError: "resource example_thing: the Go name ExampleThingResourceSchema of example_thing resource conflicts with example__thing resource",
+		},
+		"field-name-conflict": {
+			schemas: schemagen.Schemas{
+				DataSources: map[string]*tfprotov6.Schema{
+					"example_thing": {
+						Block: &tfprotov6.SchemaBlock{
+							Attributes: []*tfprotov6.SchemaAttribute{
+								{
+									Name:     "instance_id",
+									Type:     tftypes.String,
+									Required: true,
+								},
+								{
+									Name:     "instance__id",
+									Type:     tftypes.String,
+									Required: true,
+								},
+							},
+						},
+					},
+				},
+			},
+			opts:          schemagen.Options{PackageName: "example"},
+			expectedError: `data source example_thing: the Go field name InstanceID of "instance_id" conflicts with another attribute or block`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := schemagen.Generate(testCase.schemas, testCase.opts)
+
+			if err == nil {
+				t.Fatalf("expected error %q, got none", testCase.expectedError)
+			}
+
+			if diff := cmp.Diff(testCase.expectedError, err.Error()); diff != "" {
+				t.Errorf("unexpected error difference: %s", diff)
+			}
+		})
+	}
+}
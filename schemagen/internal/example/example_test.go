@@ -0,0 +1,115 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package example_test
+
+import (
+	"testing"
+	"testing/quick"
+
+	"github.com/hashicorp/terraform-plugin-go/schemagen/internal/example"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// knownValues returns a generator of the known values Terraform may send for
+// `schema`, in which required attributes and the elements of nested
+// attributes are never null.
+func knownValues(schema *tfprotov6.Schema) tftypes.ValueGenerator {
+	generator := schema.ValueGenerator()
+	generator.NoUnknowns = true
+
+	nullable := generator.Nullable
+	generator.Nullable = func(path *tftypes.AttributePath) bool {
+		attr, _ := schema.AttributeAtPath(path)
+
+		if attr == nil {
+			return nullable(path)
+		}
+
+		if _, ok := path.LastStep().(tftypes.AttributeName); ok {
+			return !attr.Required
+		}
+
+		// Elements of nested attributes are never null, unlike the
+		// elements of collections within their attributes.
+		return attr.NestedType == nil || path.WithoutLastStep().LastStep() != tftypes.AttributeName(attr.Name)
+	}
+
+	return generator
+}
+
+func checkRoundTrip(t *testing.T, generator tftypes.ValueGenerator, roundTrip func(tftypes.Value) (tftypes.Value, error)) {
+	t.Helper()
+
+	err := quick.Check(func(val tftypes.Value) bool {
+		got, err := roundTrip(val)
+		if err != nil {
+			t.Logf("unexpected error for %s: %s", val, err)
+
+			return false
+		}
+
+		if !got.Equal(val) {
+			t.Logf("expected %s, got %s", val, got)
+
+			return false
+		}
+
+		return true
+	}, &quick.Config{
+		MaxCount: 500,
+		Values:   generator.QuickValues,
+	})
+
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestExampleThingResourceRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	checkRoundTrip(t, knownValues(example.ExampleThingResourceSchema()), func(val tftypes.Value) (tftypes.Value, error) {
+		m, err := example.ExampleThingResourceFromValue(val)
+		if err != nil {
+			return tftypes.Value{}, err
+		}
+
+		return m.ToValue()
+	})
+}
+
+func TestExampleThingDataSourceRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	checkRoundTrip(t, knownValues(example.ExampleThingDataSourceSchema()), func(val tftypes.Value) (tftypes.Value, error) {
+		m, err := example.ExampleThingDataSourceFromValue(val)
+		if err != nil {
+			return tftypes.Value{}, err
+		}
+
+		return m.ToValue()
+	})
+}
+
+func TestExampleThingIdentityRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	generator := tftypes.ValueGenerator{
+		Type:       example.ExampleThingIdentitySchema().ValueType(),
+		NoUnknowns: true,
+		Nullable: func(path *tftypes.AttributePath) bool {
+			return path.Equal(tftypes.NewAttributePath().WithAttributeName("region"))
+		},
+	}
+
+	checkRoundTrip(t, generator, func(val tftypes.Value) (tftypes.Value, error) {
+		m, err := example.ExampleThingIdentityFromValue(val)
+		if err != nil {
+			return tftypes.Value{}, err
+		}
+
+		return m.ToValue()
+	})
+}
@@ -0,0 +1,189 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package example contains the code generated by schemagen for the schemas
+// of Schemas, which use every kind of attribute and nested block, so that the
+// generated code is compiled and tested.
+package example
+
+import (
+	"github.com/hashicorp/terraform-plugin-go/schemagen"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Schemas returns the schemas the code in example_generated.go was generated
+// from.
+func Schemas() schemagen.Schemas {
+	endpointAttributes := []*tfprotov6.SchemaAttribute{
+		{
+			Name:     "host",
+			Type:     tftypes.String,
+			Required: true,
+		},
+		{
+			Name:     "port",
+			Type:     tftypes.Number,
+			Optional: true,
+		},
+	}
+
+	nestedAttribute := func(name string, nesting tfprotov6.SchemaObjectNestingMode) *tfprotov6.SchemaAttribute {
+		return &tfprotov6.SchemaAttribute{
+			Name: name,
+			NestedType: &tfprotov6.SchemaObject{
+				Nesting:    nesting,
+				Attributes: endpointAttributes,
+			},
+			Optional: true,
+		}
+	}
+
+	nestedBlock := func(name string, nesting tfprotov6.SchemaNestedBlockNestingMode) *tfprotov6.SchemaNestedBlock {
+		return &tfprotov6.SchemaNestedBlock{
+			TypeName: name,
+			Nesting:  nesting,
+			Block: &tfprotov6.SchemaBlock{
+				Attributes: []*tfprotov6.SchemaAttribute{
+					{
+						Name:     "action",
+						Type:     tftypes.String,
+						Optional: true,
+					},
+					{
+						Name:     "ports",
+						Type:     tftypes.List{ElementType: tftypes.Number},
+						Optional: true,
+					},
+				},
+				BlockTypes: []*tfprotov6.SchemaNestedBlock{
+					{
+						TypeName: "target",
+						Nesting:  tfprotov6.SchemaNestedBlockNestingModeSet,
+						Block: &tfprotov6.SchemaBlock{
+							Attributes: []*tfprotov6.SchemaAttribute{
+								{
+									Name:     "address",
+									Type:     tftypes.String,
+									Required: true,
+								},
+							},
+						},
+					},
+				},
+			},
+		}
+	}
+
+	return schemagen.Schemas{
+		Resources: map[string]*tfprotov6.Schema{
+			"example_thing": {
+				Block: &tfprotov6.SchemaBlock{
+					Attributes: []*tfprotov6.SchemaAttribute{
+						{
+							Name:     "id",
+							Type:     tftypes.String,
+							Computed: true,
+						},
+						{
+							Name:     "name",
+							Type:     tftypes.String,
+							Required: true,
+						},
+						{
+							Name:     "enabled",
+							Type:     tftypes.Bool,
+							Optional: true,
+						},
+						{
+							Name:     "size",
+							Type:     tftypes.Number,
+							Optional: true,
+						},
+						{
+							Name:     "names",
+							Type:     tftypes.List{ElementType: tftypes.String},
+							Optional: true,
+						},
+						{
+							Name:     "numbers",
+							Type:     tftypes.Set{ElementType: tftypes.Number},
+							Optional: true,
+						},
+						{
+							Name:     "flags",
+							Type:     tftypes.Map{ElementType: tftypes.Bool},
+							Optional: true,
+						},
+						{
+							Name: "settings",
+							Type: tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+								"mode":  tftypes.String,
+								"sizes": tftypes.List{ElementType: tftypes.Number},
+							}},
+							Optional: true,
+						},
+						{
+							Name: "profiles",
+							Type: tftypes.List{ElementType: tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+								"name": tftypes.String,
+							}}},
+							Optional: true,
+						},
+						{
+							Name:     "extra",
+							Type:     tftypes.DynamicPseudoType,
+							Optional: true,
+						},
+						{
+							Name:     "pair",
+							Type:     tftypes.Tuple{ElementTypes: []tftypes.Type{tftypes.String, tftypes.Number}},
+							Optional: true,
+						},
+						nestedAttribute("endpoint", tfprotov6.SchemaObjectNestingModeSingle),
+						nestedAttribute("endpoint_list", tfprotov6.SchemaObjectNestingModeList),
+						nestedAttribute("endpoint_set", tfprotov6.SchemaObjectNestingModeSet),
+						nestedAttribute("endpoint_map", tfprotov6.SchemaObjectNestingModeMap),
+					},
+					BlockTypes: []*tfprotov6.SchemaNestedBlock{
+						nestedBlock("single", tfprotov6.SchemaNestedBlockNestingModeSingle),
+						nestedBlock("group", tfprotov6.SchemaNestedBlockNestingModeGroup),
+						nestedBlock("rule", tfprotov6.SchemaNestedBlockNestingModeList),
+						nestedBlock("rule_set", tfprotov6.SchemaNestedBlockNestingModeSet),
+						nestedBlock("rule_map", tfprotov6.SchemaNestedBlockNestingModeMap),
+					},
+				},
+			},
+		},
+		DataSources: map[string]*tfprotov6.Schema{
+			"example_thing": {
+				Block: &tfprotov6.SchemaBlock{
+					Attributes: []*tfprotov6.SchemaAttribute{
+						{
+							Name:     "id",
+							Type:     tftypes.String,
+							Required: true,
+						},
+						nestedAttribute("endpoints", tfprotov6.SchemaObjectNestingModeList),
+					},
+				},
+			},
+		},
+		Identities: map[string]*tfprotov6.ResourceIdentitySchema{
+			"example_thing": {
+				IdentityAttributes: []*tfprotov6.ResourceIdentitySchemaAttribute{
+					{
+						Name:              "id",
+						Type:              tftypes.String,
+						RequiredForImport: true,
+					},
+					{
+						Name:              "region",
+						Type:              tftypes.String,
+						OptionalForImport: true,
+					},
+				},
+			},
+		},
+	}
+}
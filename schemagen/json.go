@@ -0,0 +1,373 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package schemagen
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// SchemasFromJSON returns the Schemas of the provider with the address
+// `providerAddress` in `data`, the output of the `terraform providers schema
+// -json` command. The address may be the full provider source address, such
+// as "registry.terraform.io/hashicorp/random", or a suffix of it, such as
+// "hashicorp/random". It may be empty if `data` has a single provider.
+func SchemasFromJSON(data []byte, providerAddress string) (Schemas, error) {
+	var doc jsonProviderSchemas
+
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return Schemas{}, fmt.Errorf("error parsing provider schemas JSON: %w", err)
+	}
+
+	provider, err := doc.provider(providerAddress)
+	if err != nil {
+		return Schemas{}, err
+	}
+
+	var res Schemas
+
+	if res.Resources, err = jsonSchemas("resource", provider.ResourceSchemas); err != nil {
+		return Schemas{}, err
+	}
+
+	if res.DataSources, err = jsonSchemas("data source", provider.DataSourceSchemas); err != nil {
+		return Schemas{}, err
+	}
+
+	if res.EphemeralResources, err = jsonSchemas("ephemeral resource", provider.EphemeralResourceSchemas); err != nil {
+		return Schemas{}, err
+	}
+
+	if res.Actions, err = jsonSchemas("action", provider.ActionSchemas); err != nil {
+		return Schemas{}, err
+	}
+
+	for name, identity := range provider.ResourceIdentitySchemas {
+		schema, err := identity.proto()
+		if err != nil {
+			return Schemas{}, fmt.Errorf("identity schema of %s: %w", name, err)
+		}
+
+		if res.Identities == nil {
+			res.Identities = map[string]*tfprotov6.ResourceIdentitySchema{}
+		}
+
+		res.Identities[name] = schema
+	}
+
+	return res, nil
+}
+
+func jsonSchemas(kind string, schemas map[string]*jsonSchema) (map[string]*tfprotov6.Schema, error) {
+	if len(schemas) == 0 {
+		return nil, nil
+	}
+
+	res := make(map[string]*tfprotov6.Schema, len(schemas))
+
+	for name, schema := range schemas {
+		s, err := schema.proto()
+		if err != nil {
+			return nil, fmt.Errorf("%s %s: %w", kind, name, err)
+		}
+
+		res[name] = s
+	}
+
+	return res, nil
+}
+
+// jsonProviderSchemas is the output of the `terraform providers schema -json`
+// command.
+type jsonProviderSchemas struct {
+	FormatVersion   string                         `json:"format_version"`
+	ProviderSchemas map[string]*jsonProviderSchema `json:"provider_schemas"`
+}
+
+func (d jsonProviderSchemas) provider(address string) (*jsonProviderSchema, error) {
+	addresses := make([]string, 0, len(d.ProviderSchemas))
+
+	for addr := range d.ProviderSchemas {
+		addresses = append(addresses, addr)
+	}
+
+	sort.Strings(addresses)
+
+	var matches []string
+
+	for _, addr := range addresses {
+		if address == "" || addr == address || strings.HasSuffix(addr, "/"+address) {
+			matches = append(matches, addr)
+		}
+	}
+
+	switch {
+	case len(matches) == 1:
+		return d.ProviderSchemas[matches[0]], nil
+	case address == "":
+		return nil, fmt.Errorf("a provider address is required, as the JSON has schemas for %d providers: %s", len(addresses), strings.Join(addresses, ", "))
+	case len(matches) == 0:
+		return nil, fmt.Errorf("no schemas for provider %q, the JSON has schemas for: %s", address, strings.Join(addresses, ", "))
+	}
+
+	return nil, fmt.Errorf("provider address %q is ambiguous, it matches: %s", address, strings.Join(matches, ", "))
+}
+
+type jsonProviderSchema struct {
+	ResourceSchemas          map[string]*jsonSchema         `json:"resource_schemas"`
+	DataSourceSchemas        map[string]*jsonSchema         `json:"data_source_schemas"`
+	EphemeralResourceSchemas map[string]*jsonSchema         `json:"ephemeral_resource_schemas"`
+	ActionSchemas            map[string]*jsonSchema         `json:"action_schemas"`
+	ResourceIdentitySchemas  map[string]*jsonIdentitySchema `json:"resource_identity_schemas"`
+}
+
+type jsonSchema struct {
+	Version int64      `json:"version"`
+	Block   *jsonBlock `json:"block"`
+}
+
+func (s *jsonSchema) proto() (*tfprotov6.Schema, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	block, err := s.Block.proto()
+	if err != nil {
+		return nil, err
+	}
+
+	return &tfprotov6.Schema{
+		Version: s.Version,
+		Block:   block,
+	}, nil
+}
+
+type jsonBlock struct {
+	Attributes      map[string]*jsonAttribute `json:"attributes"`
+	BlockTypes      map[string]*jsonBlockType `json:"block_types"`
+	Description     string                    `json:"description"`
+	DescriptionKind string                    `json:"description_kind"`
+	Deprecated      bool                      `json:"deprecated"`
+}
+
+func (b *jsonBlock) proto() (*tfprotov6.SchemaBlock, error) {
+	res := &tfprotov6.SchemaBlock{}
+
+	if b == nil {
+		return res, nil
+	}
+
+	res.Description = b.Description
+	res.DescriptionKind = jsonStringKind(b.DescriptionKind)
+	res.Deprecated = b.Deprecated
+
+	attributes, err := jsonAttributes(b.Attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	res.Attributes = attributes
+
+	for _, name := range sortedKeys(b.BlockTypes) {
+		blockType := b.BlockTypes[name]
+
+		if blockType == nil {
+			continue
+		}
+
+		block, err := blockType.Block.proto()
+		if err != nil {
+			return nil, fmt.Errorf("block %q: %w", name, err)
+		}
+
+		nesting, ok := jsonBlockNestingModes[blockType.NestingMode]
+		if !ok {
+			return nil, fmt.Errorf("block %q: unknown nesting mode %q", name, blockType.NestingMode)
+		}
+
+		res.BlockTypes = append(res.BlockTypes, &tfprotov6.SchemaNestedBlock{
+			TypeName: name,
+			Block:    block,
+			Nesting:  nesting,
+			MinItems: blockType.MinItems,
+			MaxItems: blockType.MaxItems,
+		})
+	}
+
+	return res, nil
+}
+
+type jsonBlockType struct {
+	NestingMode string     `json:"nesting_mode"`
+	Block       *jsonBlock `json:"block"`
+	MinItems    int64      `json:"min_items"`
+	MaxItems    int64      `json:"max_items"`
+}
+
+var jsonBlockNestingModes = map[string]tfprotov6.SchemaNestedBlockNestingMode{
+	"single": tfprotov6.SchemaNestedBlockNestingModeSingle,
+	"group":  tfprotov6.SchemaNestedBlockNestingModeGroup,
+	"list":   tfprotov6.SchemaNestedBlockNestingModeList,
+	"set":    tfprotov6.SchemaNestedBlockNestingModeSet,
+	"map":    tfprotov6.SchemaNestedBlockNestingModeMap,
+}
+
+type jsonAttribute struct {
+	Type            json.RawMessage `json:"type"`
+	NestedType      *jsonNestedType `json:"nested_type"`
+	Description     string          `json:"description"`
+	DescriptionKind string          `json:"description_kind"`
+	Deprecated      bool            `json:"deprecated"`
+	Required        bool            `json:"required"`
+	Optional        bool            `json:"optional"`
+	Computed        bool            `json:"computed"`
+	Sensitive       bool            `json:"sensitive"`
+	WriteOnly       bool            `json:"write_only"`
+}
+
+func jsonAttributes(attributes map[string]*jsonAttribute) ([]*tfprotov6.SchemaAttribute, error) {
+	var res []*tfprotov6.SchemaAttribute
+
+	for _, name := range sortedKeys(attributes) {
+		attr := attributes[name]
+
+		if attr == nil {
+			continue
+		}
+
+		a := &tfprotov6.SchemaAttribute{
+			Name:            name,
+			Description:     attr.Description,
+			DescriptionKind: jsonStringKind(attr.DescriptionKind),
+			Deprecated:      attr.Deprecated,
+			Required:        attr.Required,
+			Optional:        attr.Optional,
+			Computed:        attr.Computed,
+			Sensitive:       attr.Sensitive,
+			WriteOnly:       attr.WriteOnly,
+		}
+
+		switch {
+		case attr.NestedType != nil:
+			nested, err := attr.NestedType.proto()
+			if err != nil {
+				return nil, fmt.Errorf("attribute %q: %w", name, err)
+			}
+
+			a.NestedType = nested
+		case len(attr.Type) > 0:
+			typ, err := tftypes.ParseJSONType(attr.Type) //nolint:staticcheck
+			if err != nil {
+				return nil, fmt.Errorf("attribute %q: %w", name, err)
+			}
+
+			a.Type = typ
+		default:
+			return nil, fmt.Errorf("attribute %q: missing type", name)
+		}
+
+		res = append(res, a)
+	}
+
+	return res, nil
+}
+
+type jsonNestedType struct {
+	Attributes  map[string]*jsonAttribute `json:"attributes"`
+	NestingMode string                    `json:"nesting_mode"`
+}
+
+var jsonObjectNestingModes = map[string]tfprotov6.SchemaObjectNestingMode{
+	"single": tfprotov6.SchemaObjectNestingModeSingle,
+	"list":   tfprotov6.SchemaObjectNestingModeList,
+	"set":    tfprotov6.SchemaObjectNestingModeSet,
+	"map":    tfprotov6.SchemaObjectNestingModeMap,
+}
+
+func (t *jsonNestedType) proto() (*tfprotov6.SchemaObject, error) {
+	nesting, ok := jsonObjectNestingModes[t.NestingMode]
+	if !ok {
+		return nil, fmt.Errorf("unknown nesting mode %q", t.NestingMode)
+	}
+
+	attributes, err := jsonAttributes(t.Attributes)
+	if err != nil {
+		return nil, err
+	}
+
+	return &tfprotov6.SchemaObject{
+		Attributes: attributes,
+		Nesting:    nesting,
+	}, nil
+}
+
+type jsonIdentitySchema struct {
+	Version    int64                                   `json:"version"`
+	Attributes map[string]*jsonIdentitySchemaAttribute `json:"attributes"`
+}
+
+type jsonIdentitySchemaAttribute struct {
+	Type              json.RawMessage `json:"type"`
+	Description       string          `json:"description"`
+	RequiredForImport bool            `json:"required_for_import"`
+	OptionalForImport bool            `json:"optional_for_import"`
+}
+
+func (s *jsonIdentitySchema) proto() (*tfprotov6.ResourceIdentitySchema, error) {
+	if s == nil {
+		return nil, nil
+	}
+
+	res := &tfprotov6.ResourceIdentitySchema{
+		Version: s.Version,
+	}
+
+	for _, name := range sortedKeys(s.Attributes) {
+		attr := s.Attributes[name]
+
+		if attr == nil {
+			continue
+		}
+
+		typ, err := tftypes.ParseJSONType(attr.Type) //nolint:staticcheck
+		if err != nil {
+			return nil, fmt.Errorf("attribute %q: %w", name, err)
+		}
+
+		res.IdentityAttributes = append(res.IdentityAttributes, &tfprotov6.ResourceIdentitySchemaAttribute{
+			Name:              name,
+			Type:              typ,
+			RequiredForImport: attr.RequiredForImport,
+			OptionalForImport: attr.OptionalForImport,
+			Description:       attr.Description,
+		})
+	}
+
+	return res, nil
+}
+
+func jsonStringKind(kind string) tfprotov6.StringKind {
+	if kind == "markdown" {
+		return tfprotov6.StringKindMarkdown
+	}
+
+	return tfprotov6.StringKindPlain
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+
+	for key := range m {
+		keys = append(keys, key)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
@@ -0,0 +1,241 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package schemagen_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-go/schemagen"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+const testSchemasJSON = `{
+	"format_version": "1.0",
+	"provider_schemas": {
+		"registry.terraform.io/example/example": {
+			"provider": {"version": 0, "block": {}},
+			"resource_schemas": {
+				"example_thing": {
+					"version": 1,
+					"block": {
+						"attributes": {
+							"id": {"type": "string", "computed": true, "description": "The ID.", "description_kind": "plain"},
+							"tags": {"type": ["map", "string"], "optional": true},
+							"listeners": {
+								"nested_type": {
+									"nesting_mode": "set",
+									"attributes": {
+										"port": {"type": "number", "required": true}
+									}
+								},
+								"optional": true
+							}
+						},
+						"block_types": {
+							"rule": {
+								"nesting_mode": "list",
+								"max_items": 3,
+								"block": {
+									"attributes": {
+										"action": {"type": "string", "required": true, "deprecated": true}
+									},
+									"description": "A **rule**.",
+									"description_kind": "markdown"
+								}
+							}
+						}
+					}
+				}
+			},
+			"data_source_schemas": {
+				"example_thing": {"version": 0, "block": {"attributes": {"id": {"type": "string", "required": true}}}}
+			},
+			"action_schemas": {
+				"example_restart": {"block": {"attributes": {"force": {"type": "bool", "optional": true}}}}
+			},
+			"resource_identity_schemas": {
+				"example_thing": {
+					"version": 0,
+					"attributes": {
+						"id": {"type": "string", "required_for_import": true}
+					}
+				}
+			}
+		},
+		"registry.terraform.io/hashicorp/random": {
+			"provider": {"version": 0, "block": {}}
+		}
+	}
+}`
+
+func TestSchemasFromJSON(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		data            string
+		providerAddress string
+		expected        schemagen.Schemas
+		expectedError   string
+	}{
+		"full-address": {
+			data:            testSchemasJSON,
+			providerAddress: "registry.terraform.io/example/example",
+			expected:        testSchemas(),
+		},
+		"short-address": {
+			data:            testSchemasJSON,
+			providerAddress: "example/example",
+			expected:        testSchemas(),
+		},
+		"single-provider": {
+			data: `{"format_version": "1.0", "provider_schemas": {"registry.terraform.io/hashicorp/random": {}}}`,
+		},
+		"missing-address": {
+			data:          testSchemasJSON,
+			expectedError: "a provider address is required, as the JSON has schemas for 2 providers: registry.terraform.io/example/example, registry.terraform.io/hashicorp/random",
+		},
+		"unknown-provider": {
+			data:            testSchemasJSON,
+			providerAddress: "hashicorp/aws",
+			expectedError:   `no schemas for provider "hashicorp/aws", the JSON has schemas for: registry.terraform.io/example/example, registry.terraform.io/hashicorp/random`,
+		},
+		"invalid-nesting-mode": {
+			data: `{"provider_schemas": {"example": {"resource_schemas": {"example_thing": {"block": {
+				"block_types": {"rule": {"nesting_mode": "tuple", "block": {}}}
+			}}}}}}`,
+			expectedError: `resource example_thing: block "rule": unknown nesting mode "tuple"`,
+		},
+		"invalid-json": {
+			data:          `{`,
+			expectedError: "error parsing provider schemas JSON: unexpected end of JSON input",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := schemagen.SchemasFromJSON([]byte(testCase.data), testCase.providerAddress)
+
+			if err != nil {
+				if testCase.expectedError == "" {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if diff := cmp.Diff(testCase.expectedError, err.Error()); diff != "" {
+					t.Fatalf("unexpected error difference: %s", diff)
+				}
+
+				return
+			}
+
+			if testCase.expectedError != "" {
+				t.Fatalf("expected error %q, got none", testCase.expectedError)
+			}
+
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+// testSchemas returns the Schemas of the example provider in testSchemasJSON.
+func testSchemas() schemagen.Schemas {
+	return schemagen.Schemas{
+		Resources: map[string]*tfprotov6.Schema{
+			"example_thing": {
+				Version: 1,
+				Block: &tfprotov6.SchemaBlock{
+					Attributes: []*tfprotov6.SchemaAttribute{
+						{
+							Name:        "id",
+							Type:        tftypes.String,
+							Computed:    true,
+							Description: "The ID.",
+						},
+						{
+							Name: "listeners",
+							NestedType: &tfprotov6.SchemaObject{
+								Nesting: tfprotov6.SchemaObjectNestingModeSet,
+								Attributes: []*tfprotov6.SchemaAttribute{
+									{
+										Name:     "port",
+										Type:     tftypes.Number,
+										Required: true,
+									},
+								},
+							},
+							Optional: true,
+						},
+						{
+							Name:     "tags",
+							Type:     tftypes.Map{ElementType: tftypes.String},
+							Optional: true,
+						},
+					},
+					BlockTypes: []*tfprotov6.SchemaNestedBlock{
+						{
+							TypeName: "rule",
+							Nesting:  tfprotov6.SchemaNestedBlockNestingModeList,
+							MaxItems: 3,
+							Block: &tfprotov6.SchemaBlock{
+								Description:     "A **rule**.",
+								DescriptionKind: tfprotov6.StringKindMarkdown,
+								Attributes: []*tfprotov6.SchemaAttribute{
+									{
+										Name:       "action",
+										Type:       tftypes.String,
+										Required:   true,
+										Deprecated: true,
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		DataSources: map[string]*tfprotov6.Schema{
+			"example_thing": {
+				Block: &tfprotov6.SchemaBlock{
+					Attributes: []*tfprotov6.SchemaAttribute{
+						{
+							Name:     "id",
+							Type:     tftypes.String,
+							Required: true,
+						},
+					},
+				},
+			},
+		},
+		Actions: map[string]*tfprotov6.Schema{
+			"example_restart": {
+				Block: &tfprotov6.SchemaBlock{
+					Attributes: []*tfprotov6.SchemaAttribute{
+						{
+							Name:     "force",
+							Type:     tftypes.Bool,
+							Optional: true,
+						},
+					},
+				},
+			},
+		},
+		Identities: map[string]*tfprotov6.ResourceIdentitySchema{
+			"example_thing": {
+				IdentityAttributes: []*tfprotov6.ResourceIdentitySchemaAttribute{
+					{
+						Name:              "id",
+						Type:              tftypes.String,
+						RequiredForImport: true,
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,66 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package schemagen
+
+import (
+	"strings"
+	"unicode"
+)
+
+// initialisms are the parts of names that are written in upper case in Go
+// identifiers, following the Go naming conventions.
+var initialisms = map[string]bool{
+	"acl":   true,
+	"api":   true,
+	"arn":   true,
+	"cidr":  true,
+	"cpu":   true,
+	"dns":   true,
+	"http":  true,
+	"https": true,
+	"id":    true,
+	"ip":    true,
+	"json":  true,
+	"tls":   true,
+	"ttl":   true,
+	"uri":   true,
+	"url":   true,
+	"uuid":  true,
+	"vpc":   true,
+}
+
+// goName returns the exported Go identifier for the attribute, block, or
+// schema name `name`, by converting it from snake case to camel case, as in
+// "instance_id" to "InstanceID". Characters that are not valid in Go
+// identifiers separate words, and names that would start with a digit are
+// prefixed with "X". It returns an empty string if `name` has no characters
+// that are valid in Go identifiers.
+func goName(name string) string {
+	words := strings.FieldsFunc(name, func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+
+	var b strings.Builder
+
+	for _, word := range words {
+		if initialisms[strings.ToLower(word)] {
+			b.WriteString(strings.ToUpper(word))
+
+			continue
+		}
+
+		runes := []rune(word)
+		runes[0] = unicode.ToUpper(runes[0])
+
+		b.WriteString(string(runes))
+	}
+
+	res := b.String()
+
+	if res != "" && unicode.IsDigit([]rune(res)[0]) {
+		res = "X" + res
+	}
+
+	return res
+}
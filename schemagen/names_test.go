@@ -0,0 +1,62 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package schemagen
+
+import (
+	"testing"
+)
+
+func TestGoName(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		name     string
+		expected string
+	}{
+		"single-word": {
+			name:     "name",
+			expected: "Name",
+		},
+		"snake-case": {
+			name:     "example_thing",
+			expected: "ExampleThing",
+		},
+		"initialism": {
+			name:     "instance_id",
+			expected: "InstanceID",
+		},
+		"initialisms": {
+			name:     "api_url",
+			expected: "APIURL",
+		},
+		"leading-digit": {
+			name:     "3d_model",
+			expected: "X3dModel",
+		},
+		"separators": {
+			name:     "my-thing.v2",
+			expected: "MyThingV2",
+		},
+		"repeated-underscores": {
+			name:     "a__b_",
+			expected: "AB",
+		},
+		"invalid": {
+			name:     "__",
+			expected: "",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := goName(testCase.name)
+
+			if got != testCase.expected {
+				t.Errorf("expected %q, got %q", testCase.expected, got)
+			}
+		})
+	}
+}
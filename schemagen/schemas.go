@@ -0,0 +1,58 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package schemagen
+
+import (
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+)
+
+// Schemas are the schemas of a provider to generate Go types for, by the
+// name of the resource, data source, ephemeral resource, or action.
+type Schemas struct {
+	// Resources are the schemas of managed resources.
+	Resources map[string]*tfprotov6.Schema
+
+	// DataSources are the schemas of data sources.
+	DataSources map[string]*tfprotov6.Schema
+
+	// EphemeralResources are the schemas of ephemeral resources.
+	EphemeralResources map[string]*tfprotov6.Schema
+
+	// Actions are the schemas of actions.
+	Actions map[string]*tfprotov6.Schema
+
+	// Identities are the identity schemas of managed resources.
+	Identities map[string]*tfprotov6.ResourceIdentitySchema
+}
+
+// SchemasFromResponse returns the Schemas of the GetProviderSchema and
+// GetResourceIdentitySchemas RPC responses of a provider. Either response may
+// be nil.
+func SchemasFromResponse(resp *tfprotov6.GetProviderSchemaResponse, identityResp *tfprotov6.GetResourceIdentitySchemasResponse) Schemas {
+	var res Schemas
+
+	if resp != nil {
+		res.Resources = resp.ResourceSchemas
+		res.DataSources = resp.DataSourceSchemas
+		res.EphemeralResources = resp.EphemeralResourceSchemas
+
+		for name, action := range resp.ActionSchemas {
+			if action == nil {
+				continue
+			}
+
+			if res.Actions == nil {
+				res.Actions = map[string]*tfprotov6.Schema{}
+			}
+
+			res.Actions[name] = action.Schema
+		}
+	}
+
+	if identityResp != nil {
+		res.Identities = identityResp.IdentitySchemas
+	}
+
+	return res
+}
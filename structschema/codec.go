@@ -0,0 +1,113 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package structschema
+
+import (
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Codec converts values of a schema to and from the struct type T whose
+// tagged fields describe it. Use Schema or NewCodec to create one.
+type Codec[T any] struct {
+	schema *tfprotov6.Schema
+}
+
+// NewCodec returns a Codec for values of `schema`, which must have the
+// attributes and nested blocks of the tagged fields of T, such as a schema
+// whose Go types were generated from it. Unlike Schema, the types of
+// attributes are those in `schema` rather than derived from T, so T may use
+// a slice for a set nested within another collection, for example.
+func NewCodec[T any](schema *tfprotov6.Schema) *Codec[T] {
+	return &Codec[T]{schema: schema}
+}
+
+// Type returns the tftypes.Type of values of the schema.
+func (c *Codec[T]) Type() tftypes.Type {
+	return c.schema.ValueType()
+}
+
+// ToValue returns the tftypes.Value of the schema for `in`. Nil pointers,
+// slices, and maps are null, except for nested blocks with list, set, or map
+// nesting, which are empty as Terraform never sends null values for them.
+func (c *Codec[T]) ToValue(in T) (tftypes.Value, error) {
+	val, err := tftypes.NewValueFrom(c.Type(), in)
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+
+	return tftypes.Transform(val, func(path *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if !v.IsNull() || len(path.Steps()) == 0 {
+			return v, nil
+		}
+
+		if _, ok := path.LastStep().(tftypes.AttributeName); !ok {
+			return v, nil
+		}
+
+		blockType, _ := c.schema.BlockAtPath(path)
+
+		if blockType == nil {
+			return v, nil
+		}
+
+		switch blockType.Nesting {
+		case tfprotov6.SchemaNestedBlockNestingModeList, tfprotov6.SchemaNestedBlockNestingModeSet:
+			return tftypes.NewValue(v.Type(), []tftypes.Value{}), nil
+		case tfprotov6.SchemaNestedBlockNestingModeMap:
+			return tftypes.NewValue(v.Type(), map[string]tftypes.Value{}), nil
+		}
+
+		return v, nil
+	})
+}
+
+// FromValue sets `out` to the Go value of `val`, a tftypes.Value of the
+// schema. It returns an error if `val` has unknown values that are not stored
+// in tftypes.Value fields, or null values that are not stored in pointers,
+// slices, maps, or tftypes.Value fields.
+func (c *Codec[T]) FromValue(val tftypes.Value, out *T) error {
+	res, err := tftypes.GetAs[T](val, nil)
+	if err != nil {
+		return err
+	}
+
+	*out = res
+
+	return nil
+}
+
+// ToDynamicValue returns the tfprotov6.DynamicValue of the schema for `in`,
+// for use in RPC responses.
+func (c *Codec[T]) ToDynamicValue(in T) (*tfprotov6.DynamicValue, error) {
+	val, err := c.ToValue(in)
+	if err != nil {
+		return nil, err
+	}
+
+	dv, err := tfprotov6.NewDynamicValue(c.Type(), val)
+	if err != nil {
+		return nil, err
+	}
+
+	return &dv, nil
+}
+
+// FromDynamicValue sets `out` to the Go value of `dv`, a
+// tfprotov6.DynamicValue of the schema from an RPC request. See FromValue for
+// the values that can be converted.
+func (c *Codec[T]) FromDynamicValue(dv *tfprotov6.DynamicValue, out *T) error {
+	if dv == nil {
+		return errors.New("missing DynamicValue")
+	}
+
+	val, err := dv.Unmarshal(c.Type())
+	if err != nil {
+		return err
+	}
+
+	return c.FromValue(val, out)
+}
@@ -0,0 +1,237 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package structschema_test
+
+import (
+	"math/big"
+	"testing"
+	"testing/quick"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-go/structschema"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestCodecRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	_, codec, err := structschema.Schema[testResource](0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	certificate := "-----BEGIN CERTIFICATE-----"
+	create := "10m"
+	in := testResource{
+		ID:   "example-id",
+		Name: "example",
+		Tags: map[string]string{"env": "test"},
+		Listeners: []testListener{
+			{Port: 443, Certificate: &certificate},
+		},
+		Rules: []testRule{
+			{
+				Action:  "allow",
+				Targets: []testTarget{{Address: "10.0.0.1"}},
+				Extra:   tftypes.NewValue(tftypes.DynamicPseudoType, nil),
+			},
+		},
+		Timeouts: &testTimeouts{Create: &create},
+	}
+
+	dv, err := codec.ToDynamicValue(in)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var got testResource
+
+	if err := codec.FromDynamicValue(dv, &got); err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff(in, got, cmp.Comparer(func(a, b tftypes.Value) bool {
+		return a.Equal(b)
+	})); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+// roundTripResource can hold every known value of its schema, including null
+// values nested within collections.
+type roundTripResource struct {
+	ID        *string                      `tf:"id,computed"`
+	Numbers   []*big.Float                 `tf:"numbers,optional"`
+	Flags     []*bool                      `tf:"flags,optional,set"`
+	Tags      map[string]*string           `tf:"tags,optional"`
+	Extra     tftypes.Value                `tf:"extra,optional"`
+	Endpoint  *roundTripEndpoint           `tf:"endpoint,optional,nested"`
+	Listeners []roundTripEndpoint          `tf:"listeners,optional,nested,set"`
+	Targets   map[string]roundTripEndpoint `tf:"targets,optional,nested"`
+	Rules     []roundTripRule              `tf:"rule,block"`
+	Group     roundTripRule                `tf:"group,block"`
+	Timeouts  *roundTripTimeouts           `tf:"timeouts,block"`
+}
+
+type roundTripEndpoint struct {
+	Host  *string      `tf:"host,optional"`
+	Ports []*big.Float `tf:"ports,optional"`
+}
+
+type roundTripRule struct {
+	Action  *string                      `tf:"action,optional"`
+	Targets []roundTripTimeouts          `tf:"target,block,set"`
+	Labels  map[string]roundTripTimeouts `tf:"label,block"`
+}
+
+type roundTripTimeouts struct {
+	Create *string `tf:"create,optional"`
+}
+
+func TestCodecRoundTrip_generated(t *testing.T) {
+	t.Parallel()
+
+	schema, codec, err := structschema.Schema[roundTripResource](0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	generator := schema.ValueGenerator()
+	generator.NoUnknowns = true
+
+	// Elements of nested attributes are objects, which Terraform never
+	// sends as null, unlike the elements of other collections.
+	nullable := generator.Nullable
+	generator.Nullable = func(path *tftypes.AttributePath) bool {
+		steps := path.Steps()
+
+		if len(steps) != 2 || (steps[0] != tftypes.AttributeName("listeners") && steps[0] != tftypes.AttributeName("targets")) {
+			return nullable(path)
+		}
+
+		_, isAttribute := steps[1].(tftypes.AttributeName)
+
+		return isAttribute
+	}
+
+	err = quick.Check(func(val tftypes.Value) bool {
+		var res roundTripResource
+
+		if err := codec.FromValue(val, &res); err != nil {
+			t.Logf("unexpected FromValue error for %s: %s", val, err)
+
+			return false
+		}
+
+		got, err := codec.ToValue(res)
+		if err != nil {
+			t.Logf("unexpected ToValue error for %s: %s", val, err)
+
+			return false
+		}
+
+		if !got.Equal(val) {
+			t.Logf("expected %s, got %s", val, got)
+
+			return false
+		}
+
+		return true
+	}, &quick.Config{
+		MaxCount: 500,
+		Values:   generator.QuickValues,
+	})
+
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestCodecToValue(t *testing.T) {
+	t.Parallel()
+
+	_, codec, err := structschema.Schema[testResource](0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	val, err := codec.ToValue(testResource{
+		Rules: []testRule{{Action: "deny"}},
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	testCases := map[string]struct {
+		path     *tftypes.AttributePath
+		expected bool
+	}{
+		"nil-attribute": {
+			path:     tftypes.NewAttributePath().WithAttributeName("tags"),
+			expected: true,
+		},
+		"nil-nested-attribute": {
+			path:     tftypes.NewAttributePath().WithAttributeName("listeners"),
+			expected: true,
+		},
+		"nil-single-block": {
+			path:     tftypes.NewAttributePath().WithAttributeName("timeouts"),
+			expected: true,
+		},
+		"nil-set-block": {
+			path: tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyInt(0).WithAttributeName("target"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			v, err := tftypes.GetAs[tftypes.Value](val, testCase.path)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if v.IsNull() != testCase.expected {
+				t.Errorf("expected null to be %t, got %s", testCase.expected, v)
+			}
+		})
+	}
+}
+
+func TestCodecFromValue(t *testing.T) {
+	t.Parallel()
+
+	_, codec, err := structschema.Schema[testResource](0)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	val, err := codec.ToValue(testResource{Name: "example"})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	val, err = tftypes.Transform(val, func(path *tftypes.AttributePath, v tftypes.Value) (tftypes.Value, error) {
+		if path.Equal(tftypes.NewAttributePath().WithAttributeName("id")) {
+			return tftypes.NewValue(tftypes.String, tftypes.UnknownValue), nil
+		}
+
+		return v, nil
+	})
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	var got testResource
+
+	err = codec.FromValue(val, &got)
+
+	expectedError := `AttributeName("id"): unknown values cannot be converted to string`
+
+	if err == nil || err.Error() != expectedError {
+		t.Errorf("expected error %q, got %v", expectedError, err)
+	}
+}
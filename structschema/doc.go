@@ -0,0 +1,46 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package structschema derives provider schemas from Go struct types, and
+// converts values between those structs and tftypes.Value.
+//
+// Each exported struct field with a `tf` struct tag is an attribute or
+// nested block of the schema. The tag has the name of the attribute or
+// block, followed by comma separated options:
+//
+//	type Resource struct {
+//		ID       string            `tf:"id,computed"`
+//		Name     string            `tf:"name,required" description:"The name of the resource."`
+//		Password *string           `tf:"password,optional,sensitive,write_only"`
+//		Tags     map[string]string `tf:"tags,optional"`
+//		Rules    []Rule            `tf:"rule,block,max_items=10"`
+//		Timeouts *Timeouts         `tf:"timeouts,block"`
+//	}
+//
+// The options for attributes are required, optional, computed, sensitive, and
+// write_only, with the same meaning as the fields of
+// tfprotov6.SchemaAttribute, and set, which makes a slice a set rather than a
+// list. The type of the attribute is derived from the type of the field:
+// strings, bools, and numbers are primitive types, slices are lists, maps
+// with string keys are maps, structs are objects, and tftypes.Value is
+// tftypes.DynamicPseudoType. Pointers can be used for values that may be
+// null.
+//
+// The nested option makes the field a nested attribute, and the block option
+// makes it a nested block. The field must be a struct, or a slice or map of
+// structs, whose fields are the attributes of the nested attribute or block.
+// Pointers to structs are nested with single nesting, slices are nested as
+// lists, or sets with the set option, and maps are nested as maps. Structs
+// that are not pointers are nested with single nesting for nested attributes
+// and group nesting for nested blocks, as they cannot be null. Nested blocks
+// also support the min_items=N and max_items=N options.
+//
+// Attributes and nested blocks can be documented with the description struct
+// tag, which is treated as Markdown if the markdown option is set. They can be
+// deprecated with the deprecated option, or with a deprecation_message struct
+// tag.
+//
+// Values that may be unknown, such as computed attributes during planning,
+// must be stored in tftypes.Value fields, as other Go types cannot represent
+// unknown values.
+package structschema
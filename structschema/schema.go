@@ -0,0 +1,471 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package structschema
+
+import (
+	"errors"
+	"fmt"
+	"math/big"
+	"reflect"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/schemabuilder"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var (
+	valueReflectType    = reflect.TypeOf(tftypes.Value{})
+	bigFloatReflectType = reflect.TypeOf(&big.Float{})
+)
+
+// Schema returns the tfprotov6.Schema derived from the struct type T, with
+// the version `version`, and a Codec for converting values of the schema to
+// and from T.
+func Schema[T any](version int64) (*tfprotov6.Schema, *Codec[T], error) {
+	builder, err := NewSchemaBuilder[T]()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	schema, err := builder.Version(version).ProtoV6()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	return schema, &Codec[T]{schema: schema}, nil
+}
+
+// NewSchemaBuilder returns a schemabuilder.SchemaBuilder with the attributes
+// and nested blocks derived from the struct type T. It can be used to set
+// other properties of the schema, or to build a tfprotov5.Schema if T has no
+// nested attributes.
+//
+// Errors in the struct tags of T are returned immediately, while invalid
+// combinations of options, such as an attribute that is both required and
+// computed, are returned when the schema is built.
+func NewSchemaBuilder[T any]() (*schemabuilder.SchemaBuilder, error) {
+	typ := reflect.TypeOf((*T)(nil)).Elem()
+
+	if typ.Kind() != reflect.Struct {
+		return nil, fmt.Errorf("%s is not a struct", typ)
+	}
+
+	attributes, blocks, err := structContents(typ, map[reflect.Type]bool{}, true)
+	if err != nil {
+		return nil, err
+	}
+
+	return schemabuilder.NewSchema().Attributes(attributes...).Blocks(blocks...), nil
+}
+
+// structContents returns the attributes and nested blocks of the tagged
+// fields of the struct type `typ`. `seen` holds the struct types being
+// derived, to detect recursive types.
+func structContents(typ reflect.Type, seen map[reflect.Type]bool, blocksAllowed bool) ([]*schemabuilder.AttributeBuilder, []*schemabuilder.NestedBlockBuilder, error) {
+	if seen[typ] {
+		return nil, nil, fmt.Errorf("%s is recursive", typ)
+	}
+
+	seen[typ] = true
+	defer delete(seen, typ)
+
+	var attributes []*schemabuilder.AttributeBuilder
+	var blocks []*schemabuilder.NestedBlockBuilder
+
+	for _, field := range taggedFields(typ) {
+		tag, err := parseTag(field)
+		if err != nil {
+			return nil, nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+
+		if tag.options["block"] {
+			if !blocksAllowed {
+				return nil, nil, fmt.Errorf("field %s: nested attributes cannot contain blocks", field.Name)
+			}
+
+			block, err := nestedBlock(field, tag, seen)
+			if err != nil {
+				return nil, nil, fmt.Errorf("field %s: %w", field.Name, err)
+			}
+
+			blocks = append(blocks, block)
+
+			continue
+		}
+
+		attribute, err := attribute(field, tag, seen)
+		if err != nil {
+			return nil, nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+
+		attributes = append(attributes, attribute)
+	}
+
+	return attributes, blocks, nil
+}
+
+func attribute(field reflect.StructField, tag fieldTag, seen map[reflect.Type]bool) (*schemabuilder.AttributeBuilder, error) {
+	if err := tag.onlyOptions("attributes", "required", "optional", "computed", "sensitive", "write_only", "nested", "set", "markdown", "deprecated"); err != nil {
+		return nil, err
+	}
+
+	var res *schemabuilder.AttributeBuilder
+
+	if tag.options["nested"] {
+		nesting, elemType, err := nestingOf(field.Type, tag)
+		if err != nil {
+			return nil, err
+		}
+
+		attributes, _, err := structContents(elemType, seen, false)
+		if err != nil {
+			return nil, err
+		}
+
+		switch nesting {
+		case "single":
+			res = schemabuilder.NewSingleNestedAttribute(tag.name, attributes...)
+		case "list":
+			res = schemabuilder.NewListNestedAttribute(tag.name, attributes...)
+		case "set":
+			res = schemabuilder.NewSetNestedAttribute(tag.name, attributes...)
+		case "map":
+			res = schemabuilder.NewMapNestedAttribute(tag.name, attributes...)
+		}
+	} else {
+		typ, err := attributeType(field.Type, tag.options["set"], seen)
+		if err != nil {
+			return nil, err
+		}
+
+		res = schemabuilder.NewAttribute(tag.name, typ)
+	}
+
+	if tag.options["required"] {
+		res.Required()
+	}
+
+	if tag.options["optional"] {
+		res.Optional()
+	}
+
+	if tag.options["computed"] {
+		res.Computed()
+	}
+
+	if tag.options["sensitive"] {
+		res.Sensitive()
+	}
+
+	if tag.options["write_only"] {
+		res.WriteOnly()
+	}
+
+	if tag.description != "" {
+		if tag.options["markdown"] {
+			res.MarkdownDescription(tag.description)
+		} else {
+			res.Description(tag.description)
+		}
+	}
+
+	if tag.deprecated() {
+		res.Deprecated(tag.deprecationMessage)
+	}
+
+	return res, nil
+}
+
+func nestedBlock(field reflect.StructField, tag fieldTag, seen map[reflect.Type]bool) (*schemabuilder.NestedBlockBuilder, error) {
+	if err := tag.onlyOptions("blocks", "block", "set", "min_items", "max_items", "markdown", "deprecated"); err != nil {
+		return nil, err
+	}
+
+	nesting, elemType, err := nestingOf(field.Type, tag)
+	if err != nil {
+		return nil, err
+	}
+
+	attributes, blocks, err := structContents(elemType, seen, true)
+	if err != nil {
+		return nil, err
+	}
+
+	var res *schemabuilder.NestedBlockBuilder
+
+	switch nesting {
+	case "single":
+		res = schemabuilder.NewSingleBlock(tag.name)
+	case "group":
+		res = schemabuilder.NewGroupBlock(tag.name)
+	case "list":
+		res = schemabuilder.NewListBlock(tag.name)
+	case "set":
+		res = schemabuilder.NewSetBlock(tag.name)
+	case "map":
+		res = schemabuilder.NewMapBlock(tag.name)
+	}
+
+	res.Attributes(attributes...).Blocks(blocks...)
+
+	if tag.options["min_items"] {
+		res.MinItems(tag.minItems)
+	}
+
+	if tag.options["max_items"] {
+		res.MaxItems(tag.maxItems)
+	}
+
+	if tag.description != "" {
+		if tag.options["markdown"] {
+			res.MarkdownDescription(tag.description)
+		} else {
+			res.Description(tag.description)
+		}
+	}
+
+	if tag.deprecated() {
+		res.Deprecated(tag.deprecationMessage)
+	}
+
+	return res, nil
+}
+
+// nestingOf returns the nesting mode of a nested attribute or block with the
+// Go type `typ`, and the struct type of its elements.
+func nestingOf(typ reflect.Type, tag fieldTag) (string, reflect.Type, error) {
+	var nesting string
+	elemType := typ
+
+	switch typ.Kind() {
+	case reflect.Pointer:
+		nesting = "single"
+		elemType = typ.Elem()
+	case reflect.Struct:
+		nesting = "single"
+
+		if tag.options["block"] {
+			nesting = "group"
+		}
+	case reflect.Slice:
+		nesting = "list"
+		elemType = typ.Elem()
+
+		if tag.options["set"] {
+			nesting = "set"
+		}
+	case reflect.Map:
+		if typ.Key().Kind() != reflect.String {
+			return "", nil, fmt.Errorf("%s must have string keys", typ)
+		}
+
+		nesting = "map"
+		elemType = typ.Elem()
+	}
+
+	if elemType.Kind() != reflect.Struct {
+		return "", nil, fmt.Errorf("%s must be a struct, or a pointer, slice, or map of structs", typ)
+	}
+
+	if tag.options["set"] && nesting != "set" {
+		return "", nil, fmt.Errorf("the set option requires a slice, got %s", typ)
+	}
+
+	return nesting, elemType, nil
+}
+
+// attributeType returns the tftypes.Type of an attribute with the Go type
+// `typ`. Slices are sets rather than lists if `set` is true.
+func attributeType(typ reflect.Type, set bool, seen map[reflect.Type]bool) (tftypes.Type, error) {
+	switch {
+	case typ == valueReflectType && !set:
+		return tftypes.DynamicPseudoType, nil
+	case typ == bigFloatReflectType && !set:
+		return tftypes.Number, nil
+	case typ.Kind() == reflect.Pointer && typ != bigFloatReflectType:
+		return attributeType(typ.Elem(), set, seen)
+	case set && typ.Kind() != reflect.Slice:
+		return nil, fmt.Errorf("the set option requires a slice, got %s", typ)
+	}
+
+	switch typ.Kind() {
+	case reflect.String:
+		return tftypes.String, nil
+	case reflect.Bool:
+		return tftypes.Bool, nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return tftypes.Number, nil
+	case reflect.Slice:
+		elemType, err := attributeType(typ.Elem(), false, seen)
+		if err != nil {
+			return nil, err
+		}
+
+		if set {
+			return tftypes.Set{ElementType: elemType}, nil
+		}
+
+		return tftypes.List{ElementType: elemType}, nil
+	case reflect.Map:
+		if typ.Key().Kind() != reflect.String {
+			return nil, fmt.Errorf("%s must have string keys", typ)
+		}
+
+		elemType, err := attributeType(typ.Elem(), false, seen)
+		if err != nil {
+			return nil, err
+		}
+
+		return tftypes.Map{ElementType: elemType}, nil
+	case reflect.Struct:
+		return objectType(typ, seen)
+	}
+
+	return nil, fmt.Errorf("unsupported type %s", typ)
+}
+
+// objectType returns the tftypes.Object of an attribute with the struct type
+// `typ`. Its fields only describe attribute types, so their tags must not
+// have options.
+func objectType(typ reflect.Type, seen map[reflect.Type]bool) (tftypes.Type, error) {
+	if seen[typ] {
+		return nil, fmt.Errorf("%s is recursive", typ)
+	}
+
+	seen[typ] = true
+	defer delete(seen, typ)
+
+	attributeTypes := map[string]tftypes.Type{}
+
+	for _, field := range taggedFields(typ) {
+		tag, err := parseTag(field)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+
+		if err := tag.onlyOptions("fields of object types", "set"); err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+
+		if _, ok := attributeTypes[tag.name]; ok {
+			return nil, fmt.Errorf("field %s: duplicate name %q", field.Name, tag.name)
+		}
+
+		attrType, err := attributeType(field.Type, tag.options["set"], seen)
+		if err != nil {
+			return nil, fmt.Errorf("field %s: %w", field.Name, err)
+		}
+
+		attributeTypes[tag.name] = attrType
+	}
+
+	return tftypes.Object{AttributeTypes: attributeTypes}, nil
+}
+
+// taggedFields returns the exported fields of the struct type `typ` with a
+// `tf` struct tag, in the same way as tftypes.GetAs.
+func taggedFields(typ reflect.Type) []reflect.StructField {
+	var fields []reflect.StructField
+
+	for i := 0; i < typ.NumField(); i++ {
+		field := typ.Field(i)
+		name, _, _ := strings.Cut(field.Tag.Get("tf"), ",")
+
+		if !field.IsExported() || name == "" || name == "-" {
+			continue
+		}
+
+		fields = append(fields, field)
+	}
+
+	return fields
+}
+
+// fieldTag is the parsed struct tags of a field.
+type fieldTag struct {
+	name               string
+	options            map[string]bool
+	minItems           int64
+	maxItems           int64
+	description        string
+	deprecationMessage string
+}
+
+func parseTag(field reflect.StructField) (fieldTag, error) {
+	parts := strings.Split(field.Tag.Get("tf"), ",")
+
+	res := fieldTag{
+		name:               parts[0],
+		options:            map[string]bool{},
+		description:        field.Tag.Get("description"),
+		deprecationMessage: field.Tag.Get("deprecation_message"),
+	}
+
+	for _, part := range parts[1:] {
+		key, value, hasValue := strings.Cut(part, "=")
+
+		switch key {
+		case "min_items", "max_items":
+			if !hasValue {
+				return fieldTag{}, fmt.Errorf("option %s requires a value, as in %s=1", key, key)
+			}
+
+			n, err := strconv.ParseInt(value, 10, 64)
+			if err != nil {
+				return fieldTag{}, fmt.Errorf("invalid %s value %q", key, value)
+			}
+
+			if key == "min_items" {
+				res.minItems = n
+			} else {
+				res.maxItems = n
+			}
+		case "required", "optional", "computed", "sensitive", "write_only",
+			"nested", "block", "set", "markdown", "deprecated":
+			if hasValue {
+				return fieldTag{}, fmt.Errorf("option %s does not take a value", key)
+			}
+		default:
+			return fieldTag{}, fmt.Errorf("unknown option %q", part)
+		}
+
+		res.options[key] = true
+	}
+
+	if res.options["nested"] && res.options["block"] {
+		return fieldTag{}, errors.New("cannot combine the nested and block options")
+	}
+
+	return res, nil
+}
+
+// onlyOptions returns an error if the tag has an option other than `allowed`,
+// which are the options supported for `kind`.
+func (t fieldTag) onlyOptions(kind string, allowed ...string) error {
+	options := make([]string, 0, len(t.options))
+
+	for option := range t.options {
+		options = append(options, option)
+	}
+
+	sort.Strings(options)
+
+	for _, option := range options {
+		if !slices.Contains(allowed, option) {
+			return fmt.Errorf("option %s is not supported for %s", option, kind)
+		}
+	}
+
+	return nil
+}
+
+func (t fieldTag) deprecated() bool {
+	return t.options["deprecated"] || t.deprecationMessage != ""
+}
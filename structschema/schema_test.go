@@ -0,0 +1,299 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package structschema_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-go/structschema"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+type testResource struct {
+	ID        string            `tf:"id,computed"`
+	Name      string            `tf:"name,required" description:"The **name**." `
+	Password  *string           `tf:"password,optional,sensitive,write_only"`
+	Tags      map[string]string `tf:"tags,optional"`
+	Ports     []int64           `tf:"ports,optional,set"`
+	Legacy    *string           `tf:"legacy,optional" deprecation_message:"Use name instead."`
+	Endpoint  *testEndpoint     `tf:"endpoint,optional"`
+	Listeners []testListener    `tf:"listeners,optional,nested,set"`
+	Rules     []testRule        `tf:"rule,block,min_items=1,max_items=3" description:"A rule."`
+	Timeouts  *testTimeouts     `tf:"timeouts,block"`
+	Ignored   string
+}
+
+type testEndpoint struct {
+	Host string `tf:"host"`
+	Port int    `tf:"port"`
+}
+
+type testListener struct {
+	Port        int64   `tf:"port,required"`
+	Certificate *string `tf:"certificate,optional,markdown" description:"A *PEM* certificate."`
+}
+
+type testRule struct {
+	Action  string          `tf:"action,required"`
+	Targets []testTarget    `tf:"target,block,set"`
+	Extra   tftypes.Value   `tf:"extra,optional"`
+	Labels  map[string]bool `tf:"labels,optional"`
+}
+
+type testTarget struct {
+	Address string `tf:"address,required"`
+}
+
+type testTimeouts struct {
+	Create *string `tf:"create,optional,deprecated"`
+}
+
+func TestSchema(t *testing.T) {
+	t.Parallel()
+
+	got, _, err := structschema.Schema[testResource](1)
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := &tfprotov6.Schema{
+		Version: 1,
+		Block: &tfprotov6.SchemaBlock{
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name: "endpoint",
+					Type: tftypes.Object{AttributeTypes: map[string]tftypes.Type{
+						"host": tftypes.String,
+						"port": tftypes.Number,
+					}},
+					Optional: true,
+				},
+				{
+					Name:     "id",
+					Type:     tftypes.String,
+					Computed: true,
+				},
+				{
+					Name:               "legacy",
+					Type:               tftypes.String,
+					Optional:           true,
+					Deprecated:         true,
+					DeprecationMessage: "Use name instead.",
+				},
+				{
+					Name: "listeners",
+					NestedType: &tfprotov6.SchemaObject{
+						Nesting: tfprotov6.SchemaObjectNestingModeSet,
+						Attributes: []*tfprotov6.SchemaAttribute{
+							{
+								Name:            "certificate",
+								Type:            tftypes.String,
+								Optional:        true,
+								Description:     "A *PEM* certificate.",
+								DescriptionKind: tfprotov6.StringKindMarkdown,
+							},
+							{
+								Name:     "port",
+								Type:     tftypes.Number,
+								Required: true,
+							},
+						},
+					},
+					Optional: true,
+				},
+				{
+					Name:        "name",
+					Type:        tftypes.String,
+					Required:    true,
+					Description: "The **name**.",
+				},
+				{
+					Name:      "password",
+					Type:      tftypes.String,
+					Optional:  true,
+					Sensitive: true,
+					WriteOnly: true,
+				},
+				{
+					Name:     "ports",
+					Type:     tftypes.Set{ElementType: tftypes.Number},
+					Optional: true,
+				},
+				{
+					Name:     "tags",
+					Type:     tftypes.Map{ElementType: tftypes.String},
+					Optional: true,
+				},
+			},
+			BlockTypes: []*tfprotov6.SchemaNestedBlock{
+				{
+					TypeName: "rule",
+					Nesting:  tfprotov6.SchemaNestedBlockNestingModeList,
+					MinItems: 1,
+					MaxItems: 3,
+					Block: &tfprotov6.SchemaBlock{
+						Description: "A rule.",
+						Attributes: []*tfprotov6.SchemaAttribute{
+							{
+								Name:     "action",
+								Type:     tftypes.String,
+								Required: true,
+							},
+							{
+								Name:     "extra",
+								Type:     tftypes.DynamicPseudoType,
+								Optional: true,
+							},
+							{
+								Name:     "labels",
+								Type:     tftypes.Map{ElementType: tftypes.Bool},
+								Optional: true,
+							},
+						},
+						BlockTypes: []*tfprotov6.SchemaNestedBlock{
+							{
+								TypeName: "target",
+								Nesting:  tfprotov6.SchemaNestedBlockNestingModeSet,
+								Block: &tfprotov6.SchemaBlock{
+									Attributes: []*tfprotov6.SchemaAttribute{
+										{
+											Name:     "address",
+											Type:     tftypes.String,
+											Required: true,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				{
+					TypeName: "timeouts",
+					Nesting:  tfprotov6.SchemaNestedBlockNestingModeSingle,
+					Block: &tfprotov6.SchemaBlock{
+						Attributes: []*tfprotov6.SchemaAttribute{
+							{
+								Name:       "create",
+								Type:       tftypes.String,
+								Optional:   true,
+								Deprecated: true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+func TestSchemaErrors(t *testing.T) {
+	t.Parallel()
+
+	type recursive struct {
+		Children []recursive `tf:"child,block"`
+	}
+
+	testCases := map[string]struct {
+		schema        func() error
+		expectedError string
+	}{
+		"not-struct": {
+			schema:        schemaError[string],
+			expectedError: "string is not a struct",
+		},
+		"unknown-option": {
+			schema: schemaError[struct {
+				Name string `tf:"name,requried"`
+			}],
+			expectedError: `field Name: unknown option "requried"`,
+		},
+		"unsupported-type": {
+			schema: schemaError[struct {
+				Callback func() `tf:"callback,optional"`
+			}],
+			expectedError: "field Callback: unsupported type func()",
+		},
+		"block-option-on-attribute": {
+			schema: schemaError[struct {
+				Name string `tf:"name,required,max_items=1"`
+			}],
+			expectedError: "field Name: option max_items is not supported for attributes",
+		},
+		"attribute-option-on-block": {
+			schema: schemaError[struct {
+				Rules []testRule `tf:"rule,block,required"`
+			}],
+			expectedError: "field Rules: option required is not supported for blocks",
+		},
+		"block-not-struct": {
+			schema: schemaError[struct {
+				Rules []string `tf:"rule,block"`
+			}],
+			expectedError: "field Rules: []string must be a struct, or a pointer, slice, or map of structs",
+		},
+		"block-in-nested-attribute": {
+			schema: schemaError[struct {
+				Rules []testRule `tf:"rule,optional,nested"`
+			}],
+			expectedError: "field Rules: field Targets: nested attributes cannot contain blocks",
+		},
+		"set-not-slice": {
+			schema: schemaError[struct {
+				Tags map[string]string `tf:"tags,optional,set"`
+			}],
+			expectedError: "field Tags: the set option requires a slice, got map[string]string",
+		},
+		"object-options": {
+			schema: schemaError[struct {
+				Endpoint testListener `tf:"endpoint,optional"`
+			}],
+			expectedError: "field Endpoint: field Port: option required is not supported for fields of object types",
+		},
+		"recursive": {
+			schema:        schemaError[recursive],
+			expectedError: "field Children: structschema_test.recursive is recursive",
+		},
+		"invalid-combination": {
+			schema: schemaError[struct {
+				ID string `tf:"id,required,computed"`
+			}],
+			expectedError: `attribute "id": cannot combine Computed with Required`,
+		},
+		"invalid-min-items": {
+			schema: schemaError[struct {
+				Rules []testRule `tf:"rule,block,min_items=one"`
+			}],
+			expectedError: `field Rules: invalid min_items value "one"`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := testCase.schema()
+
+			if err == nil {
+				t.Fatalf("expected error %q, got none", testCase.expectedError)
+			}
+
+			if diff := cmp.Diff(testCase.expectedError, err.Error()); diff != "" {
+				t.Errorf("unexpected error difference: %s", diff)
+			}
+		})
+	}
+}
+
+func schemaError[T any]() error {
+	_, _, err := structschema.Schema[T](0)
+
+	return err
+}
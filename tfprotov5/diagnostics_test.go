@@ -0,0 +1,360 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfprotov5_test
+
+import (
+	"errors"
+	"fmt"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestDiagnosticsFromError(t *testing.T) {
+	t.Parallel()
+
+	path := tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyInt(0)
+
+	testCases := map[string]struct {
+		err      error
+		expected tfprotov5.Diagnostics
+	}{
+		"nil": {
+			err:      nil,
+			expected: nil,
+		},
+		"error": {
+			err: errors.New("test error"),
+			expected: tfprotov5.Diagnostics{
+				{
+					Severity: tfprotov5.DiagnosticSeverityError,
+					Summary:  "test summary",
+					Detail:   "test error",
+				},
+			},
+		},
+		"attribute-path-error": {
+			err: path.NewErrorf("test error"),
+			expected: tfprotov5.Diagnostics{
+				{
+					Severity:  tfprotov5.DiagnosticSeverityError,
+					Summary:   "test summary",
+					Detail:    "test error",
+					Attribute: path,
+				},
+			},
+		},
+		"wrapped-attribute-path-error": {
+			err: fmt.Errorf("test context: %w", path.NewErrorf("test error")),
+			expected: tfprotov5.Diagnostics{
+				{
+					Severity:  tfprotov5.DiagnosticSeverityError,
+					Summary:   "test summary",
+					Detail:    `test context: AttributeName("rule").ElementKeyInt(0): test error`,
+					Attribute: path,
+				},
+			},
+		},
+		"joined-errors": {
+			err: errors.Join(
+				errors.New("test error 1"),
+				path.NewErrorf("test error 2"),
+				errors.New("test error 1"),
+			),
+			expected: tfprotov5.Diagnostics{
+				{
+					Severity: tfprotov5.DiagnosticSeverityError,
+					Summary:  "test summary",
+					Detail:   "test error 1",
+				},
+				{
+					Severity:  tfprotov5.DiagnosticSeverityError,
+					Summary:   "test summary",
+					Detail:    "test error 2",
+					Attribute: path,
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := tfprotov5.DiagnosticsFromError("test summary", testCase.err)
+
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestDiagnosticsAdd(t *testing.T) {
+	t.Parallel()
+
+	path := tftypes.NewAttributePath().WithAttributeName("name")
+
+	var got tfprotov5.Diagnostics
+
+	got.AddError("test error summary", "test error detail")
+	got.AddWarning("test warning summary", "test warning detail")
+	got.AddAttributeError(path, "test error summary", "test error detail")
+	got.AddAttributeWarning(path, "test warning summary", "test warning detail")
+	got.AddError("test error summary", "test error detail")
+	got.AddAttributeError(path, "test error summary", "test error detail")
+
+	expected := tfprotov5.Diagnostics{
+		{
+			Severity: tfprotov5.DiagnosticSeverityError,
+			Summary:  "test error summary",
+			Detail:   "test error detail",
+		},
+		{
+			Severity: tfprotov5.DiagnosticSeverityWarning,
+			Summary:  "test warning summary",
+			Detail:   "test warning detail",
+		},
+		{
+			Severity:  tfprotov5.DiagnosticSeverityError,
+			Summary:   "test error summary",
+			Detail:    "test error detail",
+			Attribute: path,
+		},
+		{
+			Severity:  tfprotov5.DiagnosticSeverityWarning,
+			Summary:   "test warning summary",
+			Detail:    "test warning detail",
+			Attribute: path,
+		},
+	}
+
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+func TestDiagnosticsDeduplicate(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		diagnostics tfprotov5.Diagnostics
+		expected    tfprotov5.Diagnostics
+	}{
+		"nil": {
+			diagnostics: nil,
+			expected:    nil,
+		},
+		"duplicates": {
+			diagnostics: tfprotov5.Diagnostics{
+				{
+					Severity: tfprotov5.DiagnosticSeverityError,
+					Summary:  "test summary",
+				},
+				nil,
+				{
+					Severity: tfprotov5.DiagnosticSeverityWarning,
+					Summary:  "test summary",
+				},
+				{
+					Severity:  tfprotov5.DiagnosticSeverityError,
+					Summary:   "test summary",
+					Attribute: tftypes.NewAttributePath().WithAttributeName("name"),
+				},
+				{
+					Severity: tfprotov5.DiagnosticSeverityError,
+					Summary:  "test summary",
+				},
+				{
+					Severity:  tfprotov5.DiagnosticSeverityError,
+					Summary:   "test summary",
+					Attribute: tftypes.NewAttributePath().WithAttributeName("name"),
+				},
+			},
+			expected: tfprotov5.Diagnostics{
+				{
+					Severity: tfprotov5.DiagnosticSeverityError,
+					Summary:  "test summary",
+				},
+				{
+					Severity: tfprotov5.DiagnosticSeverityWarning,
+					Summary:  "test summary",
+				},
+				{
+					Severity:  tfprotov5.DiagnosticSeverityError,
+					Summary:   "test summary",
+					Attribute: tftypes.NewAttributePath().WithAttributeName("name"),
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.diagnostics.Deduplicate()
+
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestDiagnosticsSeverities(t *testing.T) {
+	t.Parallel()
+
+	errorDiagnostic := &tfprotov5.Diagnostic{
+		Severity: tfprotov5.DiagnosticSeverityError,
+		Summary:  "test error summary",
+	}
+	warningDiagnostic := &tfprotov5.Diagnostic{
+		Severity: tfprotov5.DiagnosticSeverityWarning,
+		Summary:  "test warning summary",
+	}
+	invalidDiagnostic := &tfprotov5.Diagnostic{
+		Severity: tfprotov5.DiagnosticSeverityInvalid,
+		Summary:  "test invalid summary",
+	}
+
+	testCases := map[string]struct {
+		diagnostics      tfprotov5.Diagnostics
+		expectedHasError bool
+		expectedErrors   tfprotov5.Diagnostics
+		expectedWarnings tfprotov5.Diagnostics
+	}{
+		"nil": {
+			diagnostics: nil,
+		},
+		"error": {
+			diagnostics:      tfprotov5.Diagnostics{errorDiagnostic},
+			expectedHasError: true,
+			expectedErrors:   tfprotov5.Diagnostics{errorDiagnostic},
+		},
+		"warning": {
+			diagnostics:      tfprotov5.Diagnostics{warningDiagnostic, nil},
+			expectedWarnings: tfprotov5.Diagnostics{warningDiagnostic},
+		},
+		"mixed": {
+			diagnostics:      tfprotov5.Diagnostics{warningDiagnostic, invalidDiagnostic, errorDiagnostic},
+			expectedHasError: true,
+			expectedErrors:   tfprotov5.Diagnostics{errorDiagnostic},
+			expectedWarnings: tfprotov5.Diagnostics{warningDiagnostic},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := testCase.diagnostics.HasError(); got != testCase.expectedHasError {
+				t.Errorf("expected HasError %t, got %t", testCase.expectedHasError, got)
+			}
+
+			if diff := cmp.Diff(testCase.expectedErrors, testCase.diagnostics.Errors()); diff != "" {
+				t.Errorf("unexpected Errors difference: %s", diff)
+			}
+
+			if diff := cmp.Diff(testCase.expectedWarnings, testCase.diagnostics.Warnings()); diff != "" {
+				t.Errorf("unexpected Warnings difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestDiagnosticsErr(t *testing.T) {
+	t.Parallel()
+
+	path := tftypes.NewAttributePath().WithAttributeName("name")
+
+	testCases := map[string]struct {
+		diagnostics   tfprotov5.Diagnostics
+		expectedError string
+	}{
+		"nil": {
+			diagnostics: nil,
+		},
+		"warnings": {
+			diagnostics: tfprotov5.Diagnostics{
+				{
+					Severity: tfprotov5.DiagnosticSeverityWarning,
+					Summary:  "test warning summary",
+				},
+			},
+		},
+		"errors": {
+			diagnostics: tfprotov5.Diagnostics{
+				{
+					Severity: tfprotov5.DiagnosticSeverityError,
+					Summary:  "test error summary 1",
+				},
+				{
+					Severity: tfprotov5.DiagnosticSeverityWarning,
+					Summary:  "test warning summary",
+				},
+				{
+					Severity:  tfprotov5.DiagnosticSeverityError,
+					Summary:   "test error summary 2",
+					Detail:    "test error detail 2",
+					Attribute: path,
+				},
+			},
+			expectedError: "test error summary 1\n" + `AttributeName("name"): test error summary 2: test error detail 2`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := testCase.diagnostics.Err()
+
+			if err == nil {
+				if testCase.expectedError != "" {
+					t.Fatalf("expected error %q, got none", testCase.expectedError)
+				}
+
+				return
+			}
+
+			if diff := cmp.Diff(testCase.expectedError, err.Error()); diff != "" {
+				t.Errorf("unexpected error difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestDiagnosticsErrRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	path := tftypes.NewAttributePath().WithAttributeName("name")
+
+	diags := tfprotov5.Diagnostics{
+		{
+			Severity:  tfprotov5.DiagnosticSeverityError,
+			Summary:   "Invalid value",
+			Detail:    "Values must be lowercase.",
+			Attribute: path,
+		},
+	}
+
+	expected := tfprotov5.Diagnostics{
+		{
+			Severity:  tfprotov5.DiagnosticSeverityError,
+			Summary:   "test summary",
+			Detail:    "Invalid value: Values must be lowercase.",
+			Attribute: path,
+		},
+	}
+
+	got := tfprotov5.DiagnosticsFromError("test summary", diags.Err())
+
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
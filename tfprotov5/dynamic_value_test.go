@@ -4,6 +4,7 @@
 package tfprotov5_test
 
 import (
+	"errors"
 	"fmt"
 	"strings"
 	"testing"
@@ -92,6 +93,295 @@ func TestDynamicValueIsNull(t *testing.T) {
 	}
 }
 
+func TestDynamicValueContentHash(t *testing.T) {
+	t.Parallel()
+
+	typ := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"test_number_attribute": tftypes.Number,
+			"test_set_attribute":    tftypes.Set{ElementType: tftypes.String},
+		},
+	}
+
+	msgPackValue := testNewDynamicValueMust(t,
+		typ,
+		tftypes.NewValue(typ, map[string]tftypes.Value{
+			"test_number_attribute": tftypes.NewValue(tftypes.Number, 1),
+			"test_set_attribute": tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{
+				tftypes.NewValue(tftypes.String, "a"),
+				tftypes.NewValue(tftypes.String, "b"),
+			}),
+		}),
+	)
+
+	testCases := map[string]struct {
+		dynamicValue  tfprotov5.DynamicValue
+		expectedEqual bool
+		expectedError error
+	}{
+		"empty-dynamic-value": {
+			dynamicValue:  tfprotov5.DynamicValue{},
+			expectedError: tfprotov5.ErrUnknownDynamicValueType,
+		},
+		"json-reordered": {
+			dynamicValue: tfprotov5.DynamicValue{
+				JSON: []byte(`{"test_set_attribute":["b","a"],"test_number_attribute":1.0}`),
+			},
+			expectedEqual: true,
+		},
+		"json-different": {
+			dynamicValue: tfprotov5.DynamicValue{
+				JSON: []byte(`{"test_set_attribute":["a"],"test_number_attribute":1}`),
+			},
+			expectedEqual: false,
+		},
+		"json-null": {
+			dynamicValue: tfprotov5.DynamicValue{
+				JSON: []byte(`{"test_set_attribute":null,"test_number_attribute":1}`),
+			},
+			expectedEqual: false,
+		},
+	}
+
+	expected, err := msgPackValue.ContentHash(typ)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := testCase.dynamicValue.ContentHash(typ)
+
+			if err != nil {
+				if testCase.expectedError == nil {
+					t.Fatalf("wanted no error, got error: %s", err)
+				}
+
+				if !strings.Contains(err.Error(), testCase.expectedError.Error()) {
+					t.Fatalf("wanted error %q, got error: %s", testCase.expectedError.Error(), err.Error())
+				}
+
+				return
+			}
+
+			if testCase.expectedError != nil {
+				t.Fatalf("got no error, wanted err: %s", testCase.expectedError)
+			}
+
+			if (got == expected) != testCase.expectedEqual {
+				t.Errorf("expected hash equality %t, got hashes %x and %x", testCase.expectedEqual, expected, got)
+			}
+		})
+	}
+}
+
+func TestDynamicValueUnmarshalAtPath(t *testing.T) {
+	t.Parallel()
+
+	typ := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"id":     tftypes.String,
+			"region": tftypes.String,
+			"tags":   tftypes.Map{ElementType: tftypes.String},
+		},
+	}
+
+	msgPackValue := testNewDynamicValueMust(t,
+		typ,
+		tftypes.NewValue(typ, map[string]tftypes.Value{
+			"id":     tftypes.NewValue(tftypes.String, "abc123"),
+			"region": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			"tags": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+				"env": tftypes.NewValue(tftypes.String, "test"),
+			}),
+		}),
+	)
+
+	jsonValue := tfprotov5.DynamicValue{
+		JSON: []byte(`{"id":"abc123","tags":{"env":"test"}}`),
+	}
+
+	testCases := map[string]struct {
+		dynamicValue  tfprotov5.DynamicValue
+		path          *tftypes.AttributePath
+		expected      tftypes.Value
+		expectedError error
+	}{
+		"empty-dynamic-value": {
+			dynamicValue:  tfprotov5.DynamicValue{},
+			path:          tftypes.NewAttributePath().WithAttributeName("id"),
+			expectedError: tfprotov5.ErrUnknownDynamicValueType,
+		},
+		"msgpack-attribute": {
+			dynamicValue: msgPackValue,
+			path:         tftypes.NewAttributePath().WithAttributeName("id"),
+			expected:     tftypes.NewValue(tftypes.String, "abc123"),
+		},
+		"msgpack-unknown": {
+			dynamicValue: msgPackValue,
+			path:         tftypes.NewAttributePath().WithAttributeName("region"),
+			expected:     tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		},
+		"msgpack-map-element": {
+			dynamicValue: msgPackValue,
+			path:         tftypes.NewAttributePath().WithAttributeName("tags").WithElementKeyString("env"),
+			expected:     tftypes.NewValue(tftypes.String, "test"),
+		},
+		"msgpack-missing-map-element": {
+			dynamicValue:  msgPackValue,
+			path:          tftypes.NewAttributePath().WithAttributeName("tags").WithElementKeyString("team"),
+			expectedError: tftypes.ErrInvalidStep,
+		},
+		"json-attribute": {
+			dynamicValue: jsonValue,
+			path:         tftypes.NewAttributePath().WithAttributeName("id"),
+			expected:     tftypes.NewValue(tftypes.String, "abc123"),
+		},
+		"json-absent-attribute": {
+			dynamicValue: jsonValue,
+			path:         tftypes.NewAttributePath().WithAttributeName("region"),
+			expected:     tftypes.NewValue(tftypes.String, nil),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := testCase.dynamicValue.UnmarshalAtPath(typ, testCase.path)
+
+			if err != nil {
+				if testCase.expectedError == nil {
+					t.Fatalf("wanted no error, got error: %s", err)
+				}
+
+				if !errors.Is(err, testCase.expectedError) {
+					t.Fatalf("wanted error %q, got error: %s", testCase.expectedError.Error(), err.Error())
+				}
+
+				return
+			}
+
+			if testCase.expectedError != nil {
+				t.Fatalf("got no error, wanted err: %s", testCase.expectedError)
+			}
+
+			if !got.Equal(testCase.expected) {
+				t.Errorf("expected %s, got %s", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestDynamicValueUnmarshalWithCodec(t *testing.T) {
+	t.Parallel()
+
+	schema := &tfprotov5.Schema{
+		Block: &tfprotov5.SchemaBlock{
+			Attributes: []*tfprotov5.SchemaAttribute{
+				{
+					Name:     "test_number_attribute",
+					Type:     tftypes.Number,
+					Optional: true,
+				},
+				{
+					Name:     "test_set_attribute",
+					Type:     tftypes.Set{ElementType: tftypes.String},
+					Optional: true,
+				},
+			},
+		},
+	}
+	typ := schema.ValueType()
+	codec := schema.MsgPackCodec()
+
+	value := tftypes.NewValue(typ, map[string]tftypes.Value{
+		"test_number_attribute": tftypes.NewValue(tftypes.Number, 1),
+		"test_set_attribute": tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{
+			tftypes.NewValue(tftypes.String, "a"),
+			tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		}),
+	})
+
+	msgPackValue, err := tfprotov5.NewDynamicValueWithCodec(codec, value)
+
+	if err != nil {
+		t.Fatalf("unable to create DynamicValue: %s", err)
+	}
+
+	testCases := map[string]struct {
+		dynamicValue  tfprotov5.DynamicValue
+		expected      tftypes.Value
+		expectedError error
+	}{
+		"empty-dynamic-value": {
+			dynamicValue:  tfprotov5.DynamicValue{},
+			expectedError: tfprotov5.ErrUnknownDynamicValueType,
+		},
+		"msgpack": {
+			dynamicValue: msgPackValue,
+			expected:     value,
+		},
+		"msgpack-without-codec": {
+			dynamicValue: testNewDynamicValueMust(t, typ, value),
+			expected:     value,
+		},
+		"json": {
+			dynamicValue: tfprotov5.DynamicValue{
+				JSON: []byte(`{"test_set_attribute":["a"],"test_number_attribute":null}`),
+			},
+			expected: tftypes.NewValue(typ, map[string]tftypes.Value{
+				"test_number_attribute": tftypes.NewValue(tftypes.Number, nil),
+				"test_set_attribute": tftypes.NewValue(tftypes.Set{ElementType: tftypes.String}, []tftypes.Value{
+					tftypes.NewValue(tftypes.String, "a"),
+				}),
+			}),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := testCase.dynamicValue.UnmarshalWithCodec(codec)
+
+			if err != nil {
+				if testCase.expectedError == nil {
+					t.Fatalf("wanted no error, got error: %s", err)
+				}
+
+				if !errors.Is(err, testCase.expectedError) {
+					t.Fatalf("wanted error %q, got error: %s", testCase.expectedError.Error(), err.Error())
+				}
+
+				return
+			}
+
+			if testCase.expectedError != nil {
+				t.Fatalf("got no error, wanted err: %s", testCase.expectedError)
+			}
+
+			if !got.Equal(testCase.expected) {
+				t.Errorf("expected %s, got %s", testCase.expected, got)
+			}
+
+			// Unmarshal must return the same value as the codec.
+			unmarshaled, err := testCase.dynamicValue.Unmarshal(typ)
+
+			if err != nil {
+				t.Fatalf("unexpected Unmarshal error: %s", err)
+			}
+
+			if !unmarshaled.Equal(got) {
+				t.Errorf("expected Unmarshal to return %s, got %s", got, unmarshaled)
+			}
+		})
+	}
+}
+
 func testNewDynamicValueMust(t *testing.T, typ tftypes.Type, value tftypes.Value) tfprotov5.DynamicValue {
 	t.Helper()
 
@@ -0,0 +1,238 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfprotov5_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestFunctionErrorDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		functionError *tfprotov5.FunctionError
+		expected      tfprotov5.Diagnostics
+	}{
+		"nil": {
+			functionError: nil,
+			expected:      nil,
+		},
+		"empty": {
+			functionError: &tfprotov5.FunctionError{},
+			expected:      nil,
+		},
+		"text": {
+			functionError: &tfprotov5.FunctionError{
+				Text: "test function error",
+			},
+			expected: tfprotov5.Diagnostics{
+				{
+					Severity: tfprotov5.DiagnosticSeverityError,
+					Summary:  "Error in function call",
+					Detail:   "test function error",
+				},
+			},
+		},
+		"text-and-argument": {
+			functionError: &tfprotov5.FunctionError{
+				Text:             "test function error",
+				FunctionArgument: pointer(int64(2)),
+			},
+			expected: tfprotov5.Diagnostics{
+				{
+					Severity:  tfprotov5.DiagnosticSeverityError,
+					Summary:   "Error in function call",
+					Detail:    "test function error",
+					Attribute: tftypes.NewAttributePath().WithElementKeyInt(2),
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.functionError.Diagnostics()
+
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestDiagnosticsFunctionError(t *testing.T) {
+	t.Parallel()
+
+	function := &tfprotov5.Function{
+		Parameters: []*tfprotov5.FunctionParameter{
+			{
+				Name: "input",
+				Type: tftypes.String,
+			},
+			{
+				Name: "options",
+				Type: tftypes.Object{AttributeTypes: map[string]tftypes.Type{"trim": tftypes.Bool}},
+			},
+		},
+		VariadicParameter: &tfprotov5.FunctionParameter{
+			Name: "values",
+			Type: tftypes.String,
+		},
+		Return: &tfprotov5.FunctionReturn{
+			Type: tftypes.String,
+		},
+	}
+
+	testCases := map[string]struct {
+		diagnostics tfprotov5.Diagnostics
+		function    *tfprotov5.Function
+		expected    *tfprotov5.FunctionError
+	}{
+		"nil": {
+			diagnostics: nil,
+			expected:    nil,
+		},
+		"warnings": {
+			diagnostics: tfprotov5.Diagnostics{
+				{
+					Severity: tfprotov5.DiagnosticSeverityWarning,
+					Summary:  "test warning summary",
+				},
+			},
+			expected: nil,
+		},
+		"errors": {
+			diagnostics: tfprotov5.Diagnostics{
+				{
+					Severity: tfprotov5.DiagnosticSeverityError,
+					Summary:  "test error summary 1",
+					Detail:   "test error detail 1",
+				},
+				{
+					Severity: tfprotov5.DiagnosticSeverityWarning,
+					Summary:  "test warning summary",
+				},
+				{
+					Severity: tfprotov5.DiagnosticSeverityError,
+					Summary:  "test error summary 2",
+				},
+				{
+					Severity: tfprotov5.DiagnosticSeverityError,
+					Detail:   "test error detail 3",
+				},
+			},
+			expected: &tfprotov5.FunctionError{
+				Text: "test error summary 1: test error detail 1\ntest error summary 2\ntest error detail 3",
+			},
+		},
+		"element-key-int": {
+			diagnostics: tfprotov5.Diagnostics{
+				{
+					Severity:  tfprotov5.DiagnosticSeverityError,
+					Summary:   "test error summary",
+					Attribute: tftypes.NewAttributePath().WithElementKeyInt(3).WithAttributeName("trim"),
+				},
+			},
+			expected: &tfprotov5.FunctionError{
+				Text:             "test error summary",
+				FunctionArgument: pointer(int64(3)),
+			},
+		},
+		"parameter-name": {
+			diagnostics: tfprotov5.Diagnostics{
+				{
+					Severity: tfprotov5.DiagnosticSeverityError,
+					Summary:  "test error summary 1",
+				},
+				{
+					Severity:  tfprotov5.DiagnosticSeverityError,
+					Summary:   "test error summary 2",
+					Attribute: tftypes.NewAttributePath().WithAttributeName("options").WithAttributeName("trim"),
+				},
+				{
+					Severity:  tfprotov5.DiagnosticSeverityError,
+					Summary:   "test error summary 3",
+					Attribute: tftypes.NewAttributePath().WithAttributeName("input"),
+				},
+			},
+			function: function,
+			expected: &tfprotov5.FunctionError{
+				Text:             "test error summary 1\ntest error summary 2\ntest error summary 3",
+				FunctionArgument: pointer(int64(1)),
+			},
+		},
+		"parameter-name-without-function": {
+			diagnostics: tfprotov5.Diagnostics{
+				{
+					Severity:  tfprotov5.DiagnosticSeverityError,
+					Summary:   "test error summary",
+					Attribute: tftypes.NewAttributePath().WithAttributeName("input"),
+				},
+			},
+			expected: &tfprotov5.FunctionError{
+				Text: "test error summary",
+			},
+		},
+		"variadic-parameter-name": {
+			diagnostics: tfprotov5.Diagnostics{
+				{
+					Severity:  tfprotov5.DiagnosticSeverityError,
+					Summary:   "test error summary",
+					Attribute: tftypes.NewAttributePath().WithAttributeName("values").WithElementKeyInt(1),
+				},
+			},
+			function: function,
+			expected: &tfprotov5.FunctionError{
+				Text:             "test error summary",
+				FunctionArgument: pointer(int64(3)),
+			},
+		},
+		"variadic-parameter-name-without-index": {
+			diagnostics: tfprotov5.Diagnostics{
+				{
+					Severity:  tfprotov5.DiagnosticSeverityError,
+					Summary:   "test error summary",
+					Attribute: tftypes.NewAttributePath().WithAttributeName("values"),
+				},
+			},
+			function: function,
+			expected: &tfprotov5.FunctionError{
+				Text:             "test error summary",
+				FunctionArgument: pointer(int64(2)),
+			},
+		},
+		"unknown-parameter-name": {
+			diagnostics: tfprotov5.Diagnostics{
+				{
+					Severity:  tfprotov5.DiagnosticSeverityError,
+					Summary:   "test error summary",
+					Attribute: tftypes.NewAttributePath().WithAttributeName("other"),
+				},
+			},
+			function: function,
+			expected: &tfprotov5.FunctionError{
+				Text: "test error summary",
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := testCase.diagnostics.FunctionError(testCase.function)
+
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
@@ -10,26 +10,14 @@ import (
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 )
 
-// Diagnostics is a collection of Diagnostic.
-type Diagnostics []*tfprotov5.Diagnostic
+// Diagnostics is the public tfprotov5.Diagnostics collection, with the
+// logging the server needs. Its other behaviours are those of
+// tfprotov5.Diagnostics, so that the two cannot drift apart.
+type Diagnostics tfprotov5.Diagnostics
 
 // ErrorCount returns the number of error severity diagnostics.
 func (d Diagnostics) ErrorCount() int {
-	var result int
-
-	for _, diagnostic := range d {
-		if diagnostic == nil {
-			continue
-		}
-
-		if diagnostic.Severity != tfprotov5.DiagnosticSeverityError {
-			continue
-		}
-
-		result++
-	}
-
-	return result
+	return len(tfprotov5.Diagnostics(d).Errors())
 }
 
 // Log will log every diagnostic:
@@ -66,19 +54,5 @@ func (d Diagnostics) Log(ctx context.Context) {
 
 // WarningCount returns the number of warning severity diagnostics.
 func (d Diagnostics) WarningCount() int {
-	var result int
-
-	for _, diagnostic := range d {
-		if diagnostic == nil {
-			continue
-		}
-
-		if diagnostic.Severity != tfprotov5.DiagnosticSeverityWarning {
-			continue
-		}
-
-		result++
-	}
-
-	return result
+	return len(tfprotov5.Diagnostics(d).Warnings())
 }
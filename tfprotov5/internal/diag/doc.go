@@ -1,6 +1,7 @@
 // Copyright IBM Corp. 2020, 2026
 // SPDX-License-Identifier: MPL-2.0
 
-// Package diag contains diagnostics helpers. These implementations are
+// Package diag contains diagnostics helpers for the server, such as logging,
+// on top of the public tfprotov5.Diagnostics type. These implementations are
 // intentionally outside the public API.
 package diag
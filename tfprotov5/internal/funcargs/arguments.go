@@ -0,0 +1,107 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package funcargs
+
+import (
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Decode returns the values of the arguments of a call to `function`, or a
+// FunctionError if there are too few or too many arguments, or if an
+// argument cannot be decoded with the type of its parameter or is null for a
+// parameter that does not allow null values. Arguments after the parameters
+// are arguments of the variadic parameter.
+//
+// Unknown values are not checked, as callers handle them differently; use
+// Unknown to find arguments that are unknown for parameters that do not
+// allow unknown values.
+func Decode(function *tfprotov5.Function, arguments []*tfprotov5.DynamicValue) ([]tftypes.Value, *tfprotov5.FunctionError) {
+	if len(arguments) < len(function.Parameters) || (function.VariadicParameter == nil && len(arguments) > len(function.Parameters)) {
+		return nil, &tfprotov5.FunctionError{
+			Text: fmt.Sprintf("Expected %s, got %d.", expected(function), len(arguments)),
+		}
+	}
+
+	values := make([]tftypes.Value, len(arguments))
+
+	for pos, argument := range arguments {
+		parameter := Parameter(function, pos)
+
+		if parameter == nil || parameter.Type == nil {
+			return nil, &tfprotov5.FunctionError{
+				Text: fmt.Sprintf("The definition of the function has no type for argument %d.", pos),
+			}
+		}
+
+		if argument == nil {
+			return nil, argumentError(parameter, pos, "missing argument.")
+		}
+
+		val, err := argument.Unmarshal(parameter.Type)
+		if err != nil {
+			return nil, argumentError(parameter, pos, "%s.", err)
+		}
+
+		if val.IsNull() && !parameter.AllowNullValue {
+			return nil, argumentError(parameter, pos, "argument must not be null.")
+		}
+
+		values[pos] = val
+	}
+
+	return values, nil
+}
+
+// Unknown returns a FunctionError for the first of `values`, the arguments of
+// a call to `function`, that is not fully known while its parameter does not
+// allow unknown values, or nil if there is none.
+func Unknown(function *tfprotov5.Function, values []tftypes.Value) *tfprotov5.FunctionError {
+	for pos, val := range values {
+		parameter := Parameter(function, pos)
+
+		if parameter != nil && !parameter.AllowUnknownValues && !val.IsFullyKnown() {
+			return argumentError(parameter, pos, "argument must not be unknown.")
+		}
+	}
+
+	return nil
+}
+
+// Parameter returns the parameter of the argument at `pos` in a call to
+// `function`, which is the variadic parameter for arguments after the
+// parameters.
+func Parameter(function *tfprotov5.Function, pos int) *tfprotov5.FunctionParameter {
+	if pos < len(function.Parameters) {
+		return function.Parameters[pos]
+	}
+
+	return function.VariadicParameter
+}
+
+func argumentError(parameter *tfprotov5.FunctionParameter, pos int, format string, a ...any) *tfprotov5.FunctionError {
+	index := int64(pos)
+
+	return &tfprotov5.FunctionError{
+		Text:             fmt.Sprintf("Invalid value for %q parameter: ", parameter.Name) + fmt.Sprintf(format, a...),
+		FunctionArgument: &index,
+	}
+}
+
+// expected describes the number of arguments `function` expects.
+func expected(function *tfprotov5.Function) string {
+	res := fmt.Sprintf("%d arguments", len(function.Parameters))
+
+	if len(function.Parameters) == 1 {
+		res = "1 argument"
+	}
+
+	if function.VariadicParameter != nil {
+		res = "at least " + res
+	}
+
+	return res
+}
@@ -0,0 +1,212 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package funcargs_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/internal/funcargs"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func testFunction() *tfprotov5.Function {
+	return &tfprotov5.Function{
+		Parameters: []*tfprotov5.FunctionParameter{
+			{
+				Name: "input",
+				Type: tftypes.String,
+			},
+			{
+				Name:               "value",
+				Type:               tftypes.DynamicPseudoType,
+				AllowNullValue:     true,
+				AllowUnknownValues: true,
+			},
+		},
+		VariadicParameter: &tfprotov5.FunctionParameter{
+			Name: "values",
+			Type: tftypes.Number,
+		},
+		Return: &tfprotov5.FunctionReturn{
+			Type: tftypes.String,
+		},
+	}
+}
+
+// testArgument encodes `val` as Terraform would for `parameter`, with the
+// type of the value unless the parameter is dynamic.
+func testArgument(t *testing.T, parameter *tfprotov5.FunctionParameter, val tftypes.Value) *tfprotov5.DynamicValue {
+	t.Helper()
+
+	typ := val.Type()
+
+	if parameter != nil && parameter.Type.Is(tftypes.DynamicPseudoType) {
+		typ = tftypes.DynamicPseudoType
+	}
+
+	dv, err := tfprotov5.NewDynamicValue(typ, val)
+	if err != nil {
+		t.Fatalf("unexpected error encoding argument: %s", err)
+	}
+
+	return &dv
+}
+
+func TestDecode(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		function        *tfprotov5.Function
+		arguments       []tftypes.Value
+		nilArgument     bool
+		expected        []tftypes.Value
+		expectedError   *tfprotov5.FunctionError
+		expectedUnknown *tfprotov5.FunctionError
+	}{
+		"arguments": {
+			function: testFunction(),
+			arguments: []tftypes.Value{
+				tftypes.NewValue(tftypes.String, "test"),
+				tftypes.NewValue(tftypes.Bool, true),
+				tftypes.NewValue(tftypes.Number, 1),
+				tftypes.NewValue(tftypes.Number, 2),
+			},
+			expected: []tftypes.Value{
+				tftypes.NewValue(tftypes.String, "test"),
+				tftypes.NewValue(tftypes.Bool, true),
+				tftypes.NewValue(tftypes.Number, 1),
+				tftypes.NewValue(tftypes.Number, 2),
+			},
+		},
+		"allowed-null-and-unknown": {
+			function: testFunction(),
+			arguments: []tftypes.Value{
+				tftypes.NewValue(tftypes.String, "test"),
+				tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+					tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				}),
+			},
+			expected: []tftypes.Value{
+				tftypes.NewValue(tftypes.String, "test"),
+				tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+					tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				}),
+			},
+		},
+		"too-few-arguments": {
+			function: testFunction(),
+			arguments: []tftypes.Value{
+				tftypes.NewValue(tftypes.String, "test"),
+			},
+			expectedError: &tfprotov5.FunctionError{
+				Text: "Expected at least 2 arguments, got 1.",
+			},
+		},
+		"too-many-arguments": {
+			function: &tfprotov5.Function{
+				Parameters: []*tfprotov5.FunctionParameter{
+					{
+						Name: "input",
+						Type: tftypes.String,
+					},
+				},
+			},
+			arguments: []tftypes.Value{
+				tftypes.NewValue(tftypes.String, "a"),
+				tftypes.NewValue(tftypes.String, "b"),
+			},
+			expectedError: &tfprotov5.FunctionError{
+				Text: "Expected 1 argument, got 2.",
+			},
+		},
+		"missing-argument": {
+			function:    testFunction(),
+			nilArgument: true,
+			arguments: []tftypes.Value{
+				tftypes.NewValue(tftypes.String, "test"),
+			},
+			expectedError: &tfprotov5.FunctionError{
+				Text:             `Invalid value for "value" parameter: missing argument.`,
+				FunctionArgument: pointer(int64(1)),
+			},
+		},
+		"wrong-type": {
+			function: testFunction(),
+			arguments: []tftypes.Value{
+				tftypes.NewValue(tftypes.String, "test"),
+				tftypes.NewValue(tftypes.String, "test"),
+				tftypes.NewValue(tftypes.String, "test"),
+			},
+			expectedError: &tfprotov5.FunctionError{
+				Text:             `Invalid value for "values" parameter: error parsing "test" as number: number has no digits.`,
+				FunctionArgument: pointer(int64(2)),
+			},
+		},
+		"null": {
+			function: testFunction(),
+			arguments: []tftypes.Value{
+				tftypes.NewValue(tftypes.String, nil),
+				tftypes.NewValue(tftypes.String, "test"),
+			},
+			expectedError: &tfprotov5.FunctionError{
+				Text:             `Invalid value for "input" parameter: argument must not be null.`,
+				FunctionArgument: pointer(int64(0)),
+			},
+		},
+		"unknown": {
+			function: testFunction(),
+			arguments: []tftypes.Value{
+				tftypes.NewValue(tftypes.String, "test"),
+				tftypes.NewValue(tftypes.String, "test"),
+				tftypes.NewValue(tftypes.Number, tftypes.UnknownValue),
+			},
+			expected: []tftypes.Value{
+				tftypes.NewValue(tftypes.String, "test"),
+				tftypes.NewValue(tftypes.String, "test"),
+				tftypes.NewValue(tftypes.Number, tftypes.UnknownValue),
+			},
+			expectedUnknown: &tfprotov5.FunctionError{
+				Text:             `Invalid value for "values" parameter: argument must not be unknown.`,
+				FunctionArgument: pointer(int64(2)),
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var arguments []*tfprotov5.DynamicValue
+
+			for pos, argument := range testCase.arguments {
+				arguments = append(arguments, testArgument(t, funcargs.Parameter(testCase.function, pos), argument))
+			}
+
+			if testCase.nilArgument {
+				arguments = append(arguments, nil)
+			}
+
+			got, funcErr := funcargs.Decode(testCase.function, arguments)
+
+			if diff := cmp.Diff(testCase.expectedError, funcErr); diff != "" {
+				t.Fatalf("unexpected function error difference: %s", diff)
+			}
+
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+
+			if funcErr != nil {
+				return
+			}
+
+			if diff := cmp.Diff(testCase.expectedUnknown, funcargs.Unknown(testCase.function, got)); diff != "" {
+				t.Errorf("unexpected unknown function error difference: %s", diff)
+			}
+		})
+	}
+}
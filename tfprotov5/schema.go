@@ -84,6 +84,20 @@ func (s *Schema) ValueType() tftypes.Type {
 	return s.Block.ValueType()
 }
 
+// MsgPackCodec returns the tftypes.MsgPackCodec for the ValueType of the
+// Schema, for use with NewDynamicValueWithCodec and
+// DynamicValue.UnmarshalWithCodec. Codecs are cached by type, so repeated
+// calls for the same Schema return the same codec.
+//
+// Every call still builds the ValueType of the Schema, hashes it, and compares
+// it with the type of the cached codec, which costs about as much as walking
+// the whole Schema. Providers that decode many values with a large Schema
+// should call MsgPackCodec once and keep the codec, rather than calling it
+// for every value.
+func (s *Schema) MsgPackCodec() *tftypes.MsgPackCodec {
+	return tftypes.NewMsgPackCodec(s.ValueType())
+}
+
 // SchemaBlock represents a block in a schema. Blocks are how Terraform creates
 // groupings of attributes. In configurations, they don't use the equals sign
 // and use dynamic instead of list comprehensions.
@@ -0,0 +1,328 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfprotov5
+
+import (
+	"maps"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// MarkComputedUnknownOpts contains options that can be used to modify the
+// behaviour of SchemaBlock.MarkComputedUnknownWithOpts.
+type MarkComputedUnknownOpts struct {
+	// KeepPriorState contains the paths of computed attributes that should
+	// keep their prior state value instead of being marked unknown, such
+	// as identifiers that never change after creation. A path also keeps
+	// the prior state value of any attribute nested underneath it.
+	//
+	// The elements of sets are identified by their planned value, which
+	// is not known in advance, so a path may leave out the
+	// ElementKeyValue step of a set to refer to the attribute in every
+	// element of the set.
+	KeepPriorState []*tftypes.AttributePath
+}
+
+// keepPriorState returns true if the attribute at path, or any of its
+// parents, should keep its prior state value.
+func (o MarkComputedUnknownOpts) keepPriorState(path *tftypes.AttributePath) bool {
+	steps := path.Steps()
+	withoutSetElements := make([]tftypes.AttributePathStep, 0, len(steps))
+
+	for _, step := range steps {
+		if _, ok := step.(tftypes.ElementKeyValue); !ok {
+			withoutSetElements = append(withoutSetElements, step)
+		}
+	}
+
+	for _, keep := range o.KeepPriorState {
+		if hasPathPrefix(steps, keep) || hasPathPrefix(withoutSetElements, keep) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// hasPathPrefix returns true if the path with the steps is prefix, or is
+// nested underneath it.
+func hasPathPrefix(steps []tftypes.AttributePathStep, prefix *tftypes.AttributePath) bool {
+	prefixSteps := prefix.Steps()
+
+	if len(prefixSteps) > len(steps) {
+		return false
+	}
+
+	return tftypes.NewAttributePathWithSteps(steps[:len(prefixSteps)]).Equal(prefix)
+}
+
+// MarkComputedUnknown returns the planned value for a resource using the
+// SchemaBlock, given its configuration and prior state. The planned value is
+// the proposed new state, as returned by Schema.ProposedNewState, with every
+// computed attribute that is not set in the configuration marked as unknown,
+// including optional and computed attributes. Nested blocks, in every nesting
+// mode, are walked so that their computed attributes are marked as well.
+//
+// Only the computed attributes of objects which changed are marked: if the
+// planned value of the resource or a nested block is equal to its prior state
+// value, so the configuration did not change it, its computed attributes keep
+// their prior state values. This means planning an unchanged configuration
+// plans no changes. The elements of lists are
+// compared with the prior elements at the same index, the elements of maps
+// with the prior elements with the same key, and the elements of sets with
+// any equal prior element.
+//
+// If config is null, as it is when a resource is being destroyed, the
+// returned value is null.
+func (s *SchemaBlock) MarkComputedUnknown(config tftypes.Value, priorState tftypes.Value) (tftypes.Value, error) {
+	return s.MarkComputedUnknownWithOpts(config, priorState, MarkComputedUnknownOpts{})
+}
+
+// MarkComputedUnknownWithOpts is identical to MarkComputedUnknown but also
+// accepts a MarkComputedUnknownOpts which contains options that can be used
+// to keep prior state values for specific computed attributes.
+func (s *SchemaBlock) MarkComputedUnknownWithOpts(config tftypes.Value, priorState tftypes.Value, opts MarkComputedUnknownOpts) (tftypes.Value, error) {
+	if config.IsNull() {
+		return tftypes.NewValue(s.ValueType(), nil), nil
+	}
+
+	path := tftypes.NewAttributePath()
+
+	planned, err := s.proposedNew(path, priorState, config)
+
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+
+	return s.markComputedUnknown(path, planned, priorState, config, opts)
+}
+
+func (s *SchemaBlock) markComputedUnknown(path *tftypes.AttributePath, planned tftypes.Value, prior tftypes.Value, config tftypes.Value, opts MarkComputedUnknownOpts) (tftypes.Value, error) {
+	if s == nil || planned.IsNull() || !planned.IsKnown() || config.IsNull() || !config.IsKnown() {
+		return planned, nil
+	}
+
+	if unchangedFromPrior(planned, prior) {
+		return planned, nil
+	}
+
+	newAttrs, err := markComputedUnknownAttributes(path, s.Attributes, planned, config, opts)
+
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+
+	for _, blockType := range s.BlockTypes {
+		if blockType == nil {
+			continue
+		}
+
+		blockPath := path.WithAttributeName(blockType.TypeName)
+
+		plannedV, err := objectAttribute(blockPath, planned, blockType.TypeName)
+
+		if err != nil {
+			return tftypes.Value{}, err
+		}
+
+		priorV, err := priorAttribute(blockPath, prior, blockType.TypeName, plannedV.Type())
+
+		if err != nil {
+			return tftypes.Value{}, err
+		}
+
+		configV, err := objectAttribute(blockPath, config, blockType.TypeName)
+
+		if err != nil {
+			return tftypes.Value{}, err
+		}
+
+		switch blockType.Nesting {
+		case SchemaNestedBlockNestingModeSingle, SchemaNestedBlockNestingModeGroup:
+			newAttrs[blockType.TypeName], err = blockType.Block.markComputedUnknown(blockPath, plannedV, priorV, configV, opts)
+		default:
+			newAttrs[blockType.TypeName], err = markComputedUnknownElements(blockPath, plannedV, priorV, configV, func(elemPath *tftypes.AttributePath, plannedEV tftypes.Value, priorEV tftypes.Value, configEV tftypes.Value) (tftypes.Value, error) {
+				return blockType.Block.markComputedUnknown(elemPath, plannedEV, priorEV, configEV, opts)
+			})
+		}
+
+		if err != nil {
+			return tftypes.Value{}, err
+		}
+	}
+
+	return newProposedValue(path, planned.Type(), newAttrs)
+}
+
+// markComputedUnknownAttributes returns the attributes of the planned object,
+// with computed attributes that are null in the configuration object marked
+// as unknown.
+func markComputedUnknownAttributes(path *tftypes.AttributePath, attrs []*SchemaAttribute, planned tftypes.Value, config tftypes.Value, opts MarkComputedUnknownOpts) (map[string]tftypes.Value, error) {
+	plannedAttrs := map[string]tftypes.Value{}
+
+	if err := planned.As(&plannedAttrs); err != nil {
+		return nil, path.NewError(err)
+	}
+
+	newAttrs := maps.Clone(plannedAttrs)
+
+	for _, attr := range attrs {
+		if attr == nil {
+			continue
+		}
+
+		attrPath := path.WithAttributeName(attr.Name)
+
+		plannedV, err := objectAttribute(attrPath, planned, attr.Name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		configV, err := objectAttribute(attrPath, config, attr.Name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if attr.Computed && configV.IsNull() && !opts.keepPriorState(attrPath) {
+			newAttrs[attr.Name] = tftypes.NewValue(plannedV.Type(), tftypes.UnknownValue)
+		}
+	}
+
+	return newAttrs, nil
+}
+
+// markComputedUnknownElements calls markElement for every element of a list,
+// set, or map of nested blocks, along with the corresponding prior element and
+// the configuration element it was planned from. The planned value must have
+// been created from the configuration, so that its elements are in the same
+// order and have the same keys. Elements without a corresponding prior element
+// get a null prior element.
+func markComputedUnknownElements(path *tftypes.AttributePath, planned tftypes.Value, prior tftypes.Value, config tftypes.Value, markElement func(*tftypes.AttributePath, tftypes.Value, tftypes.Value, tftypes.Value) (tftypes.Value, error)) (tftypes.Value, error) {
+	if planned.IsNull() || !planned.IsKnown() || config.IsNull() || !config.IsKnown() {
+		return planned, nil
+	}
+
+	priorKnown := !prior.IsNull() && prior.IsKnown()
+
+	switch planned.Type().(type) {
+	case tftypes.List, tftypes.Set, tftypes.Tuple:
+		var plannedVals, priorVals, configVals []tftypes.Value
+
+		if err := planned.As(&plannedVals); err != nil {
+			return tftypes.Value{}, path.NewError(err)
+		}
+
+		if priorKnown {
+			if err := prior.As(&priorVals); err != nil {
+				return tftypes.Value{}, path.NewError(err)
+			}
+		}
+
+		if err := config.As(&configVals); err != nil {
+			return tftypes.Value{}, path.NewError(err)
+		}
+
+		if len(plannedVals) != len(configVals) {
+			return tftypes.Value{}, path.NewErrorf("planned value has %d elements, configuration has %d", len(plannedVals), len(configVals))
+		}
+
+		newVals := make([]tftypes.Value, 0, len(plannedVals))
+
+		for idx, plannedEV := range plannedVals {
+			elemPath := path.WithElementKeyInt(idx)
+			priorEV := tftypes.NewValue(plannedEV.Type(), nil)
+
+			if planned.Type().Is(tftypes.Set{}) {
+				elemPath = path.WithElementKeyValue(plannedEV)
+
+				// Set elements have no identity other than their
+				// value, so only an equal prior element is
+				// unchanged.
+				for _, priorCmp := range priorVals {
+					if priorCmp.Equal(plannedEV) {
+						priorEV = priorCmp
+
+						break
+					}
+				}
+			} else if idx < len(priorVals) {
+				priorEV = priorVals[idx]
+			}
+
+			newEV, err := markElement(elemPath, plannedEV, priorEV, configVals[idx])
+
+			if err != nil {
+				return tftypes.Value{}, err
+			}
+
+			newVals = append(newVals, newEV)
+		}
+
+		return newProposedValue(path, planned.Type(), newVals)
+	case tftypes.Map, tftypes.Object:
+		plannedVals := map[string]tftypes.Value{}
+		priorVals := map[string]tftypes.Value{}
+		configVals := map[string]tftypes.Value{}
+
+		if err := planned.As(&plannedVals); err != nil {
+			return tftypes.Value{}, path.NewError(err)
+		}
+
+		if priorKnown {
+			if err := prior.As(&priorVals); err != nil {
+				return tftypes.Value{}, path.NewError(err)
+			}
+		}
+
+		if err := config.As(&configVals); err != nil {
+			return tftypes.Value{}, path.NewError(err)
+		}
+
+		newVals := make(map[string]tftypes.Value, len(plannedVals))
+
+		for key, plannedEV := range plannedVals {
+			configEV, ok := configVals[key]
+
+			if !ok {
+				return tftypes.Value{}, path.WithElementKeyString(key).NewErrorf("planned element not found in configuration")
+			}
+
+			priorEV, ok := priorVals[key]
+
+			if !ok {
+				priorEV = tftypes.NewValue(plannedEV.Type(), nil)
+			}
+
+			newEV, err := markElement(path.WithElementKeyString(key), plannedEV, priorEV, configEV)
+
+			if err != nil {
+				return tftypes.Value{}, err
+			}
+
+			newVals[key] = newEV
+		}
+
+		return newProposedValue(path, planned.Type(), newVals)
+	default:
+		return tftypes.Value{}, path.NewErrorf("unexpected nested value type: %s", planned.Type())
+	}
+}
+
+// unchangedFromPrior returns true if the planned object is equal to its prior
+// state value, meaning the configuration did not change it.
+func unchangedFromPrior(planned tftypes.Value, prior tftypes.Value) bool {
+	return !prior.IsNull() && prior.IsKnown() && planned.Equal(prior)
+}
+
+// priorAttribute returns the named attribute of a prior object, which is null
+// if the prior object is null or unknown, as there is no prior value then.
+func priorAttribute(path *tftypes.AttributePath, prior tftypes.Value, name string, typ tftypes.Type) (tftypes.Value, error) {
+	if prior.IsNull() || !prior.IsKnown() {
+		return tftypes.NewValue(typ, nil), nil
+	}
+
+	return objectAttribute(path, prior, name)
+}
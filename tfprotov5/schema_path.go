@@ -0,0 +1,187 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfprotov5
+
+import (
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// SchemaPathNesting describes a nested block that a tftypes.AttributePath
+// passes through, as returned by AttributeAtPath and BlockAtPath.
+type SchemaPathNesting struct {
+	// Path is the path of the nested block.
+	Path *tftypes.AttributePath
+
+	// Block is the nested block the path passes through.
+	Block *SchemaNestedBlock
+}
+
+// IsSet returns true if the nested block is a set, in which case the elements
+// of the path within it are identified by their value rather than by an index
+// or key.
+func (n SchemaPathNesting) IsSet() bool {
+	return n.Block != nil && n.Block.Nesting == SchemaNestedBlockNestingModeSet
+}
+
+// AttributeAtPath returns the attribute the path refers to, relative to the
+// object described by the Schema, or nil if the path does not refer to an
+// attribute. See SchemaBlock.AttributeAtPath for details.
+func (s *Schema) AttributeAtPath(path *tftypes.AttributePath) (*SchemaAttribute, []SchemaPathNesting) {
+	if s == nil {
+		return nil, nil
+	}
+
+	return s.Block.AttributeAtPath(path)
+}
+
+// BlockAtPath returns the nested block the path refers to, relative to the
+// object described by the Schema, or nil if the path does not refer to a
+// nested block. See SchemaBlock.BlockAtPath for details.
+func (s *Schema) BlockAtPath(path *tftypes.AttributePath) (*SchemaNestedBlock, []SchemaPathNesting) {
+	if s == nil {
+		return nil, nil
+	}
+
+	return s.Block.BlockAtPath(path)
+}
+
+// AttributeAtPath returns the attribute the path refers to, relative to the
+// object described by the block, or nil if the path does not refer to an
+// attribute. Paths to values within an attribute, such as an element of a
+// collection attribute, refer to that attribute.
+//
+// The path must have an element step, of the kind its nesting mode uses,
+// after the name of every nested block other than single and group nesting.
+// The returned SchemaPathNesting are the nested blocks the path passes
+// through, outermost first, which describe whether the path is within a set,
+// for example.
+func (s *SchemaBlock) AttributeAtPath(path *tftypes.AttributePath) (*SchemaAttribute, []SchemaPathNesting) {
+	res := s.lookupPath(path.Steps())
+
+	return res.attribute, res.nesting
+}
+
+// BlockAtPath returns the nested block the path refers to, relative to the
+// object described by the block, or nil if the path does not refer to a
+// nested block. Paths to an element of a nested block refer to that nested
+// block, while paths to attributes within nested blocks do not.
+//
+// The returned SchemaPathNesting are the nested blocks the path passes
+// through, outermost first, including the returned block if the path refers
+// to one of its elements. See AttributeAtPath for the element steps the path
+// must have.
+func (s *SchemaBlock) BlockAtPath(path *tftypes.AttributePath) (*SchemaNestedBlock, []SchemaPathNesting) {
+	res := s.lookupPath(path.Steps())
+
+	return res.block, res.nesting
+}
+
+// schemaPathResult is the result of looking up a path in a schema. At most
+// one of attribute and block is set.
+type schemaPathResult struct {
+	attribute *SchemaAttribute
+	block     *SchemaNestedBlock
+	nesting   []SchemaPathNesting
+
+	// exact is true if the path refers to the attribute or block itself,
+	// rather than to a value within it.
+	exact bool
+}
+
+// lookupPath returns the attribute or nested block `steps` refers to,
+// relative to the object described by the block.
+func (s *SchemaBlock) lookupPath(steps []tftypes.AttributePathStep) schemaPathResult {
+	var res schemaPathResult
+
+	block := s
+
+	for pos := 0; pos < len(steps); pos++ {
+		name, ok := steps[pos].(tftypes.AttributeName)
+
+		if !ok || block == nil {
+			return schemaPathResult{}
+		}
+
+		if attr := block.attributeNamed(string(name)); attr != nil {
+			// The rest of the path is within the value of the
+			// attribute.
+			res.attribute = attr
+			res.exact = pos == len(steps)-1
+
+			return res
+		}
+
+		blockType := block.nestedBlockNamed(string(name))
+
+		if blockType == nil {
+			return schemaPathResult{}
+		}
+
+		res.block = blockType
+		res.exact = pos == len(steps)-1
+
+		if res.exact {
+			return res
+		}
+
+		res.nesting = append(res.nesting, SchemaPathNesting{
+			Path:  tftypes.NewAttributePathWithSteps(steps[:pos+1]),
+			Block: blockType,
+		})
+
+		switch blockType.Nesting {
+		case SchemaNestedBlockNestingModeSingle, SchemaNestedBlockNestingModeGroup:
+		default:
+			pos++
+
+			if !blockType.elementStepValid(steps[pos]) {
+				return schemaPathResult{}
+			}
+
+			if pos == len(steps)-1 {
+				return res
+			}
+		}
+
+		res.block = nil
+		block = blockType.Block
+	}
+
+	return schemaPathResult{}
+}
+
+func (s *SchemaBlock) attributeNamed(name string) *SchemaAttribute {
+	for _, attr := range s.Attributes {
+		if attr != nil && attr.Name == name {
+			return attr
+		}
+	}
+
+	return nil
+}
+
+func (s *SchemaBlock) nestedBlockNamed(name string) *SchemaNestedBlock {
+	for _, blockType := range s.BlockTypes {
+		if blockType != nil && blockType.TypeName == name {
+			return blockType
+		}
+	}
+
+	return nil
+}
+
+// elementStepValid returns true if the step is the kind of element step used
+// by the nesting mode of the block.
+func (s *SchemaNestedBlock) elementStepValid(step tftypes.AttributePathStep) bool {
+	switch step.(type) {
+	case tftypes.ElementKeyInt:
+		return s.Nesting == SchemaNestedBlockNestingModeList
+	case tftypes.ElementKeyValue:
+		return s.Nesting == SchemaNestedBlockNestingModeSet
+	case tftypes.ElementKeyString:
+		return s.Nesting == SchemaNestedBlockNestingModeMap
+	}
+
+	return false
+}
@@ -0,0 +1,213 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfprotov5_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSchemaAttributeAtPath(t *testing.T) {
+	t.Parallel()
+
+	schema := testSchemaPathSchema()
+	block := schema.Block
+	ruleBlock := block.BlockTypes[0]
+
+	testCases := map[string]struct {
+		path              *tftypes.AttributePath
+		expected          *tfprotov5.SchemaAttribute
+		expectedNestingTo []string
+		expectedSet       []bool
+	}{
+		"root": {
+			path: tftypes.NewAttributePath(),
+		},
+		"attribute": {
+			path:     tftypes.NewAttributePath().WithAttributeName("id"),
+			expected: block.Attributes[0],
+		},
+		"collection-element": {
+			path:     tftypes.NewAttributePath().WithAttributeName("tags").WithElementKeyString("env"),
+			expected: block.Attributes[1],
+		},
+		"missing": {
+			path: tftypes.NewAttributePath().WithAttributeName("missing"),
+		},
+		"list-block-attribute": {
+			path:              tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyInt(0).WithAttributeName("port"),
+			expected:          ruleBlock.Block.Attributes[0],
+			expectedNestingTo: []string{"rule"},
+			expectedSet:       []bool{false},
+		},
+		"list-block-wrong-element-step": {
+			path: tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyString("a").WithAttributeName("port"),
+		},
+		"set-block-attribute": {
+			path: tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyInt(0).
+				WithAttributeName("target").WithElementKeyValue(tftypes.NewValue(tftypes.String, "x")).WithAttributeName("address"),
+			expected:          ruleBlock.Block.BlockTypes[0].Block.Attributes[0],
+			expectedNestingTo: []string{"rule", "target"},
+			expectedSet:       []bool{false, true},
+		},
+		"group-block-attribute": {
+			path:              tftypes.NewAttributePath().WithAttributeName("settings").WithAttributeName("mode"),
+			expected:          block.BlockTypes[1].Block.Attributes[0],
+			expectedNestingTo: []string{"settings"},
+			expectedSet:       []bool{false},
+		},
+		"block-not-attribute": {
+			path: tftypes.NewAttributePath().WithAttributeName("rule"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, nesting := schema.AttributeAtPath(testCase.path)
+
+			if got != testCase.expected {
+				t.Errorf("expected attribute %v, got %v", testCase.expected, got)
+			}
+
+			if diff := cmp.Diff(testCase.expectedNestingTo, schemaPathNestingNames(nesting)); diff != "" {
+				t.Errorf("unexpected nesting difference: %s", diff)
+			}
+
+			var gotSet []bool
+
+			for _, n := range nesting {
+				gotSet = append(gotSet, n.IsSet())
+			}
+
+			if diff := cmp.Diff(testCase.expectedSet, gotSet); diff != "" {
+				t.Errorf("unexpected set difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestSchemaBlockAtPath(t *testing.T) {
+	t.Parallel()
+
+	schema := testSchemaPathSchema()
+	ruleBlock := schema.Block.BlockTypes[0]
+
+	testCases := map[string]struct {
+		path              *tftypes.AttributePath
+		expected          *tfprotov5.SchemaNestedBlock
+		expectedNestingTo []string
+	}{
+		"block": {
+			path:     tftypes.NewAttributePath().WithAttributeName("rule"),
+			expected: ruleBlock,
+		},
+		"block-element": {
+			path:              tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyInt(1),
+			expected:          ruleBlock,
+			expectedNestingTo: []string{"rule"},
+		},
+		"nested-block": {
+			path:              tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyInt(1).WithAttributeName("target"),
+			expected:          ruleBlock.Block.BlockTypes[0],
+			expectedNestingTo: []string{"rule"},
+		},
+		"attribute": {
+			path: tftypes.NewAttributePath().WithAttributeName("id"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, nesting := schema.BlockAtPath(testCase.path)
+
+			if got != testCase.expected {
+				t.Errorf("expected block %v, got %v", testCase.expected, got)
+			}
+
+			if diff := cmp.Diff(testCase.expectedNestingTo, schemaPathNestingNames(nesting)); diff != "" {
+				t.Errorf("unexpected nesting difference: %s", diff)
+			}
+		})
+	}
+}
+
+func schemaPathNestingNames(nesting []tfprotov5.SchemaPathNesting) []string {
+	var names []string
+
+	for _, n := range nesting {
+		names = append(names, n.Block.TypeName)
+	}
+
+	return names
+}
+
+func testSchemaPathSchema() *tfprotov5.Schema {
+	return &tfprotov5.Schema{
+		Block: &tfprotov5.SchemaBlock{
+			Attributes: []*tfprotov5.SchemaAttribute{
+				{
+					Name:     "id",
+					Type:     tftypes.String,
+					Computed: true,
+				},
+				{
+					Name:     "tags",
+					Type:     tftypes.Map{ElementType: tftypes.String},
+					Optional: true,
+				},
+			},
+			BlockTypes: []*tfprotov5.SchemaNestedBlock{
+				{
+					TypeName: "rule",
+					Nesting:  tfprotov5.SchemaNestedBlockNestingModeList,
+					Block: &tfprotov5.SchemaBlock{
+						Attributes: []*tfprotov5.SchemaAttribute{
+							{
+								Name:     "port",
+								Type:     tftypes.Number,
+								Required: true,
+							},
+						},
+						BlockTypes: []*tfprotov5.SchemaNestedBlock{
+							{
+								TypeName: "target",
+								Nesting:  tfprotov5.SchemaNestedBlockNestingModeSet,
+								Block: &tfprotov5.SchemaBlock{
+									Attributes: []*tfprotov5.SchemaAttribute{
+										{
+											Name:     "address",
+											Type:     tftypes.String,
+											Required: true,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				{
+					TypeName: "settings",
+					Nesting:  tfprotov5.SchemaNestedBlockNestingModeGroup,
+					Block: &tfprotov5.SchemaBlock{
+						Attributes: []*tfprotov5.SchemaAttribute{
+							{
+								Name:     "mode",
+								Type:     tftypes.String,
+								Optional: true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,523 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfprotov5
+
+import (
+	"errors"
+	"fmt"
+	"maps"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// ProposedNewState returns the value Terraform would send as the
+// ProposedNewState of a PlanResourceChangeRequest, given the prior state and
+// the configuration of a resource using the Schema. It follows the same rules
+// as Terraform's own merge of configuration and prior state, which makes it
+// useful for unit testing PlanResourceChange implementations and for tooling
+// that drives the resource lifecycle without Terraform.
+//
+// Configured values are always used, with the following exceptions:
+//
+//   - Computed attributes that are null in the configuration use the prior
+//     state value.
+//
+//   - Nested blocks are correlated with the prior state (by index for lists,
+//     by key for maps, and by comparing non-computed values for sets) and
+//     merged recursively using the same rules.
+//
+// If priorState is null, as it is when a resource is being created, it is
+// treated as an object with all attributes null and all nested block
+// collections empty.
+//
+// If config is null, as it is when a resource is being destroyed, the
+// returned value is null. This matches what Terraform sends in a
+// PlanResourceChangeRequest when planning to destroy a resource.
+func (s *Schema) ProposedNewState(priorState tftypes.Value, config tftypes.Value) (tftypes.Value, error) {
+	var block *SchemaBlock
+
+	if s != nil {
+		block = s.Block
+	}
+
+	if config.IsNull() {
+		return tftypes.NewValue(s.ValueType(), nil), nil
+	}
+
+	return block.proposedNew(tftypes.NewAttributePath(), priorState, config)
+}
+
+func (s *SchemaBlock) proposedNew(path *tftypes.AttributePath, prior tftypes.Value, config tftypes.Value) (tftypes.Value, error) {
+	// If both are null, return early so that nested blocks which are not
+	// configured do not appear in the proposed value.
+	if config.IsNull() && prior.IsNull() {
+		return prior, nil
+	}
+
+	if prior.IsNull() {
+		var err error
+
+		prior, err = s.emptyValue(path)
+
+		if err != nil {
+			return tftypes.Value{}, err
+		}
+	}
+
+	// A block should never be null or unknown at this point, except when
+	// an entire block is generated by a dynamic block with an unknown
+	// for_each. Validation will catch any problems, so take the prior value.
+	if config.IsNull() || !config.IsKnown() {
+		return prior, nil
+	}
+
+	if s == nil {
+		return config, nil
+	}
+
+	newAttrs, err := proposedNewAttributes(path, s.Attributes, prior, config)
+
+	if err != nil {
+		return tftypes.Value{}, err
+	}
+
+	for _, blockType := range s.BlockTypes {
+		if blockType == nil {
+			continue
+		}
+
+		blockPath := path.WithAttributeName(blockType.TypeName)
+
+		priorV, err := objectAttribute(blockPath, prior, blockType.TypeName)
+
+		if err != nil {
+			return tftypes.Value{}, err
+		}
+
+		configV, err := objectAttribute(blockPath, config, blockType.TypeName)
+
+		if err != nil {
+			return tftypes.Value{}, err
+		}
+
+		newV, err := blockType.proposedNew(blockPath, priorV, configV)
+
+		if err != nil {
+			return tftypes.Value{}, err
+		}
+
+		newAttrs[blockType.TypeName] = newV
+	}
+
+	return newProposedValue(path, config.Type(), newAttrs)
+}
+
+func (s *SchemaBlock) attributeAtPath(steps []tftypes.AttributePathStep) *SchemaAttribute {
+	res := s.lookupPath(steps)
+
+	if !res.exact {
+		return nil
+	}
+
+	return res.attribute
+}
+
+// emptyValue returns the value of the block when it is not configured at
+// all: every attribute is null, single nested blocks are null, group nested
+// blocks are empty and the collections of other nested blocks are empty.
+func (s *SchemaBlock) emptyValue(path *tftypes.AttributePath) (tftypes.Value, error) {
+	vals := map[string]tftypes.Value{}
+
+	if s == nil {
+		return newProposedValue(path, s.ValueType(), vals)
+	}
+
+	for _, attr := range s.Attributes {
+		if attr == nil {
+			continue
+		}
+
+		vals[attr.Name] = tftypes.NewValue(attr.ValueType(), nil)
+	}
+
+	for _, blockType := range s.BlockTypes {
+		if blockType == nil {
+			continue
+		}
+
+		blockPath := path.WithAttributeName(blockType.TypeName)
+
+		switch blockType.Nesting {
+		case SchemaNestedBlockNestingModeSingle:
+			vals[blockType.TypeName] = tftypes.NewValue(blockType.ValueType(), nil)
+		case SchemaNestedBlockNestingModeGroup:
+			val, err := blockType.Block.emptyValue(blockPath)
+
+			if err != nil {
+				return tftypes.Value{}, err
+			}
+
+			vals[blockType.TypeName] = val
+		case SchemaNestedBlockNestingModeList, SchemaNestedBlockNestingModeSet:
+			vals[blockType.TypeName] = tftypes.NewValue(blockType.ValueType(), []tftypes.Value{})
+		case SchemaNestedBlockNestingModeMap:
+			vals[blockType.TypeName] = tftypes.NewValue(blockType.ValueType(), map[string]tftypes.Value{})
+		default:
+			return tftypes.Value{}, blockPath.NewErrorf("unsupported nested block nesting mode: %s", blockType.Nesting)
+		}
+	}
+
+	return newProposedValue(path, s.ValueType(), vals)
+}
+
+func (s *SchemaNestedBlock) proposedNew(path *tftypes.AttributePath, prior tftypes.Value, config tftypes.Value) (tftypes.Value, error) {
+	// The only time an entire block is unknown is when it is generated by
+	// a dynamic block with an unknown for_each.
+	if !config.IsKnown() {
+		return config, nil
+	}
+
+	switch s.Nesting {
+	case SchemaNestedBlockNestingModeSingle:
+		// A single nested block cannot be computed, so a null
+		// configuration is always used as-is.
+		if config.IsNull() {
+			return config, nil
+		}
+
+		return s.Block.proposedNew(path, prior, config)
+	case SchemaNestedBlockNestingModeGroup:
+		return s.Block.proposedNew(path, prior, config)
+	case SchemaNestedBlockNestingModeList:
+		return proposedNewNestingList(path, s.Block, prior, config)
+	case SchemaNestedBlockNestingModeMap:
+		return proposedNewNestingMap(path, s.Block, prior, config)
+	case SchemaNestedBlockNestingModeSet:
+		return proposedNewNestingSet(path, s.Block, prior, config)
+	default:
+		return tftypes.Value{}, path.NewErrorf("unsupported nested block nesting mode: %s", s.Nesting)
+	}
+}
+
+func proposedNewAttributes(path *tftypes.AttributePath, attrs []*SchemaAttribute, prior tftypes.Value, config tftypes.Value) (map[string]tftypes.Value, error) {
+	newAttrs := map[string]tftypes.Value{}
+
+	// Start from the configuration so that any attribute not described by
+	// the schema is preserved.
+	if err := config.As(&newAttrs); err != nil {
+		return nil, path.NewError(err)
+	}
+
+	newAttrs = maps.Clone(newAttrs)
+
+	for _, attr := range attrs {
+		if attr == nil {
+			continue
+		}
+
+		attrPath := path.WithAttributeName(attr.Name)
+
+		priorV, err := objectAttribute(attrPath, prior, attr.Name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		configV, err := objectAttribute(attrPath, config, attr.Name)
+
+		if err != nil {
+			return nil, err
+		}
+
+		// Required is not considered when constructing the plan, so
+		// attributes are either computed or not. Optional and computed
+		// attributes are only treated as computed when not configured.
+		// Terraform makes an exception for optional nested attributes
+		// with non-computed values in the prior state, which protocol
+		// version 5 does not support, so the prior value is always used.
+		if attr.Computed && configV.IsNull() {
+			newAttrs[attr.Name] = priorV
+
+			continue
+		}
+
+		newAttrs[attr.Name] = configV
+	}
+
+	return newAttrs, nil
+}
+
+func proposedNewNestingList(path *tftypes.AttributePath, block *SchemaBlock, prior tftypes.Value, config tftypes.Value) (tftypes.Value, error) {
+	if config.IsNull() {
+		return config, nil
+	}
+
+	var configVals, priorVals []tftypes.Value
+
+	if err := config.As(&configVals); err != nil {
+		return tftypes.Value{}, path.NewError(err)
+	}
+
+	if len(configVals) == 0 {
+		return config, nil
+	}
+
+	if prior.IsKnown() {
+		if err := prior.As(&priorVals); err != nil {
+			return tftypes.Value{}, path.NewError(err)
+		}
+	}
+
+	newVals := make([]tftypes.Value, 0, len(configVals))
+
+	// Nested blocks are correlated by index.
+	for idx, configEV := range configVals {
+		if prior.IsKnown() && idx >= len(priorVals) {
+			// If there is no corresponding prior element then the
+			// config value is used as-is.
+			newVals = append(newVals, configEV)
+
+			continue
+		}
+
+		priorEV := tftypes.NewValue(configEV.Type(), tftypes.UnknownValue)
+
+		if prior.IsKnown() {
+			priorEV = priorVals[idx]
+		}
+
+		newEV, err := block.proposedNew(path.WithElementKeyInt(idx), priorEV, configEV)
+
+		if err != nil {
+			return tftypes.Value{}, err
+		}
+
+		newVals = append(newVals, newEV)
+	}
+
+	return newProposedValue(path, config.Type(), newVals)
+}
+
+func proposedNewNestingMap(path *tftypes.AttributePath, block *SchemaBlock, prior tftypes.Value, config tftypes.Value) (tftypes.Value, error) {
+	if config.IsNull() {
+		return config, nil
+	}
+
+	configVals := map[string]tftypes.Value{}
+	priorVals := map[string]tftypes.Value{}
+
+	if err := config.As(&configVals); err != nil {
+		return tftypes.Value{}, path.NewError(err)
+	}
+
+	if len(configVals) == 0 {
+		return config, nil
+	}
+
+	// The prior value may be null or unknown, in which case none of the
+	// configured elements have a prior element.
+	if prior.IsKnown() {
+		if err := prior.As(&priorVals); err != nil {
+			return tftypes.Value{}, path.NewError(err)
+		}
+	}
+
+	newVals := make(map[string]tftypes.Value, len(configVals))
+
+	// Nested blocks are correlated by key.
+	for key, configEV := range configVals {
+		priorEV, ok := priorVals[key]
+
+		if !ok {
+			// If there is no corresponding prior element then the
+			// config value is used as-is.
+			newVals[key] = configEV
+
+			continue
+		}
+
+		newEV, err := block.proposedNew(path.WithElementKeyString(key), priorEV, configEV)
+
+		if err != nil {
+			return tftypes.Value{}, err
+		}
+
+		newVals[key] = newEV
+	}
+
+	return newProposedValue(path, config.Type(), newVals)
+}
+
+func proposedNewNestingSet(path *tftypes.AttributePath, block *SchemaBlock, prior tftypes.Value, config tftypes.Value) (tftypes.Value, error) {
+	if !config.Type().Is(tftypes.Set{}) {
+		return tftypes.Value{}, path.NewErrorf("expected set value for set nesting mode, got: %s", config.Type())
+	}
+
+	if config.IsNull() {
+		return config, nil
+	}
+
+	var configVals, priorVals []tftypes.Value
+
+	if err := config.As(&configVals); err != nil {
+		return tftypes.Value{}, path.NewError(err)
+	}
+
+	if len(configVals) == 0 {
+		return config, nil
+	}
+
+	if prior.IsKnown() {
+		if err := prior.As(&priorVals); err != nil {
+			return tftypes.Value{}, path.NewError(err)
+		}
+	}
+
+	newVals := make([]tftypes.Value, 0, len(configVals))
+
+	// Track which prior elements have already been correlated.
+	used := make([]bool, len(priorVals))
+
+	for _, configEV := range configVals {
+		priorEV := tftypes.NewValue(configEV.Type(), nil)
+
+		for i, priorCmp := range priorVals {
+			if used[i] {
+				continue
+			}
+
+			// Multiple prior elements could match a configured element,
+			// in which case the first match is used. Since configured set
+			// elements are unique, such matches can only differ by
+			// computed values.
+			if validPriorFromConfig(block, priorCmp, configEV) {
+				priorEV = priorCmp
+				used[i] = true
+
+				break
+			}
+		}
+
+		newEV, err := block.proposedNew(path.WithElementKeyValue(configEV), priorEV, configEV)
+
+		if err != nil {
+			return tftypes.Value{}, err
+		}
+
+		newVals = append(newVals, newEV)
+	}
+
+	return newProposedValue(path, config.Type(), newVals)
+}
+
+// errStopValidPriorWalk is used to stop walking in validPriorFromConfig once
+// the result is known.
+var errStopValidPriorWalk = errors.New("stop walking")
+
+// validPriorFromConfig returns true if the prior set element could have been
+// produced from the configured set element, meaning every difference between
+// them is in a computed attribute that is null in the configuration.
+func validPriorFromConfig(block *SchemaBlock, prior tftypes.Value, config tftypes.Value) bool {
+	if prior.Equal(config) {
+		return true
+	}
+
+	valid := true
+
+	_ = tftypes.Walk(prior, func(path *tftypes.AttributePath, priorV tftypes.Value) (bool, error) {
+		configI, _, err := tftypes.WalkAttributePath(config, path)
+
+		if err != nil {
+			// Most likely dynamic values with different types.
+			valid = false
+
+			return false, errStopValidPriorWalk
+		}
+
+		configV, ok := configI.(tftypes.Value)
+
+		if !ok {
+			valid = false
+
+			return false, errStopValidPriorWalk
+		}
+
+		// Equal values need no further inspection.
+		if configV.Equal(priorV) {
+			return false, nil
+		}
+
+		// Nested sets cannot be correlated, so they must be equal.
+		if configV.Type().Is(tftypes.Set{}) {
+			valid = false
+
+			return false, errStopValidPriorWalk
+		}
+
+		attr := block.attributeAtPath(path.Steps())
+
+		// Not at an attribute yet, keep descending to the leaves.
+		if attr == nil {
+			return true, nil
+		}
+
+		// A leaf attribute may only differ if it is computed and not
+		// configured.
+		if !attr.Computed || !configV.IsNull() {
+			valid = false
+
+			return false, errStopValidPriorWalk
+		}
+
+		return false, nil
+	})
+
+	return valid
+}
+
+// objectAttribute returns the named attribute of an object value. The
+// attribute of a null or unknown object is null or unknown respectively.
+func objectAttribute(path *tftypes.AttributePath, obj tftypes.Value, name string) (tftypes.Value, error) {
+	objType, ok := obj.Type().(tftypes.Object)
+
+	if !ok {
+		return tftypes.Value{}, path.NewErrorf("expected object value, got: %s", obj.Type())
+	}
+
+	attrType, ok := objType.AttributeTypes[name]
+
+	if !ok {
+		return tftypes.Value{}, path.NewErrorf("attribute not found in object type %s", objType)
+	}
+
+	if obj.IsNull() {
+		return tftypes.NewValue(attrType, nil), nil
+	}
+
+	if !obj.IsKnown() {
+		return tftypes.NewValue(attrType, tftypes.UnknownValue), nil
+	}
+
+	attrs := map[string]tftypes.Value{}
+
+	if err := obj.As(&attrs); err != nil {
+		return tftypes.Value{}, path.NewError(err)
+	}
+
+	attr, ok := attrs[name]
+
+	if !ok {
+		return tftypes.NewValue(attrType, nil), nil
+	}
+
+	return attr, nil
+}
+
+func newProposedValue(path *tftypes.AttributePath, typ tftypes.Type, val interface{}) (tftypes.Value, error) {
+	if err := tftypes.ValidateValue(typ, val); err != nil {
+		return tftypes.Value{}, path.NewError(fmt.Errorf("unable to create proposed value: %w", err))
+	}
+
+	return tftypes.NewValue(typ, val), nil
+}
@@ -0,0 +1,338 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfprotov5_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSchemaProposedNewState(t *testing.T) {
+	t.Parallel()
+
+	nestedObjectType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"computed": tftypes.String,
+			"optional": tftypes.String,
+		},
+	}
+
+	schema := &tfprotov5.Schema{
+		Block: &tfprotov5.SchemaBlock{
+			Attributes: []*tfprotov5.SchemaAttribute{
+				{
+					Name:     "id",
+					Type:     tftypes.String,
+					Computed: true,
+				},
+				{
+					Name:     "optional",
+					Type:     tftypes.String,
+					Optional: true,
+				},
+				{
+					Name:     "optional_computed",
+					Type:     tftypes.String,
+					Optional: true,
+					Computed: true,
+				},
+			},
+			BlockTypes: []*tfprotov5.SchemaNestedBlock{
+				{
+					TypeName: "list_block",
+					Block: &tfprotov5.SchemaBlock{
+						Attributes: []*tfprotov5.SchemaAttribute{
+							{
+								Name:     "computed",
+								Type:     tftypes.String,
+								Computed: true,
+							},
+							{
+								Name:     "optional",
+								Type:     tftypes.String,
+								Optional: true,
+							},
+						},
+					},
+					Nesting: tfprotov5.SchemaNestedBlockNestingModeList,
+				},
+				{
+					TypeName: "set_block",
+					Block: &tfprotov5.SchemaBlock{
+						Attributes: []*tfprotov5.SchemaAttribute{
+							{
+								Name:     "computed",
+								Type:     tftypes.String,
+								Computed: true,
+							},
+							{
+								Name:     "optional",
+								Type:     tftypes.String,
+								Optional: true,
+							},
+						},
+					},
+					Nesting: tfprotov5.SchemaNestedBlockNestingModeSet,
+				},
+				{
+					TypeName: "single_block",
+					Block: &tfprotov5.SchemaBlock{
+						Attributes: []*tfprotov5.SchemaAttribute{
+							{
+								Name:     "computed",
+								Type:     tftypes.String,
+								Computed: true,
+							},
+							{
+								Name:     "optional",
+								Type:     tftypes.String,
+								Optional: true,
+							},
+						},
+					},
+					Nesting: tfprotov5.SchemaNestedBlockNestingModeSingle,
+				},
+			},
+		},
+	}
+
+	schemaType := schema.ValueType()
+
+	nestedObject := func(computed, optional *string) tftypes.Value {
+		return tftypes.NewValue(nestedObjectType, map[string]tftypes.Value{
+			"computed": tftypes.NewValue(tftypes.String, computed),
+			"optional": tftypes.NewValue(tftypes.String, optional),
+		})
+	}
+
+	object := func(id, optional, optionalComputed *string, listBlock, setBlock, singleBlock tftypes.Value) tftypes.Value {
+		return tftypes.NewValue(schemaType, map[string]tftypes.Value{
+			"id":                tftypes.NewValue(tftypes.String, id),
+			"optional":          tftypes.NewValue(tftypes.String, optional),
+			"optional_computed": tftypes.NewValue(tftypes.String, optionalComputed),
+			"list_block":        listBlock,
+			"set_block":         setBlock,
+			"single_block":      singleBlock,
+		})
+	}
+
+	list := func(vals ...tftypes.Value) tftypes.Value {
+		return tftypes.NewValue(tftypes.List{ElementType: nestedObjectType}, vals)
+	}
+
+	set := func(vals ...tftypes.Value) tftypes.Value {
+		return tftypes.NewValue(tftypes.Set{ElementType: nestedObjectType}, vals)
+	}
+
+	nullObject := tftypes.NewValue(nestedObjectType, nil)
+
+	testCases := map[string]struct {
+		schema        *tfprotov5.Schema
+		priorState    tftypes.Value
+		config        tftypes.Value
+		expected      tftypes.Value
+		expectedError error
+	}{
+		"destroy": {
+			schema:     schema,
+			priorState: object(pointer("id-123"), pointer("a"), pointer("b"), list(), set(), nullObject),
+			config:     tftypes.NewValue(schemaType, nil),
+			expected:   tftypes.NewValue(schemaType, nil),
+		},
+		"create": {
+			schema:     schema,
+			priorState: tftypes.NewValue(schemaType, nil),
+			config: object(nil, pointer("a"), nil,
+				list(nestedObject(nil, pointer("y"))),
+				set(nestedObject(nil, pointer("z"))),
+				nestedObject(nil, pointer("s")),
+			),
+			expected: object(nil, pointer("a"), nil,
+				list(nestedObject(nil, pointer("y"))),
+				set(nestedObject(nil, pointer("z"))),
+				nestedObject(nil, pointer("s")),
+			),
+		},
+		"update-computed-from-prior": {
+			schema: schema,
+			priorState: object(pointer("id-123"), pointer("a"), pointer("prior"),
+				list(nestedObject(pointer("c2"), pointer("y"))),
+				set(
+					nestedObject(pointer("c3"), pointer("z")),
+					nestedObject(pointer("c4"), pointer("removed")),
+				),
+				nestedObject(pointer("c5"), pointer("s")),
+			),
+			config: object(nil, pointer("b"), nil,
+				list(nestedObject(nil, pointer("changed")), nestedObject(nil, pointer("new"))),
+				set(
+					nestedObject(nil, pointer("z")),
+					nestedObject(nil, pointer("added")),
+				),
+				nestedObject(nil, pointer("s")),
+			),
+			expected: object(pointer("id-123"), pointer("b"), pointer("prior"),
+				list(nestedObject(pointer("c2"), pointer("changed")), nestedObject(nil, pointer("new"))),
+				set(
+					nestedObject(pointer("c3"), pointer("z")),
+					nestedObject(nil, pointer("added")),
+				),
+				nestedObject(pointer("c5"), pointer("s")),
+			),
+		},
+		"update-optional-computed-configured": {
+			schema:     schema,
+			priorState: object(pointer("id-123"), nil, pointer("prior"), list(), set(), nullObject),
+			config:     object(nil, nil, pointer("config"), list(), set(), nullObject),
+			expected:   object(pointer("id-123"), nil, pointer("config"), list(), set(), nullObject),
+		},
+		"update-single-block-removed": {
+			schema:     schema,
+			priorState: object(pointer("id-123"), nil, nil, list(), set(), nestedObject(pointer("c"), pointer("s"))),
+			config:     object(nil, nil, nil, list(), set(), nullObject),
+			expected:   object(pointer("id-123"), nil, nil, list(), set(), nullObject),
+		},
+		"unknown-config-values": {
+			schema:     schema,
+			priorState: object(pointer("id-123"), pointer("a"), pointer("prior"), list(), set(), nullObject),
+			config: tftypes.NewValue(schemaType, map[string]tftypes.Value{
+				"id":                tftypes.NewValue(tftypes.String, nil),
+				"optional":          tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				"optional_computed": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				"list_block":        list(),
+				"set_block":         set(),
+				"single_block":      nullObject,
+			}),
+			expected: tftypes.NewValue(schemaType, map[string]tftypes.Value{
+				"id":                tftypes.NewValue(tftypes.String, "id-123"),
+				"optional":          tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				"optional_computed": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				"list_block":        list(),
+				"set_block":         set(),
+				"single_block":      nullObject,
+			}),
+		},
+		"group-block-prior-null": {
+			schema: &tfprotov5.Schema{
+				Block: &tfprotov5.SchemaBlock{
+					BlockTypes: []*tfprotov5.SchemaNestedBlock{
+						{
+							TypeName: "group",
+							Block: &tfprotov5.SchemaBlock{
+								Attributes: []*tfprotov5.SchemaAttribute{
+									{
+										Name:     "computed",
+										Type:     tftypes.String,
+										Computed: true,
+									},
+								},
+							},
+							Nesting: tfprotov5.SchemaNestedBlockNestingModeGroup,
+						},
+					},
+				},
+			},
+			priorState: tftypes.NewValue(tftypes.Object{
+				AttributeTypes: map[string]tftypes.Type{
+					"group": tftypes.Object{
+						AttributeTypes: map[string]tftypes.Type{
+							"computed": tftypes.String,
+						},
+					},
+				},
+			}, nil),
+			config: tftypes.NewValue(tftypes.Object{
+				AttributeTypes: map[string]tftypes.Type{
+					"group": tftypes.Object{
+						AttributeTypes: map[string]tftypes.Type{
+							"computed": tftypes.String,
+						},
+					},
+				},
+			}, map[string]tftypes.Value{
+				"group": tftypes.NewValue(tftypes.Object{
+					AttributeTypes: map[string]tftypes.Type{
+						"computed": tftypes.String,
+					},
+				}, nil),
+			}),
+			expected: tftypes.NewValue(tftypes.Object{
+				AttributeTypes: map[string]tftypes.Type{
+					"group": tftypes.Object{
+						AttributeTypes: map[string]tftypes.Type{
+							"computed": tftypes.String,
+						},
+					},
+				},
+			}, map[string]tftypes.Value{
+				"group": tftypes.NewValue(tftypes.Object{
+					AttributeTypes: map[string]tftypes.Type{
+						"computed": tftypes.String,
+					},
+				}, map[string]tftypes.Value{
+					"computed": tftypes.NewValue(tftypes.String, nil),
+				}),
+			}),
+		},
+		"invalid-nesting-mode": {
+			schema: &tfprotov5.Schema{
+				Block: &tfprotov5.SchemaBlock{
+					BlockTypes: []*tfprotov5.SchemaNestedBlock{
+						{
+							TypeName: "invalid",
+							Block:    &tfprotov5.SchemaBlock{},
+						},
+					},
+				},
+			},
+			priorState: tftypes.NewValue(tftypes.Object{
+				AttributeTypes: map[string]tftypes.Type{
+					"invalid": tftypes.Object{AttributeTypes: map[string]tftypes.Type{}},
+				},
+			}, map[string]tftypes.Value{
+				"invalid": tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{}}, map[string]tftypes.Value{}),
+			}),
+			config: tftypes.NewValue(tftypes.Object{
+				AttributeTypes: map[string]tftypes.Type{
+					"invalid": tftypes.Object{AttributeTypes: map[string]tftypes.Type{}},
+				},
+			}, map[string]tftypes.Value{
+				"invalid": tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{}}, map[string]tftypes.Value{}),
+			}),
+			expectedError: tftypes.NewAttributePath().WithAttributeName("invalid").NewErrorf("unsupported nested block nesting mode: INVALID"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := testCase.schema.ProposedNewState(testCase.priorState, testCase.config)
+
+			if err != nil {
+				if testCase.expectedError == nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if err.Error() != testCase.expectedError.Error() {
+					t.Fatalf("expected error %q, got: %s", testCase.expectedError, err)
+				}
+
+				return
+			}
+
+			if testCase.expectedError != nil {
+				t.Fatalf("expected error %q, got none", testCase.expectedError)
+			}
+
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package tf5function implements provider functions as Go functions with
+// typed arguments and results.
+//
+// Functions are added to a Registry with Register, along with their
+// definition, which declares the parameters, variadic parameter, and return
+// type of the function. The Registry implements tfprotov5.FunctionServer: it
+// returns the definitions from GetFunctions, and for CallFunction decodes and
+// checks the arguments before calling the Go function of the called function.
+// The definitions are also returned by Functions, for the Functions field of
+// GetProviderSchemaResponse.
+//
+// The arguments of a call are given to the Go function as a struct with a
+// field for each parameter, whose `tf` struct tag is the name of the
+// parameter, and a slice field for the arguments of the variadic parameter:
+//
+//	type joinArgs struct {
+//		Separator string   `tf:"separator"`
+//		Values    []string `tf:"values"`
+//	}
+//
+//	err := tf5function.Register(registry, "join", &tfprotov5.Function{
+//		Parameters: []*tfprotov5.FunctionParameter{
+//			{Name: "separator", Type: tftypes.String},
+//		},
+//		VariadicParameter: &tfprotov5.FunctionParameter{
+//			Name: "values",
+//			Type: tftypes.String,
+//		},
+//		Return: &tfprotov5.FunctionReturn{Type: tftypes.String},
+//	}, func(ctx context.Context, args joinArgs) (string, error) {
+//		return strings.Join(args.Values, args.Separator), nil
+//	})
+//
+// Arguments are converted with tftypes.GetAs and results with
+// tftypes.NewValueFrom, so the fields and results can have any Go type they
+// support. Arguments of parameters with AllowNullValue must have fields that
+// can be nil, and arguments of parameters with AllowUnknownValues must have
+// tftypes.Value fields.
+//
+// Functions that are not pure are registered with WithoutResultCache. Their
+// names, returned by UncachedFunctions, must be passed to
+// tf5server.WithFunctionResultCache to keep their results out of the cache.
+package tf5function
@@ -0,0 +1,353 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf5function
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/internal/funcargs"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var _ tfprotov5.FunctionServer = &Registry{}
+
+// Handler is the Go function of a provider function. It is called with the
+// arguments of a call as `args`, and returns the result of the call or an
+// error.
+//
+// Errors are returned to Terraform as the FunctionError of the call. Errors
+// that are, or wrap, a tftypes.AttributePathError whose path refers to an
+// argument, as described by tfprotov5.Diagnostics.FunctionError, are
+// reported for that argument, as in:
+//
+//	tftypes.NewAttributePath().WithAttributeName("separator").NewErrorf("must not be empty")
+type Handler[A, R any] func(ctx context.Context, args A) (R, error)
+
+// Registry is a collection of provider functions, which implements
+// tfprotov5.FunctionServer. Functions must be registered before the Registry
+// is used by a server, as Register must not be called concurrently with the
+// other methods.
+type Registry struct {
+	functions map[string]*function
+}
+
+// function is a registered function.
+type function struct {
+	definition *tfprotov5.Function
+
+	// uncached is true if the results of the function must not be cached,
+	// as set by WithoutResultCache.
+	uncached bool
+
+	call func(ctx context.Context, arguments []*tfprotov5.DynamicValue) *tfprotov5.CallFunctionResponse
+}
+
+// RegisterOption configures a function registered with Register.
+type RegisterOption func(*function)
+
+// WithoutResultCache returns a RegisterOption for functions that are not
+// pure, such as functions returning the current time, whose results must not
+// be cached. The names of these functions are returned by UncachedFunctions,
+// and must be passed to tf5server.WithFunctionResultCache to keep their results
+// out of the cache, as the server does not know about the registry.
+func WithoutResultCache() RegisterOption {
+	return func(fn *function) {
+		fn.uncached = true
+	}
+}
+
+// NewRegistry returns an empty Registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		functions: map[string]*function{},
+	}
+}
+
+// Register adds the function `name` to the Registry, with the given
+// definition and Go function. It returns an error if the definition is
+// invalid, if a function is already registered with the same name, or if
+// the fields of A or the type R do not match the parameters or return type of
+// the definition. The options in `opts` configure the function.
+func Register[A, R any](r *Registry, name string, definition *tfprotov5.Function, handler Handler[A, R], opts ...RegisterOption) error {
+	if name == "" {
+		return errors.New("function name must not be empty")
+	}
+
+	if _, ok := r.functions[name]; ok {
+		return fmt.Errorf("function %q is already registered", name)
+	}
+
+	if handler == nil {
+		return fmt.Errorf("function %q: missing handler", name)
+	}
+
+	if err := validateDefinition(definition); err != nil {
+		return fmt.Errorf("function %q: %w", name, err)
+	}
+
+	if err := checkGoTypes[A, R](definition); err != nil {
+		return fmt.Errorf("function %q: %w", name, err)
+	}
+
+	fn := &function{
+		definition: definition,
+		call: func(ctx context.Context, arguments []*tfprotov5.DynamicValue) *tfprotov5.CallFunctionResponse {
+			return call(ctx, definition, handler, arguments)
+		},
+	}
+
+	for _, opt := range opts {
+		opt(fn)
+	}
+
+	r.functions[name] = fn
+
+	return nil
+}
+
+// Functions returns the definitions of the registered functions, by name,
+// such as for the Functions field of GetProviderSchemaResponse.
+func (r *Registry) Functions() map[string]*tfprotov5.Function {
+	res := make(map[string]*tfprotov5.Function, len(r.functions))
+
+	for name, fn := range r.functions {
+		res[name] = fn.definition
+	}
+
+	return res
+}
+
+// UncachedFunctions returns the sorted names of the registered functions
+// whose results must not be cached, as they were registered with
+// WithoutResultCache. Pass them to tf5server.WithFunctionResultCache when
+// caching function results, as in:
+//
+//	tf5server.WithFunctionResultCache(1000, registry.UncachedFunctions()...)
+func (r *Registry) UncachedFunctions() []string {
+	var names []string
+
+	for name, fn := range r.functions {
+		if fn.uncached {
+			names = append(names, name)
+		}
+	}
+
+	sort.Strings(names)
+
+	return names
+}
+
+// GetFunctions returns the definitions of the registered functions.
+func (r *Registry) GetFunctions(_ context.Context, _ *tfprotov5.GetFunctionsRequest) (*tfprotov5.GetFunctionsResponse, error) {
+	return &tfprotov5.GetFunctionsResponse{
+		Functions: r.Functions(),
+	}, nil
+}
+
+// CallFunction calls the registered function with the name of the request,
+// returning a FunctionError if there is no such function, if the arguments
+// do not match its parameters, or if the function returns an error.
+func (r *Registry) CallFunction(ctx context.Context, req *tfprotov5.CallFunctionRequest) (*tfprotov5.CallFunctionResponse, error) {
+	fn, ok := r.functions[req.Name]
+	if !ok {
+		return &tfprotov5.CallFunctionResponse{
+			Error: &tfprotov5.FunctionError{
+				Text: fmt.Sprintf("The provider has no function named %q.", req.Name),
+			},
+		}, nil
+	}
+
+	return fn.call(ctx, req.Arguments), nil
+}
+
+// validateDefinition returns an error if the parameters or return type of a
+// function definition are missing, or if parameter names are not unique.
+func validateDefinition(definition *tfprotov5.Function) error {
+	if definition == nil {
+		return errors.New("missing definition")
+	}
+
+	if definition.Return == nil || definition.Return.Type == nil {
+		return errors.New("missing return type")
+	}
+
+	names := map[string]bool{}
+
+	for pos, parameter := range parameters(definition) {
+		if parameter == nil {
+			return fmt.Errorf("missing parameter %d", pos)
+		}
+
+		if parameter.Name == "" {
+			return fmt.Errorf("parameter %d has no name", pos)
+		}
+
+		if names[parameter.Name] {
+			return fmt.Errorf("duplicate parameter name %q", parameter.Name)
+		}
+
+		if parameter.Type == nil {
+			return fmt.Errorf("parameter %q has no type", parameter.Name)
+		}
+
+		names[parameter.Name] = true
+	}
+
+	return nil
+}
+
+// checkGoTypes returns an error if A or R cannot hold the arguments or result
+// of a function, by converting their zero values to and from Values.
+func checkGoTypes[A, R any](definition *tfprotov5.Function) error {
+	var args A
+
+	argsType := argumentsType(definition, nil)
+
+	argsVal, err := tftypes.NewValueFrom(argsType, args)
+	if err != nil {
+		return fmt.Errorf("arguments type %T does not match the parameters: %w", args, err)
+	}
+
+	if _, err := tftypes.GetAs[A](argsVal, nil); err != nil {
+		return fmt.Errorf("arguments type %T does not match the parameters: %w", args, err)
+	}
+
+	var result R
+
+	if _, err := tftypes.NewValueFrom(definition.Return.Type, result); err != nil {
+		return fmt.Errorf("result type %T does not match the return type: %w", result, err)
+	}
+
+	return nil
+}
+
+// call decodes the arguments of a call and calls `handler` with them.
+func call[A, R any](ctx context.Context, definition *tfprotov5.Function, handler Handler[A, R], arguments []*tfprotov5.DynamicValue) *tfprotov5.CallFunctionResponse {
+	args, known, funcErr := decodeArguments(definition, arguments)
+	if funcErr != nil {
+		return &tfprotov5.CallFunctionResponse{Error: funcErr}
+	}
+
+	// As Terraform does, calls with unknown arguments for parameters that do
+	// not allow them have an unknown result, without calling the function.
+	if !known {
+		return result(definition, tftypes.NewValue(definition.Return.Type, tftypes.UnknownValue))
+	}
+
+	in, err := tftypes.GetAs[A](args, nil)
+	if err != nil {
+		return &tfprotov5.CallFunctionResponse{
+			Error: tfprotov5.DiagnosticsFromError("", err).FunctionError(definition),
+		}
+	}
+
+	out, err := handler(ctx, in)
+	if err != nil {
+		return &tfprotov5.CallFunctionResponse{
+			Error: tfprotov5.DiagnosticsFromError("", err).FunctionError(definition),
+		}
+	}
+
+	val, err := tftypes.NewValueFrom(definition.Return.Type, out)
+	if err != nil {
+		return &tfprotov5.CallFunctionResponse{
+			Error: &tfprotov5.FunctionError{
+				Text: fmt.Sprintf("Error converting the function result: %s", err),
+			},
+		}
+	}
+
+	return result(definition, val)
+}
+
+// decodeArguments returns the arguments of a call as an Object value, with an
+// attribute for each parameter, and a Tuple attribute for the arguments of
+// the variadic parameter. It returns false if an argument is unknown for a
+// parameter that does not allow unknown values, and a FunctionError if the
+// arguments do not match the parameters.
+func decodeArguments(definition *tfprotov5.Function, arguments []*tfprotov5.DynamicValue) (tftypes.Value, bool, *tfprotov5.FunctionError) {
+	values, funcErr := funcargs.Decode(definition, arguments)
+	if funcErr != nil {
+		return tftypes.Value{}, false, funcErr
+	}
+
+	known := funcargs.Unknown(definition, values) == nil
+	argsType := argumentsType(definition, values)
+	attributes := make(map[string]tftypes.Value, len(argsType.AttributeTypes))
+
+	for pos, parameter := range definition.Parameters {
+		attributes[parameter.Name] = values[pos]
+	}
+
+	if variadic := definition.VariadicParameter; variadic != nil {
+		variadicType := argsType.AttributeTypes[variadic.Name]
+		attributes[variadic.Name] = tftypes.NewValue(variadicType, values[len(definition.Parameters):])
+	}
+
+	return tftypes.NewValue(argsType, attributes), known, nil
+}
+
+// argumentsType returns the Object type of the arguments of a call with the
+// argument values `values`, whose types are used instead of the parameter
+// types, as they are concrete for dynamic parameters. If `values` is nil,
+// the parameter types are used, and the variadic arguments are an empty
+// Tuple.
+func argumentsType(definition *tfprotov5.Function, values []tftypes.Value) tftypes.Object {
+	res := tftypes.Object{
+		AttributeTypes: make(map[string]tftypes.Type, len(definition.Parameters)+1),
+	}
+
+	for pos, parameter := range definition.Parameters {
+		res.AttributeTypes[parameter.Name] = parameter.Type
+
+		if values != nil {
+			res.AttributeTypes[parameter.Name] = values[pos].Type()
+		}
+	}
+
+	if variadic := definition.VariadicParameter; variadic != nil {
+		variadicType := tftypes.Tuple{}
+
+		for pos := len(definition.Parameters); pos < len(values); pos++ {
+			variadicType.ElementTypes = append(variadicType.ElementTypes, values[pos].Type())
+		}
+
+		res.AttributeTypes[variadic.Name] = variadicType
+	}
+
+	return res
+}
+
+// parameters returns the parameters of a function definition, followed by
+// its variadic parameter if it has one.
+func parameters(definition *tfprotov5.Function) []*tfprotov5.FunctionParameter {
+	if definition.VariadicParameter == nil {
+		return definition.Parameters
+	}
+
+	res := make([]*tfprotov5.FunctionParameter, 0, len(definition.Parameters)+1)
+	res = append(res, definition.Parameters...)
+
+	return append(res, definition.VariadicParameter)
+}
+
+// result returns the response of a call with the result `val`.
+func result(definition *tfprotov5.Function, val tftypes.Value) *tfprotov5.CallFunctionResponse {
+	dv, err := tfprotov5.NewDynamicValue(definition.Return.Type, val)
+	if err != nil {
+		return &tfprotov5.CallFunctionResponse{
+			Error: &tfprotov5.FunctionError{
+				Text: fmt.Sprintf("Error encoding the function result: %s", err),
+			},
+		}
+	}
+
+	return &tfprotov5.CallFunctionResponse{
+		Result: &dv,
+	}
+}
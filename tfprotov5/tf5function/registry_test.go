@@ -0,0 +1,447 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf5function_test
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/tf5function"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+type joinArgs struct {
+	Separator string   `tf:"separator"`
+	Values    []string `tf:"values"`
+}
+
+func joinDefinition() *tfprotov5.Function {
+	return &tfprotov5.Function{
+		Parameters: []*tfprotov5.FunctionParameter{
+			{
+				Name: "separator",
+				Type: tftypes.String,
+			},
+		},
+		VariadicParameter: &tfprotov5.FunctionParameter{
+			Name: "values",
+			Type: tftypes.String,
+		},
+		Return: &tfprotov5.FunctionReturn{
+			Type: tftypes.String,
+		},
+	}
+}
+
+func join(_ context.Context, args joinArgs) (string, error) {
+	if args.Separator == "" {
+		return "", tftypes.NewAttributePath().WithAttributeName("separator").NewErrorf("must not be empty")
+	}
+
+	for pos, value := range args.Values {
+		if value == "" {
+			return "", tftypes.NewAttributePath().WithAttributeName("values").WithElementKeyInt(pos).NewErrorf("must not be empty")
+		}
+	}
+
+	return strings.Join(args.Values, args.Separator), nil
+}
+
+type describeArgs struct {
+	Value   tftypes.Value `tf:"value"`
+	Default *string       `tf:"default"`
+}
+
+func describeDefinition() *tfprotov5.Function {
+	return &tfprotov5.Function{
+		Parameters: []*tfprotov5.FunctionParameter{
+			{
+				Name:               "value",
+				Type:               tftypes.DynamicPseudoType,
+				AllowNullValue:     true,
+				AllowUnknownValues: true,
+			},
+			{
+				Name:           "default",
+				Type:           tftypes.String,
+				AllowNullValue: true,
+			},
+		},
+		Return: &tfprotov5.FunctionReturn{
+			Type: tftypes.DynamicPseudoType,
+		},
+	}
+}
+
+func describe(_ context.Context, args describeArgs) (string, error) {
+	switch {
+	case !args.Value.IsKnown():
+		return "unknown " + args.Value.Type().String(), nil
+	case args.Value.IsNull() && args.Default != nil:
+		return *args.Default, nil
+	case args.Value.IsNull():
+		return "null", nil
+	}
+
+	return args.Value.Type().String(), nil
+}
+
+func testRegistry(t *testing.T) *tf5function.Registry {
+	t.Helper()
+
+	registry := tf5function.NewRegistry()
+
+	if err := tf5function.Register(registry, "join", joinDefinition(), join); err != nil {
+		t.Fatalf("unexpected error registering join: %s", err)
+	}
+
+	if err := tf5function.Register(registry, "describe", describeDefinition(), describe); err != nil {
+		t.Fatalf("unexpected error registering describe: %s", err)
+	}
+
+	return registry
+}
+
+func TestRegistryGetFunctions(t *testing.T) {
+	t.Parallel()
+
+	registry := testRegistry(t)
+
+	got, err := registry.GetFunctions(context.Background(), &tfprotov5.GetFunctionsRequest{})
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected := &tfprotov5.GetFunctionsResponse{
+		Functions: map[string]*tfprotov5.Function{
+			"describe": describeDefinition(),
+			"join":     joinDefinition(),
+		},
+	}
+
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+func TestRegistryUncachedFunctions(t *testing.T) {
+	t.Parallel()
+
+	registry := tf5function.NewRegistry()
+
+	if err := tf5function.Register(registry, "join", joinDefinition(), join); err != nil {
+		t.Fatalf("unexpected error registering join: %s", err)
+	}
+
+	if err := tf5function.Register(registry, "describe", describeDefinition(), describe, tf5function.WithoutResultCache()); err != nil {
+		t.Fatalf("unexpected error registering describe: %s", err)
+	}
+
+	if diff := cmp.Diff([]string{"describe"}, registry.UncachedFunctions()); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+func TestRegistryCallFunction(t *testing.T) {
+	t.Parallel()
+
+	registry := testRegistry(t)
+
+	testCases := map[string]struct {
+		name           string
+		arguments      []tftypes.Value
+		expectedResult tftypes.Value
+		expectedError  *tfprotov5.FunctionError
+	}{
+		"join": {
+			name: "join",
+			arguments: []tftypes.Value{
+				tftypes.NewValue(tftypes.String, ", "),
+				tftypes.NewValue(tftypes.String, "a"),
+				tftypes.NewValue(tftypes.String, "b"),
+			},
+			expectedResult: tftypes.NewValue(tftypes.String, "a, b"),
+		},
+		"join-no-variadic-arguments": {
+			name: "join",
+			arguments: []tftypes.Value{
+				tftypes.NewValue(tftypes.String, ", "),
+			},
+			expectedResult: tftypes.NewValue(tftypes.String, ""),
+		},
+		"join-parameter-error": {
+			name: "join",
+			arguments: []tftypes.Value{
+				tftypes.NewValue(tftypes.String, ""),
+				tftypes.NewValue(tftypes.String, "a"),
+			},
+			expectedError: &tfprotov5.FunctionError{
+				Text:             "must not be empty",
+				FunctionArgument: pointer(int64(0)),
+			},
+		},
+		"join-variadic-parameter-error": {
+			name: "join",
+			arguments: []tftypes.Value{
+				tftypes.NewValue(tftypes.String, ", "),
+				tftypes.NewValue(tftypes.String, "a"),
+				tftypes.NewValue(tftypes.String, ""),
+			},
+			expectedError: &tfprotov5.FunctionError{
+				Text:             "must not be empty",
+				FunctionArgument: pointer(int64(2)),
+			},
+		},
+		"join-null": {
+			name: "join",
+			arguments: []tftypes.Value{
+				tftypes.NewValue(tftypes.String, ", "),
+				tftypes.NewValue(tftypes.String, nil),
+			},
+			expectedError: &tfprotov5.FunctionError{
+				Text:             `Invalid value for "values" parameter: argument must not be null.`,
+				FunctionArgument: pointer(int64(1)),
+			},
+		},
+		"join-unknown": {
+			name: "join",
+			arguments: []tftypes.Value{
+				tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				tftypes.NewValue(tftypes.String, "a"),
+			},
+			expectedResult: tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		},
+		"join-too-few-arguments": {
+			name:      "join",
+			arguments: []tftypes.Value{},
+			expectedError: &tfprotov5.FunctionError{
+				Text: "Expected at least 1 argument, got 0.",
+			},
+		},
+		"describe": {
+			name: "describe",
+			arguments: []tftypes.Value{
+				tftypes.NewValue(tftypes.List{ElementType: tftypes.Bool}, []tftypes.Value{}),
+				tftypes.NewValue(tftypes.String, nil),
+			},
+			expectedResult: tftypes.NewValue(tftypes.String, "tftypes.List[tftypes.Bool]"),
+		},
+		"describe-null": {
+			name: "describe",
+			arguments: []tftypes.Value{
+				tftypes.NewValue(tftypes.DynamicPseudoType, nil),
+				tftypes.NewValue(tftypes.String, "default"),
+			},
+			expectedResult: tftypes.NewValue(tftypes.String, "default"),
+		},
+		"describe-unknown": {
+			name: "describe",
+			arguments: []tftypes.Value{
+				tftypes.NewValue(tftypes.Number, tftypes.UnknownValue),
+				tftypes.NewValue(tftypes.String, nil),
+			},
+			expectedResult: tftypes.NewValue(tftypes.String, "unknown tftypes.Number"),
+		},
+		"describe-too-many-arguments": {
+			name: "describe",
+			arguments: []tftypes.Value{
+				tftypes.NewValue(tftypes.String, "a"),
+				tftypes.NewValue(tftypes.String, "b"),
+				tftypes.NewValue(tftypes.String, "c"),
+			},
+			expectedError: &tfprotov5.FunctionError{
+				Text: "Expected 2 arguments, got 3.",
+			},
+		},
+		"unknown-function": {
+			name: "other",
+			expectedError: &tfprotov5.FunctionError{
+				Text: `The provider has no function named "other".`,
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			definition := registry.Functions()[testCase.name]
+
+			req := &tfprotov5.CallFunctionRequest{
+				Name: testCase.name,
+			}
+
+			for pos, argument := range testCase.arguments {
+				typ := argument.Type()
+
+				if pos < len(definition.Parameters) {
+					typ = definition.Parameters[pos].Type
+				}
+
+				dv, err := tfprotov5.NewDynamicValue(typ, argument)
+				if err != nil {
+					t.Fatalf("unexpected error encoding argument %d: %s", pos, err)
+				}
+
+				req.Arguments = append(req.Arguments, &dv)
+			}
+
+			got, err := registry.CallFunction(context.Background(), req)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expectedError, got.Error); diff != "" {
+				t.Fatalf("unexpected function error difference: %s", diff)
+			}
+
+			if testCase.expectedError != nil {
+				return
+			}
+
+			result, err := got.Result.Unmarshal(definition.Return.Type)
+
+			if err != nil {
+				t.Fatalf("unexpected error decoding result: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expectedResult, result); diff != "" {
+				t.Errorf("unexpected result difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestRegisterErrors(t *testing.T) {
+	t.Parallel()
+
+	type stringArgs struct {
+		Value string `tf:"value"`
+	}
+
+	stringDefinition := func() *tfprotov5.Function {
+		return &tfprotov5.Function{
+			Parameters: []*tfprotov5.FunctionParameter{
+				{
+					Name: "value",
+					Type: tftypes.String,
+				},
+			},
+			Return: &tfprotov5.FunctionReturn{
+				Type: tftypes.String,
+			},
+		}
+	}
+
+	identity := func(_ context.Context, args stringArgs) (string, error) {
+		return args.Value, nil
+	}
+
+	testCases := map[string]struct {
+		register      func(*tf5function.Registry) error
+		expectedError string
+	}{
+		"empty-name": {
+			register: func(r *tf5function.Registry) error {
+				return tf5function.Register(r, "", stringDefinition(), identity)
+			},
+			expectedError: "function name must not be empty",
+		},
+		"duplicate-name": {
+			register: func(r *tf5function.Registry) error {
+				return tf5function.Register(r, "join", stringDefinition(), identity)
+			},
+			expectedError: `function "join" is already registered`,
+		},
+		"missing-handler": {
+			register: func(r *tf5function.Registry) error {
+				return tf5function.Register[stringArgs, string](r, "identity", stringDefinition(), nil)
+			},
+			expectedError: `function "identity": missing handler`,
+		},
+		"missing-definition": {
+			register: func(r *tf5function.Registry) error {
+				return tf5function.Register(r, "identity", nil, identity)
+			},
+			expectedError: `function "identity": missing definition`,
+		},
+		"missing-return-type": {
+			register: func(r *tf5function.Registry) error {
+				definition := stringDefinition()
+				definition.Return = nil
+
+				return tf5function.Register(r, "identity", definition, identity)
+			},
+			expectedError: `function "identity": missing return type`,
+		},
+		"duplicate-parameter-name": {
+			register: func(r *tf5function.Registry) error {
+				definition := stringDefinition()
+				definition.VariadicParameter = &tfprotov5.FunctionParameter{
+					Name: "value",
+					Type: tftypes.String,
+				}
+
+				return tf5function.Register(r, "identity", definition, identity)
+			},
+			expectedError: `function "identity": duplicate parameter name "value"`,
+		},
+		"parameter-without-field": {
+			register: func(r *tf5function.Registry) error {
+				definition := stringDefinition()
+				definition.Parameters = append(definition.Parameters, &tfprotov5.FunctionParameter{
+					Name: "other",
+					Type: tftypes.String,
+				})
+
+				return tf5function.Register(r, "identity", definition, identity)
+			},
+			expectedError: `function "identity": arguments type tf5function_test.stringArgs does not match the parameters: AttributeName("other"): tf5function_test.stringArgs has no field for attribute "other"`,
+		},
+		"field-type": {
+			register: func(r *tf5function.Registry) error {
+				definition := stringDefinition()
+				definition.Parameters[0].Type = tftypes.Bool
+
+				return tf5function.Register(r, "identity", definition, identity)
+			},
+			expectedError: `function "identity": arguments type tf5function_test.stringArgs does not match the parameters: AttributeName("value"): can't use string as tftypes.Bool`,
+		},
+		"result-type": {
+			register: func(r *tf5function.Registry) error {
+				return tf5function.Register(r, "identity", stringDefinition(), func(_ context.Context, args stringArgs) (bool, error) {
+					return false, errors.New("not implemented")
+				})
+			},
+			expectedError: `function "identity": result type bool does not match the return type: can't use bool as tftypes.String`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := testCase.register(testRegistry(t))
+
+			if err == nil {
+				t.Fatalf("expected error %q, got none", testCase.expectedError)
+			}
+
+			if diff := cmp.Diff(testCase.expectedError, err.Error()); diff != "" {
+				t.Errorf("unexpected error difference: %s", diff)
+			}
+		})
+	}
+}
+
+func pointer[T any](value T) *T {
+	return &value
+}
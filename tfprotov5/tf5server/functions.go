@@ -0,0 +1,138 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf5server
+
+import (
+	"context"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-go/internal/logging"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/internal/funcargs"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// storeFunctions stores the function definitions of a GetProviderSchema or
+// GetFunctions response, if they are needed to check the arguments of
+// CallFunction requests or to cache their results, and the response has no
+// error diagnostics.
+func (s *server) storeFunctions(functions map[string]*tfprotov5.Function, diagnostics []*tfprotov5.Diagnostic) {
+	if (!s.validateFunctionArguments && s.functionResults == nil) || tfprotov5.Diagnostics(diagnostics).HasError() {
+		return
+	}
+
+	if functions == nil {
+		functions = map[string]*tfprotov5.Function{}
+	}
+
+	s.functionsMu.Lock()
+	defer s.functionsMu.Unlock()
+
+	s.functions = functions
+}
+
+// storedFunctions returns the stored function definitions, or nil if they are
+// not known yet.
+func (s *server) storedFunctions() map[string]*tfprotov5.Function {
+	s.functionsMu.RLock()
+	defer s.functionsMu.RUnlock()
+
+	return s.functions
+}
+
+// function returns the definition of the function `name`, calling the
+// GetFunctions RPC of the provider if the definitions are not known yet. It
+// returns nil if there is no such function, or if the definitions cannot be
+// fetched. The definitions are fetched at most once: concurrent calls wait for
+// the first fetch, and a failed fetch is not retried. No lock is held while
+// the provider is called.
+func (s *server) function(ctx context.Context, name string) *tfprotov5.Function {
+	if functions := s.storedFunctions(); functions != nil {
+		return functions[name]
+	}
+
+	s.functionsFetch.Do(func() {
+		logging.ProtocolTrace(ctx, "Calling downstream GetFunctions for the function definitions")
+
+		resp, err := s.downstream.GetFunctions(ctx, &tfprotov5.GetFunctionsRequest{})
+
+		switch {
+		case err != nil:
+			logging.ProtocolWarn(ctx, "Error from downstream GetFunctions, function definitions are unavailable", map[string]any{logging.KeyError: err})
+		case tfprotov5.Diagnostics(resp.Diagnostics).HasError():
+			logging.ProtocolWarn(ctx, "Downstream GetFunctions returned error diagnostics, function definitions are unavailable")
+		default:
+			s.storeFunctions(resp.Functions, resp.Diagnostics)
+		}
+	})
+
+	return s.storedFunctions()[name]
+}
+
+// functionArguments returns the definition of the function called by `req`
+// and its arguments, decoded with the definition, so that they are decoded
+// once for both argument validation and the function result cache. It returns
+// a FunctionError if the arguments do not match the definition, and no
+// definition if it is not needed, as argument validation is disabled and the
+// result is not cached, or if the function has none.
+func (s *server) functionArguments(ctx context.Context, req *tfprotov5.CallFunctionRequest) (*tfprotov5.Function, []tftypes.Value, *tfprotov5.FunctionError) {
+	if !s.validateFunctionArguments && (s.functionResults == nil || s.uncachedFunctions[req.Name]) {
+		return nil, nil, nil
+	}
+
+	function := s.function(ctx, req.Name)
+
+	if function == nil {
+		logging.ProtocolTrace(ctx, "No function definition, not checking function arguments or caching function result")
+		return nil, nil, nil
+	}
+
+	values, funcErr := funcargs.Decode(function, req.Arguments)
+
+	return function, values, funcErr
+}
+
+// functionResultCacheKey returns the key of the result of a call to the
+// function `name` in the function result cache: the function name followed
+// by the content hash of each of `values`, the decoded arguments. It returns
+// "" if the result cannot be cached, as caching is disabled, the function
+// opted out, or the arguments were not decoded.
+func (s *server) functionResultCacheKey(name string, values []tftypes.Value) string {
+	if s.functionResults == nil || s.uncachedFunctions[name] || values == nil {
+		return ""
+	}
+
+	var key strings.Builder
+
+	key.WriteString(name)
+
+	for _, val := range values {
+		hash := val.ContentHash()
+
+		key.WriteByte(0)
+		key.Write(hash[:])
+	}
+
+	return key.String()
+}
+
+// cachedFunctionResult returns the cached response with the key `key`, if
+// there is one, and logs the cache hit or miss.
+func (s *server) cachedFunctionResult(ctx context.Context, key string) (*tfprotov5.CallFunctionResponse, bool) {
+	resp, ok := s.functionResults.Get(key)
+	hits, misses := s.functionResults.Stats()
+
+	fields := map[string]any{
+		logging.KeyFunctionResultCacheHits:   hits,
+		logging.KeyFunctionResultCacheMisses: misses,
+	}
+
+	if ok {
+		logging.ProtocolTrace(ctx, "Function result cache hit, not calling downstream", fields)
+	} else {
+		logging.ProtocolTrace(ctx, "Function result cache miss", fields)
+	}
+
+	return resp, ok
+}
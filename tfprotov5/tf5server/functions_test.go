@@ -0,0 +1,426 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tf5server
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/internal/tfplugin5"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// testFunctionProvider is a provider with a single "upper" function, which
+// counts its calls.
+type testFunctionProvider struct {
+	tfprotov5.ProviderServer
+
+	// getFunctionsErr and getFunctionsDiagnostics are returned from
+	// GetFunctions instead of the definitions, if set.
+	getFunctionsErr         error
+	getFunctionsDiagnostics []*tfprotov5.Diagnostic
+
+	mu                sync.Mutex
+	callFunctionCalls int
+	getFunctionsCalls int
+}
+
+func (p *testFunctionProvider) GetFunctions(_ context.Context, _ *tfprotov5.GetFunctionsRequest) (*tfprotov5.GetFunctionsResponse, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.getFunctionsCalls++
+
+	if p.getFunctionsErr != nil {
+		return nil, p.getFunctionsErr
+	}
+
+	if p.getFunctionsDiagnostics != nil {
+		return &tfprotov5.GetFunctionsResponse{
+			Diagnostics: p.getFunctionsDiagnostics,
+		}, nil
+	}
+
+	return &tfprotov5.GetFunctionsResponse{
+		Functions: map[string]*tfprotov5.Function{
+			"upper": {
+				Parameters: []*tfprotov5.FunctionParameter{
+					{
+						Name: "input",
+						Type: tftypes.String,
+					},
+				},
+				Return: &tfprotov5.FunctionReturn{
+					Type: tftypes.String,
+				},
+			},
+		},
+	}, nil
+}
+
+func (p *testFunctionProvider) CallFunction(_ context.Context, _ *tfprotov5.CallFunctionRequest) (*tfprotov5.CallFunctionResponse, error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	p.callFunctionCalls++
+
+	result, err := tfprotov5.NewDynamicValue(tftypes.String, tftypes.NewValue(tftypes.String, "RESULT"))
+	if err != nil {
+		return nil, err
+	}
+
+	return &tfprotov5.CallFunctionResponse{
+		Result: &result,
+	}, nil
+}
+
+func testFunctionArgument(t *testing.T, val tftypes.Value) *tfplugin5.DynamicValue {
+	t.Helper()
+
+	dv, err := tfprotov5.NewDynamicValue(val.Type(), val)
+	if err != nil {
+		t.Fatalf("unexpected error encoding argument: %s", err)
+	}
+
+	return &tfplugin5.DynamicValue{
+		Msgpack: dv.MsgPack,
+	}
+}
+
+func TestServerCallFunctionArgumentValidation(t *testing.T) {
+	t.Parallel()
+
+	argumentIndex := int64(0)
+
+	testCases := map[string]struct {
+		opts                      []ServeOpt
+		name                      string
+		arguments                 []tftypes.Value
+		expectedError             *tfprotov5.FunctionError
+		expectedCallFunctionCalls int
+		expectedGetFunctionsCalls int
+	}{
+		"disabled": {
+			name: "upper",
+			arguments: []tftypes.Value{
+				tftypes.NewValue(tftypes.String, nil),
+			},
+			expectedCallFunctionCalls: 1,
+		},
+		"valid": {
+			opts: []ServeOpt{WithFunctionArgumentValidation()},
+			name: "upper",
+			arguments: []tftypes.Value{
+				tftypes.NewValue(tftypes.String, "test"),
+			},
+			expectedCallFunctionCalls: 1,
+			expectedGetFunctionsCalls: 1,
+		},
+		"argument-count": {
+			opts: []ServeOpt{WithFunctionArgumentValidation()},
+			name: "upper",
+			expectedError: &tfprotov5.FunctionError{
+				Text: "Expected 1 argument, got 0.",
+			},
+			expectedGetFunctionsCalls: 1,
+		},
+		"null": {
+			opts: []ServeOpt{WithFunctionArgumentValidation()},
+			name: "upper",
+			arguments: []tftypes.Value{
+				tftypes.NewValue(tftypes.String, nil),
+			},
+			expectedError: &tfprotov5.FunctionError{
+				Text:             `Invalid value for "input" parameter: argument must not be null.`,
+				FunctionArgument: &argumentIndex,
+			},
+			expectedGetFunctionsCalls: 1,
+		},
+		"unknown": {
+			opts: []ServeOpt{WithFunctionArgumentValidation()},
+			name: "upper",
+			arguments: []tftypes.Value{
+				tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+			},
+			expectedError: &tfprotov5.FunctionError{
+				Text:             `Invalid value for "input" parameter: argument must not be unknown.`,
+				FunctionArgument: &argumentIndex,
+			},
+			expectedGetFunctionsCalls: 1,
+		},
+		"undefined-function": {
+			opts:                      []ServeOpt{WithFunctionArgumentValidation()},
+			name:                      "other",
+			expectedCallFunctionCalls: 1,
+			expectedGetFunctionsCalls: 1,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			provider := &testFunctionProvider{}
+			server := New("test", provider, append(testCase.opts, WithoutLogStderrOverride())...)
+
+			req := &tfplugin5.CallFunction_Request{
+				Name: testCase.name,
+			}
+
+			for _, argument := range testCase.arguments {
+				req.Arguments = append(req.Arguments, testFunctionArgument(t, argument))
+			}
+
+			// The second call checks that the function definitions are
+			// only fetched once.
+			for range 2 {
+				resp, err := server.CallFunction(context.Background(), req)
+
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				var got *tfprotov5.FunctionError
+
+				if resp.Error != nil {
+					got = &tfprotov5.FunctionError{
+						Text:             resp.Error.Text,
+						FunctionArgument: resp.Error.FunctionArgument,
+					}
+				}
+
+				if diff := cmp.Diff(testCase.expectedError, got); diff != "" {
+					t.Errorf("unexpected function error difference: %s", diff)
+				}
+			}
+
+			if provider.callFunctionCalls != 2*testCase.expectedCallFunctionCalls {
+				t.Errorf("expected %d CallFunction calls, got %d", 2*testCase.expectedCallFunctionCalls, provider.callFunctionCalls)
+			}
+
+			if provider.getFunctionsCalls != testCase.expectedGetFunctionsCalls {
+				t.Errorf("expected %d GetFunctions calls, got %d", testCase.expectedGetFunctionsCalls, provider.getFunctionsCalls)
+			}
+		})
+	}
+}
+
+func TestServerCallFunctionGetFunctionsFailure(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		provider *testFunctionProvider
+	}{
+		"error": {
+			provider: &testFunctionProvider{
+				getFunctionsErr: errors.New("test error"),
+			},
+		},
+		"diagnostics": {
+			provider: &testFunctionProvider{
+				getFunctionsDiagnostics: []*tfprotov5.Diagnostic{
+					{
+						Severity: tfprotov5.DiagnosticSeverityError,
+						Summary:  "test error",
+					},
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			server := New("test", testCase.provider, WithFunctionArgumentValidation(), WithFunctionResultCache(10), WithoutLogStderrOverride())
+
+			req := &tfplugin5.CallFunction_Request{
+				Name: "upper",
+				Arguments: []*tfplugin5.DynamicValue{
+					testFunctionArgument(t, tftypes.NewValue(tftypes.String, "test")),
+				},
+			}
+
+			// Concurrent first calls, and later calls, must not fetch the
+			// function definitions again after the first fetch failed.
+			var wg sync.WaitGroup
+
+			for range 10 {
+				wg.Add(1)
+
+				go func() {
+					defer wg.Done()
+
+					resp, err := server.CallFunction(context.Background(), req)
+
+					if err != nil {
+						t.Errorf("unexpected error: %s", err)
+						return
+					}
+
+					if resp.Error != nil {
+						t.Errorf("unexpected function error: %s", resp.Error.Text)
+					}
+				}()
+			}
+
+			wg.Wait()
+
+			if _, err := server.CallFunction(context.Background(), req); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if testCase.provider.callFunctionCalls != 11 {
+				t.Errorf("expected 11 CallFunction calls, got %d", testCase.provider.callFunctionCalls)
+			}
+
+			if testCase.provider.getFunctionsCalls != 1 {
+				t.Errorf("expected 1 GetFunctions call, got %d", testCase.provider.getFunctionsCalls)
+			}
+		})
+	}
+}
+
+func TestServerCallFunctionResultCache(t *testing.T) {
+	t.Parallel()
+
+	a := tftypes.NewValue(tftypes.String, "a")
+	b := tftypes.NewValue(tftypes.String, "b")
+	c := tftypes.NewValue(tftypes.String, "c")
+
+	testCases := map[string]struct {
+		opts                      []ServeOpt
+		name                      string
+		calls                     [][]tftypes.Value
+		expectedCallFunctionCalls int
+		expectedGetFunctionsCalls int
+	}{
+		"disabled": {
+			name:                      "upper",
+			calls:                     [][]tftypes.Value{{a}, {a}},
+			expectedCallFunctionCalls: 2,
+		},
+		"hit": {
+			opts:                      []ServeOpt{WithFunctionResultCache(2)},
+			name:                      "upper",
+			calls:                     [][]tftypes.Value{{a}, {a}, {b}, {a}, {b}},
+			expectedCallFunctionCalls: 2,
+			expectedGetFunctionsCalls: 1,
+		},
+		"eviction": {
+			opts:                      []ServeOpt{WithFunctionResultCache(2)},
+			name:                      "upper",
+			calls:                     [][]tftypes.Value{{a}, {b}, {c}, {a}},
+			expectedCallFunctionCalls: 4,
+			expectedGetFunctionsCalls: 1,
+		},
+		"uncached-function": {
+			opts:                      []ServeOpt{WithFunctionResultCache(2, "upper")},
+			name:                      "upper",
+			calls:                     [][]tftypes.Value{{a}, {a}},
+			expectedCallFunctionCalls: 2,
+		},
+		"undefined-function": {
+			opts:                      []ServeOpt{WithFunctionResultCache(2)},
+			name:                      "other",
+			calls:                     [][]tftypes.Value{{a}, {a}},
+			expectedCallFunctionCalls: 2,
+			expectedGetFunctionsCalls: 1,
+		},
+		"invalid-arguments": {
+			opts:                      []ServeOpt{WithFunctionResultCache(2)},
+			name:                      "upper",
+			calls:                     [][]tftypes.Value{{a, b}, {a, b}},
+			expectedCallFunctionCalls: 2,
+			expectedGetFunctionsCalls: 1,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			provider := &testFunctionProvider{}
+			server := New("test", provider, append(testCase.opts, WithoutLogStderrOverride())...)
+
+			for _, arguments := range testCase.calls {
+				req := &tfplugin5.CallFunction_Request{
+					Name: testCase.name,
+				}
+
+				for _, argument := range arguments {
+					req.Arguments = append(req.Arguments, testFunctionArgument(t, argument))
+				}
+
+				resp, err := server.CallFunction(context.Background(), req)
+
+				if err != nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if resp.Error != nil {
+					t.Fatalf("unexpected function error: %s", resp.Error.Text)
+				}
+
+				result := tfprotov5.DynamicValue{MsgPack: resp.Result.Msgpack}
+
+				got, err := result.Unmarshal(tftypes.String)
+
+				if err != nil {
+					t.Fatalf("unexpected error decoding result: %s", err)
+				}
+
+				if diff := cmp.Diff(tftypes.NewValue(tftypes.String, "RESULT"), got); diff != "" {
+					t.Errorf("unexpected result difference: %s", diff)
+				}
+			}
+
+			if provider.callFunctionCalls != testCase.expectedCallFunctionCalls {
+				t.Errorf("expected %d CallFunction calls, got %d", testCase.expectedCallFunctionCalls, provider.callFunctionCalls)
+			}
+
+			if provider.getFunctionsCalls != testCase.expectedGetFunctionsCalls {
+				t.Errorf("expected %d GetFunctions calls, got %d", testCase.expectedGetFunctionsCalls, provider.getFunctionsCalls)
+			}
+		})
+	}
+}
+
+func TestWithFunctionResultCache(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		size          int
+		expectedError string
+	}{
+		"positive": {
+			size: 1,
+		},
+		"zero": {
+			size:          0,
+			expectedError: "function result cache size must be positive",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var got string
+
+			if err := WithFunctionResultCache(testCase.size).ApplyServeOpt(&ServeConfig{}); err != nil {
+				got = err.Error()
+			}
+
+			if diff := cmp.Diff(testCase.expectedError, got); diff != "" {
+				t.Errorf("unexpected error difference: %s", diff)
+			}
+		})
+	}
+}
@@ -27,9 +27,12 @@ import (
 	"github.com/mitchellh/go-testing-interface"
 
 	"github.com/hashicorp/terraform-plugin-go/internal/logging"
+	"github.com/hashicorp/terraform-plugin-go/internal/lru"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5/internal/diag"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5/internal/fromproto"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/internal/funcargs"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov5/internal/funcerr"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5/internal/tf5serverlogging"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5/internal/tfplugin5"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov5/internal/toproto"
@@ -113,6 +116,11 @@ type ServeConfig struct {
 	disableLogLocation   bool
 	useLoggingSink       testing.T
 	envVar               string
+
+	validateFunctionArguments bool
+
+	functionResultCacheSize int
+	uncachedFunctions       []string
 }
 
 type serveConfigFunc func(*ServeConfig) error
@@ -245,6 +253,58 @@ func WithLogEnvVarName(name string) ServeOpt {
 	})
 }
 
+// WithFunctionArgumentValidation returns a ServeOpt that makes the server
+// check the arguments of CallFunction requests against the definition of the
+// called function before calling the provider. Calls with the wrong number of
+// arguments, or with an argument that cannot be decoded with the type of its
+// parameter, is null while the parameter does not allow null values, or is
+// unknown while the parameter does not allow unknown values, get a
+// FunctionError for the argument without the provider being called.
+//
+// The function definitions are those of the GetProviderSchema or
+// GetFunctions response of the provider. If Terraform has not called either
+// RPC, the server calls GetFunctions of the provider once to get them, and
+// does not call it again if it fails. Calls of functions without a definition
+// are passed to the provider unchecked.
+func WithFunctionArgumentValidation() ServeOpt {
+	return serveConfigFunc(func(in *ServeConfig) error {
+		in.validateFunctionArguments = true
+		return nil
+	})
+}
+
+// WithFunctionResultCache returns a ServeOpt that makes the server cache the
+// results of CallFunction requests, as provider functions are meant to be
+// pure: a call of a function with the same arguments as an earlier call gets
+// the result of that call without the provider being called. The cache holds
+// at most `size` results, evicting the least recently used ones, and is keyed
+// by the function name and the canonical encoding of each argument, so that
+// equal arguments share results however Terraform encoded them.
+//
+// Only results without a FunctionError are cached. The functions named in
+// `uncachedFunctions`, such as functions that are not pure, are never cached.
+// This is the only way for functions to opt out, as the protocol has no way
+// for providers to mark functions as not pure: functions registered with
+// tf5function.WithoutResultCache are only excluded if the names returned by
+// Registry.UncachedFunctions are passed here.
+//
+// The number of cache hits and misses so far is logged with each cached
+// function call in the SDK protocol logs.
+//
+// The arguments are decoded with the function definitions, which are obtained
+// as for WithFunctionArgumentValidation. Calls of functions without a
+// definition are not cached.
+func WithFunctionResultCache(size int, uncachedFunctions ...string) ServeOpt {
+	return serveConfigFunc(func(in *ServeConfig) error {
+		if size <= 0 {
+			return errors.New("function result cache size must be positive")
+		}
+		in.functionResultCacheSize = size
+		in.uncachedFunctions = uncachedFunctions
+		return nil
+	})
+}
+
 // Serve starts a tfprotov5.ProviderServer serving, ready for Terraform to
 // connect to it. The name passed in should be the fully qualified name that
 // users will enter in the source field of the required_providers block, like
@@ -427,6 +487,28 @@ type server struct {
 
 	// protocolVersion is the protocol version for the server.
 	protocolVersion string
+
+	// validateFunctionArguments enables checking the arguments of
+	// CallFunction requests against the function definitions.
+	validateFunctionArguments bool
+
+	// functions are the function definitions of the provider by name, used
+	// to check the arguments of CallFunction requests. They are nil until
+	// the definitions are known.
+	functions   map[string]*tfprotov5.Function
+	functionsMu sync.RWMutex
+
+	// functionsFetch fetches the function definitions from the GetFunctions
+	// RPC of the provider at most once, even if the fetch fails.
+	functionsFetch sync.Once
+
+	// functionResults caches the responses of CallFunction requests by
+	// function name and arguments. It is nil if caching is disabled.
+	functionResults *lru.Cache[string, *tfprotov5.CallFunctionResponse]
+
+	// uncachedFunctions are the names of the functions whose results are
+	// never cached.
+	uncachedFunctions map[string]bool
 }
 
 func mergeStop(ctx context.Context, cancel context.CancelFunc, stopCh chan struct{}) {
@@ -496,6 +578,16 @@ func New(name string, serve tfprotov5.ProviderServer, opts ...ServeOpt) tfplugin
 	if envVar != "" {
 		options = append(options, tfsdklog.WithLogName(envVar), tflog.WithLevelFromEnv(logging.EnvTfLogProvider, envVar))
 	}
+
+	var functionResults *lru.Cache[string, *tfprotov5.CallFunctionResponse]
+	if conf.functionResultCacheSize > 0 {
+		functionResults = lru.New[string, *tfprotov5.CallFunctionResponse](conf.functionResultCacheSize)
+	}
+	uncachedFunctions := make(map[string]bool, len(conf.uncachedFunctions))
+	for _, name := range conf.uncachedFunctions {
+		uncachedFunctions[name] = true
+	}
+
 	return &server{
 		downstream:      serve,
 		stopCh:          make(chan struct{}),
@@ -506,6 +598,10 @@ func New(name string, serve tfprotov5.ProviderServer, opts ...ServeOpt) tfplugin
 		testHandle:      conf.useLoggingSink,
 		protocolDataDir: os.Getenv(logging.EnvTfLogSdkProtoDataDir),
 		protocolVersion: protocolVersion,
+
+		validateFunctionArguments: conf.validateFunctionArguments,
+		functionResults:           functionResults,
+		uncachedFunctions:         uncachedFunctions,
 	}
 }
 
@@ -558,6 +654,8 @@ func (s *server) GetSchema(ctx context.Context, protoReq *tfplugin5.GetProviderS
 	tf5serverlogging.DownstreamResponse(ctx, resp.Diagnostics)
 	tf5serverlogging.ServerCapabilities(ctx, resp.ServerCapabilities)
 
+	s.storeFunctions(resp.Functions, resp.Diagnostics)
+
 	protoResp := toproto.GetProviderSchema_Response(resp)
 
 	return protoResp, nil
@@ -1039,6 +1137,31 @@ func (s *server) CallFunction(ctx context.Context, protoReq *tfplugin5.CallFunct
 		logging.ProtocolData(ctx, s.protocolDataDir, rpc, "Request", fmt.Sprintf("Arguments_%d", position), argument)
 	}
 
+	function, values, funcErr := s.functionArguments(ctx, req)
+
+	if s.validateFunctionArguments && function != nil {
+		if funcErr == nil {
+			funcErr = funcargs.Unknown(function, values)
+		}
+
+		if funcErr != nil {
+			logging.ProtocolTrace(ctx, "Arguments do not match the function definition, not calling downstream")
+			(*funcerr.FunctionError)(funcErr).Log(ctx)
+
+			return toproto.CallFunction_Response(&tfprotov5.CallFunctionResponse{Error: funcErr}), nil
+		}
+	}
+
+	cacheKey := s.functionResultCacheKey(req.Name, values)
+
+	if cacheKey != "" {
+		if resp, ok := s.cachedFunctionResult(ctx, cacheKey); ok {
+			logging.ProtocolData(ctx, s.protocolDataDir, rpc, "Response", "Result", resp.Result)
+
+			return toproto.CallFunction_Response(resp), nil
+		}
+	}
+
 	ctx = tf5serverlogging.DownstreamRequest(ctx)
 
 	resp, err := s.downstream.CallFunction(ctx, req)
@@ -1051,6 +1174,10 @@ func (s *server) CallFunction(ctx context.Context, protoReq *tfplugin5.CallFunct
 	tf5serverlogging.DownstreamResponseWithError(ctx, resp.Error)
 	logging.ProtocolData(ctx, s.protocolDataDir, rpc, "Response", "Result", resp.Result)
 
+	if cacheKey != "" && resp.Error == nil {
+		s.functionResults.Add(cacheKey, resp)
+	}
+
 	protoResp := toproto.CallFunction_Response(resp)
 
 	return protoResp, nil
@@ -1077,6 +1204,8 @@ func (s *server) GetFunctions(ctx context.Context, protoReq *tfplugin5.GetFuncti
 
 	tf5serverlogging.DownstreamResponse(ctx, resp.Diagnostics)
 
+	s.storeFunctions(resp.Functions, resp.Diagnostics)
+
 	protoResp := toproto.GetFunctions_Response(resp)
 
 	return protoResp, nil
@@ -0,0 +1,208 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfprotov6
+
+import (
+	"errors"
+
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// Diagnostics is a collection of Diagnostic. It can be assigned to and
+// converted from the Diagnostics field of RPC responses:
+//
+//	var diags tfprotov6.Diagnostics
+//	diags.AddAttributeError(path, "Invalid value.", "Values must be lowercase.")
+//	resp.Diagnostics = diags
+//
+//	if tfprotov6.Diagnostics(resp.Diagnostics).HasError() {
+//		// ...
+//	}
+type Diagnostics []*Diagnostic
+
+// DiagnosticsFromError returns error Diagnostics for `err`, with the given
+// summary and the message of `err` as detail. Errors joined with errors.Join
+// are converted into a Diagnostic each. When an error is, or wraps, a
+// tftypes.AttributePathError, the Attribute of its Diagnostic is the path of
+// the error; if the error is the tftypes.AttributePathError itself, the
+// detail is the message of the error it wraps, without the path. Nil errors
+// return nil Diagnostics.
+func DiagnosticsFromError(summary string, err error) Diagnostics {
+	if err == nil {
+		return nil
+	}
+
+	if joined, ok := err.(interface{ Unwrap() []error }); ok {
+		var diags Diagnostics
+
+		for _, err := range joined.Unwrap() {
+			diags.Append(DiagnosticsFromError(summary, err)...)
+		}
+
+		return diags
+	}
+
+	diagnostic := &Diagnostic{
+		Severity: DiagnosticSeverityError,
+		Summary:  summary,
+		Detail:   err.Error(),
+	}
+
+	var pathErr tftypes.AttributePathError
+
+	if errors.As(err, &pathErr) {
+		diagnostic.Attribute = pathErr.Path
+
+		if _, ok := err.(tftypes.AttributePathError); ok && pathErr.Unwrap() != nil {
+			diagnostic.Detail = pathErr.Unwrap().Error()
+		}
+	}
+
+	return Diagnostics{diagnostic}
+}
+
+// AddError appends an error Diagnostic with the given summary and detail.
+func (d *Diagnostics) AddError(summary, detail string) {
+	d.Append(&Diagnostic{
+		Severity: DiagnosticSeverityError,
+		Summary:  summary,
+		Detail:   detail,
+	})
+}
+
+// AddWarning appends a warning Diagnostic with the given summary and detail.
+func (d *Diagnostics) AddWarning(summary, detail string) {
+	d.Append(&Diagnostic{
+		Severity: DiagnosticSeverityWarning,
+		Summary:  summary,
+		Detail:   detail,
+	})
+}
+
+// AddAttributeError appends an error Diagnostic for the attribute at `path`
+// with the given summary and detail.
+func (d *Diagnostics) AddAttributeError(path *tftypes.AttributePath, summary, detail string) {
+	d.Append(&Diagnostic{
+		Severity:  DiagnosticSeverityError,
+		Summary:   summary,
+		Detail:    detail,
+		Attribute: path,
+	})
+}
+
+// AddAttributeWarning appends a warning Diagnostic for the attribute at
+// `path` with the given summary and detail.
+func (d *Diagnostics) AddAttributeWarning(path *tftypes.AttributePath, summary, detail string) {
+	d.Append(&Diagnostic{
+		Severity:  DiagnosticSeverityWarning,
+		Summary:   summary,
+		Detail:    detail,
+		Attribute: path,
+	})
+}
+
+// Append appends `diags`, such as the Diagnostics of a sub-call, leaving out
+// nil diagnostics and diagnostics that are already in the collection.
+func (d *Diagnostics) Append(diags ...*Diagnostic) {
+	for _, diagnostic := range diags {
+		if diagnostic == nil || d.Contains(diagnostic) {
+			continue
+		}
+
+		*d = append(*d, diagnostic)
+	}
+}
+
+// Contains returns true if the collection has a Diagnostic equal to
+// `diagnostic`, with the same severity, summary, detail, and attribute path.
+func (d Diagnostics) Contains(diagnostic *Diagnostic) bool {
+	for _, existing := range d {
+		if diagnosticsEqual(existing, diagnostic) {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Deduplicate returns the collection without nil diagnostics and without
+// diagnostics equal to an earlier one, keeping the order of the others.
+func (d Diagnostics) Deduplicate() Diagnostics {
+	var res Diagnostics
+
+	res.Append(d...)
+
+	return res
+}
+
+// HasError returns true if the collection has an error Diagnostic.
+func (d Diagnostics) HasError() bool {
+	for _, diagnostic := range d {
+		if diagnostic != nil && diagnostic.Severity == DiagnosticSeverityError {
+			return true
+		}
+	}
+
+	return false
+}
+
+// Errors returns the error diagnostics of the collection.
+func (d Diagnostics) Errors() Diagnostics {
+	return d.withSeverity(DiagnosticSeverityError)
+}
+
+// Warnings returns the warning diagnostics of the collection.
+func (d Diagnostics) Warnings() Diagnostics {
+	return d.withSeverity(DiagnosticSeverityWarning)
+}
+
+// Err returns the error diagnostics of the collection as a Go error, or nil
+// if there are none. Each Diagnostic is converted into an error with its
+// summary and detail as message, wrapped in a tftypes.AttributePathError if
+// it has an attribute path, and the errors are joined with errors.Join.
+// Warning diagnostics are left out.
+func (d Diagnostics) Err() error {
+	var errs []error
+
+	for _, diagnostic := range d.Errors() {
+		msg := diagnostic.Summary
+
+		if diagnostic.Detail != "" {
+			msg += ": " + diagnostic.Detail
+		}
+
+		err := errors.New(msg)
+
+		if diagnostic.Attribute != nil {
+			err = diagnostic.Attribute.NewError(err)
+		}
+
+		errs = append(errs, err)
+	}
+
+	return errors.Join(errs...)
+}
+
+func (d Diagnostics) withSeverity(severity DiagnosticSeverity) Diagnostics {
+	var res Diagnostics
+
+	for _, diagnostic := range d {
+		if diagnostic != nil && diagnostic.Severity == severity {
+			res = append(res, diagnostic)
+		}
+	}
+
+	return res
+}
+
+func diagnosticsEqual(a, b *Diagnostic) bool {
+	if a == nil || b == nil {
+		return a == b
+	}
+
+	return a.Severity == b.Severity &&
+		a.Summary == b.Summary &&
+		a.Detail == b.Detail &&
+		a.Attribute.Equal(b.Attribute)
+}
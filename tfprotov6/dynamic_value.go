@@ -5,6 +5,7 @@ package tfprotov6
 
 import (
 	"bytes"
+	"crypto/sha256"
 	"encoding/json"
 	"errors"
 	"fmt"
@@ -41,6 +42,19 @@ func NewDynamicValue(t tftypes.Type, v tftypes.Value) (DynamicValue, error) {
 	}, nil
 }
 
+// NewDynamicValueWithCodec creates a DynamicValue from a tftypes.Value, like
+// NewDynamicValue, using a tftypes.MsgPackCodec for the type you want to send
+// the value as, such as the one returned by Schema.MsgPackCodec.
+func NewDynamicValueWithCodec(codec *tftypes.MsgPackCodec, v tftypes.Value) (DynamicValue, error) {
+	b, err := codec.Marshal(v)
+	if err != nil {
+		return DynamicValue{}, err
+	}
+	return DynamicValue{
+		MsgPack: b,
+	}, nil
+}
+
 // DynamicValue represents a nested encoding value that came from the protocol.
 // The only way providers should ever interact with it is by calling its
 // `Unmarshal` method to retrieve a `tftypes.Value`. Although the type system
@@ -121,12 +135,80 @@ func (d DynamicValue) IsNull() (bool, error) {
 // unsupported way, or has created one from scratch, and should treat it as
 // opaque and not modify it, only calling `Unmarshal` on `DynamicValue`s
 // received from RPC requests.
+//
+// MessagePack data is decoded with the tftypes.MsgPackCodec for `typ`, which
+// is looked up in the cache of tftypes.NewMsgPackCodec on every call.
 func (d DynamicValue) Unmarshal(typ tftypes.Type) (tftypes.Value, error) {
 	if d.JSON != nil {
 		return tftypes.ValueFromJSON(d.JSON, typ) //nolint:staticcheck
 	}
 	if d.MsgPack != nil {
-		return tftypes.ValueFromMsgPack(d.MsgPack, typ) //nolint:staticcheck
+		return tftypes.NewMsgPackCodec(typ).Unmarshal(d.MsgPack)
+	}
+	return tftypes.Value{}, ErrUnknownDynamicValueType
+}
+
+// UnmarshalWithCodec returns a `tftypes.Value` that represents the information
+// contained in the DynamicValue, like Unmarshal with the type of the
+// tftypes.MsgPackCodec. MessagePack data is decoded by the codec, which avoids
+// inspecting the type again for every value. Providers handling large values,
+// such as the prior state in PlanResourceChange, can use the codec returned
+// by Schema.MsgPackCodec for their resource schema.
+func (d DynamicValue) UnmarshalWithCodec(codec *tftypes.MsgPackCodec) (tftypes.Value, error) {
+	if d.JSON != nil {
+		return tftypes.ValueFromJSON(d.JSON, codec.Type()) //nolint:staticcheck
+	}
+	if d.MsgPack != nil {
+		return codec.Unmarshal(d.MsgPack)
+	}
+	return tftypes.Value{}, ErrUnknownDynamicValueType
+}
+
+// UnmarshalAtPath returns the `tftypes.Value` at `path` within the information
+// contained in the DynamicValue, interpreted as `typ`, without decoding the
+// rest of it. This is useful when only a few attributes of a large value are
+// needed, such as the identifier of a resource in its prior state.
+//
+// The returned Value is the same as the Value at `path` in the Value returned
+// by Unmarshal, including for null and unknown values. If `path` does not
+// exist in the value, because a value on the path is null or unknown or
+// does not have the element or attribute, an error wrapping
+// tftypes.ErrInvalidStep is returned.
+func (d DynamicValue) UnmarshalAtPath(typ tftypes.Type, path *tftypes.AttributePath) (tftypes.Value, error) {
+	if d.JSON != nil {
+		return tftypes.ValueFromJSONAtPath(d.JSON, typ, path) //nolint:staticcheck
+	}
+	if d.MsgPack != nil {
+		return tftypes.ValueFromMsgPackAtPath(d.MsgPack, typ, path) //nolint:staticcheck
 	}
 	return tftypes.Value{}, ErrUnknownDynamicValueType
 }
+
+// CanonicalEncoding returns the canonical encoding of the `tftypes.Value`
+// contained in the DynamicValue, after unmarshaling it as `typ`. The encoding
+// is the same whether the DynamicValue holds JSON or MessagePack data. See
+// tftypes.Value.CanonicalEncoding for details.
+func (d DynamicValue) CanonicalEncoding(typ tftypes.Type) ([]byte, error) {
+	val, err := d.Unmarshal(typ)
+
+	if err != nil {
+		return nil, err
+	}
+
+	return val.CanonicalEncoding(), nil
+}
+
+// ContentHash returns the SHA-256 hash of the canonical encoding of the
+// `tftypes.Value` contained in the DynamicValue, after unmarshaling it as
+// `typ`. It can be used as a cache key for work based on the value, such as
+// API lookups during ReadDataSource. See tftypes.Value.ContentHash for
+// details.
+func (d DynamicValue) ContentHash(typ tftypes.Type) ([sha256.Size]byte, error) {
+	val, err := d.Unmarshal(typ)
+
+	if err != nil {
+		return [sha256.Size]byte{}, err
+	}
+
+	return val.ContentHash(), nil
+}
@@ -0,0 +1,116 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfprotov6_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func BenchmarkDynamicValueUnmarshal1000(b *testing.B) {
+	schema, priorState := benchmarkPriorState(b, 1000)
+	typ := schema.ValueType()
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		_, err := priorState.Unmarshal(typ)
+
+		if err != nil {
+			b.Fatalf("unexpected Unmarshal error: %s", err)
+		}
+	}
+}
+
+func BenchmarkDynamicValueUnmarshalWithCodec1000(b *testing.B) {
+	schema, priorState := benchmarkPriorState(b, 1000)
+
+	b.ReportAllocs()
+	b.ResetTimer()
+
+	for n := 0; n < b.N; n++ {
+		// The codec is looked up per request, as a provider would.
+		_, err := priorState.UnmarshalWithCodec(schema.MsgPackCodec())
+
+		if err != nil {
+			b.Fatalf("unexpected UnmarshalWithCodec error: %s", err)
+		}
+	}
+}
+
+// benchmarkPriorState returns a resource schema and a prior state for it, as
+// received by PlanResourceChange, with a set of rule blocks.
+func benchmarkPriorState(b *testing.B, rules int) (*tfprotov6.Schema, tfprotov6.DynamicValue) {
+	b.Helper()
+
+	schema := &tfprotov6.Schema{
+		Block: &tfprotov6.SchemaBlock{
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{Name: "id", Type: tftypes.String, Computed: true},
+				{Name: "name", Type: tftypes.String, Required: true},
+				{Name: "tags", Type: tftypes.Map{ElementType: tftypes.String}, Optional: true},
+			},
+			BlockTypes: []*tfprotov6.SchemaNestedBlock{
+				{
+					TypeName: "rule",
+					Nesting:  tfprotov6.SchemaNestedBlockNestingModeSet,
+					Block: &tfprotov6.SchemaBlock{
+						Attributes: []*tfprotov6.SchemaAttribute{
+							{Name: "description", Type: tftypes.String, Optional: true},
+							{Name: "enabled", Type: tftypes.Bool, Optional: true},
+							{Name: "from_port", Type: tftypes.Number, Required: true},
+							{Name: "to_port", Type: tftypes.Number, Required: true},
+							{Name: "cidr_blocks", Type: tftypes.List{ElementType: tftypes.String}, Optional: true},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	typ, ok := schema.ValueType().(tftypes.Object)
+
+	if !ok {
+		b.Fatalf("expected object type, got %s", schema.ValueType())
+	}
+
+	ruleType, ok := typ.AttributeTypes["rule"].(tftypes.Set)
+
+	if !ok {
+		b.Fatalf("expected set type, got %s", typ.AttributeTypes["rule"])
+	}
+
+	ruleValues := make([]tftypes.Value, 0, rules)
+
+	for index := 0; index < rules; index++ {
+		ruleValues = append(ruleValues, tftypes.NewValue(ruleType.ElementType, map[string]tftypes.Value{
+			"description": tftypes.NewValue(tftypes.String, fmt.Sprintf("rule %d", index)),
+			"enabled":     tftypes.NewValue(tftypes.Bool, true),
+			"from_port":   tftypes.NewValue(tftypes.Number, index),
+			"to_port":     tftypes.NewValue(tftypes.Number, index+100),
+			"cidr_blocks": tftypes.NewValue(tftypes.List{ElementType: tftypes.String}, []tftypes.Value{
+				tftypes.NewValue(tftypes.String, "10.0.0.0/8"),
+			}),
+		}))
+	}
+
+	priorState, err := tfprotov6.NewDynamicValue(typ, tftypes.NewValue(typ, map[string]tftypes.Value{
+		"id":   tftypes.NewValue(tftypes.String, "abc123"),
+		"name": tftypes.NewValue(tftypes.String, "test"),
+		"tags": tftypes.NewValue(tftypes.Map{ElementType: tftypes.String}, map[string]tftypes.Value{
+			"env": tftypes.NewValue(tftypes.String, "test"),
+		}),
+		"rule": tftypes.NewValue(ruleType, ruleValues),
+	}))
+
+	if err != nil {
+		b.Fatalf("unexpected NewDynamicValue error: %s", err)
+	}
+
+	return schema, priorState
+}
@@ -3,6 +3,8 @@
 
 package tfprotov6
 
+import "github.com/hashicorp/terraform-plugin-go/tftypes"
+
 // FunctionError is used to convey information back to the user running Terraform.
 type FunctionError struct {
 	// Text is the description of the error.
@@ -12,3 +14,137 @@ type FunctionError struct {
 	// configuration source.
 	FunctionArgument *int64
 }
+
+// functionErrorSummary is the summary of the diagnostics converted from a
+// FunctionError.
+const functionErrorSummary = "Error in function call"
+
+// Diagnostics returns the FunctionError as Diagnostics, for code that reports
+// problems as diagnostics. A nil FunctionError, or one without text or
+// argument, returns nil Diagnostics. Otherwise the Diagnostics have a single
+// error Diagnostic, with the text as detail and, if the FunctionError has an
+// argument, an attribute path of a single tftypes.ElementKeyInt step with the
+// argument index.
+func (e *FunctionError) Diagnostics() Diagnostics {
+	if e == nil || (e.Text == "" && e.FunctionArgument == nil) {
+		return nil
+	}
+
+	diagnostic := &Diagnostic{
+		Severity: DiagnosticSeverityError,
+		Summary:  functionErrorSummary,
+		Detail:   e.Text,
+	}
+
+	if e.FunctionArgument != nil {
+		diagnostic.Attribute = tftypes.NewAttributePath().WithElementKeyInt(int(*e.FunctionArgument))
+	}
+
+	return Diagnostics{diagnostic}
+}
+
+// FunctionError returns the error diagnostics of the collection as a
+// FunctionError, or nil if there are none. Warning diagnostics are left out,
+// as function calls cannot return warnings.
+//
+// The text of the FunctionError is the summary and detail of each error
+// Diagnostic, separated by ": ", with a line for each Diagnostic. Its
+// argument is the argument index of the first error Diagnostic with an
+// attribute path that refers to an argument. The first step of such a path
+// is either:
+//
+//   - a tftypes.ElementKeyInt with the index of the argument, counting the
+//     positional parameters and then the variadic arguments
+//   - a tftypes.AttributeName with the name of a positional parameter of
+//     `function`
+//   - a tftypes.AttributeName with the name of the variadic parameter of
+//     `function`, followed by a tftypes.ElementKeyInt with the index of the
+//     variadic argument
+//
+// The `function` definition is only needed for paths with parameter names,
+// and may be nil.
+func (d Diagnostics) FunctionError(function *Function) *FunctionError {
+	var res *FunctionError
+
+	for _, diagnostic := range d.Errors() {
+		if res == nil {
+			res = &FunctionError{}
+		}
+
+		text := diagnostic.Summary
+
+		switch {
+		case text == "":
+			text = diagnostic.Detail
+		case diagnostic.Detail != "":
+			text += ": " + diagnostic.Detail
+		}
+
+		if text != "" {
+			if res.Text != "" {
+				res.Text += "\n"
+			}
+
+			res.Text += text
+		}
+
+		if res.FunctionArgument == nil {
+			res.FunctionArgument = functionArgument(function, diagnostic.Attribute)
+		}
+	}
+
+	return res
+}
+
+// functionArgument returns the index of the function argument `path` refers
+// to, or nil if it does not refer to one.
+func functionArgument(function *Function, path *tftypes.AttributePath) *int64 {
+	steps := path.Steps()
+
+	if len(steps) == 0 {
+		return nil
+	}
+
+	switch step := steps[0].(type) {
+	case tftypes.ElementKeyInt:
+		if step < 0 {
+			return nil
+		}
+
+		index := int64(step)
+
+		return &index
+	case tftypes.AttributeName:
+		if function == nil {
+			return nil
+		}
+
+		for i, parameter := range function.Parameters {
+			if parameter != nil && parameter.Name == string(step) {
+				index := int64(i)
+
+				return &index
+			}
+		}
+
+		if function.VariadicParameter == nil || function.VariadicParameter.Name != string(step) {
+			return nil
+		}
+
+		index := int64(len(function.Parameters))
+
+		if len(steps) > 1 {
+			variadicIndex, ok := steps[1].(tftypes.ElementKeyInt)
+
+			if !ok || variadicIndex < 0 {
+				return nil
+			}
+
+			index += int64(variadicIndex)
+		}
+
+		return &index
+	}
+
+	return nil
+}
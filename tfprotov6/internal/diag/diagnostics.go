@@ -10,26 +10,14 @@ import (
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 )
 
-// Diagnostics is a collection of Diagnostic.
-type Diagnostics []*tfprotov6.Diagnostic
+// Diagnostics is the public tfprotov6.Diagnostics collection, with the
+// logging the server needs. Its other behaviours are those of
+// tfprotov6.Diagnostics, so that the two cannot drift apart.
+type Diagnostics tfprotov6.Diagnostics
 
 // ErrorCount returns the number of error severity diagnostics.
 func (d Diagnostics) ErrorCount() int {
-	var result int
-
-	for _, diagnostic := range d {
-		if diagnostic == nil {
-			continue
-		}
-
-		if diagnostic.Severity != tfprotov6.DiagnosticSeverityError {
-			continue
-		}
-
-		result++
-	}
-
-	return result
+	return len(tfprotov6.Diagnostics(d).Errors())
 }
 
 // Log will log every diagnostic:
@@ -66,19 +54,5 @@ func (d Diagnostics) Log(ctx context.Context) {
 
 // WarningCount returns the number of warning severity diagnostics.
 func (d Diagnostics) WarningCount() int {
-	var result int
-
-	for _, diagnostic := range d {
-		if diagnostic == nil {
-			continue
-		}
-
-		if diagnostic.Severity != tfprotov6.DiagnosticSeverityWarning {
-			continue
-		}
-
-		result++
-	}
-
-	return result
+	return len(tfprotov6.Diagnostics(d).Warnings())
 }
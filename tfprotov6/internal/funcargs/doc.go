@@ -0,0 +1,7 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+// Package funcargs contains helpers to check the arguments of function calls
+// against function definitions. These implementations are intentionally
+// outside the public API.
+package funcargs
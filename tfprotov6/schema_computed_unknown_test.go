@@ -0,0 +1,359 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfprotov6_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSchemaBlockMarkComputedUnknownWithOpts(t *testing.T) {
+	t.Parallel()
+
+	nestedObjectType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"computed": tftypes.String,
+			"optional": tftypes.String,
+		},
+	}
+
+	nestedAttributes := []*tfprotov6.SchemaAttribute{
+		{
+			Name:     "computed",
+			Type:     tftypes.String,
+			Computed: true,
+		},
+		{
+			Name:     "optional",
+			Type:     tftypes.String,
+			Optional: true,
+		},
+	}
+
+	block := &tfprotov6.SchemaBlock{
+		Attributes: []*tfprotov6.SchemaAttribute{
+			{
+				Name:     "id",
+				Type:     tftypes.String,
+				Computed: true,
+			},
+			{
+				Name:     "optional_computed",
+				Type:     tftypes.String,
+				Optional: true,
+				Computed: true,
+			},
+			{
+				Name: "nested_map",
+				NestedType: &tfprotov6.SchemaObject{
+					Attributes: nestedAttributes,
+					Nesting:    tfprotov6.SchemaObjectNestingModeMap,
+				},
+				Optional: true,
+			},
+		},
+		BlockTypes: []*tfprotov6.SchemaNestedBlock{
+			{
+				TypeName: "set_block",
+				Block: &tfprotov6.SchemaBlock{
+					Attributes: nestedAttributes,
+				},
+				Nesting: tfprotov6.SchemaNestedBlockNestingModeSet,
+			},
+		},
+	}
+
+	blockType := block.ValueType()
+
+	nestedObject := func(computed, optional interface{}) tftypes.Value {
+		return tftypes.NewValue(nestedObjectType, map[string]tftypes.Value{
+			"computed": tftypes.NewValue(tftypes.String, computed),
+			"optional": tftypes.NewValue(tftypes.String, optional),
+		})
+	}
+
+	object := func(id, optionalComputed interface{}, nestedMap map[string]tftypes.Value, setBlock []tftypes.Value) tftypes.Value {
+		return tftypes.NewValue(blockType, map[string]tftypes.Value{
+			"id":                tftypes.NewValue(tftypes.String, id),
+			"optional_computed": tftypes.NewValue(tftypes.String, optionalComputed),
+			"nested_map":        tftypes.NewValue(tftypes.Map{ElementType: nestedObjectType}, nestedMap),
+			"set_block":         tftypes.NewValue(tftypes.Set{ElementType: nestedObjectType}, setBlock),
+		})
+	}
+
+	testCases := map[string]struct {
+		config     tftypes.Value
+		priorState tftypes.Value
+		keep       []*tftypes.AttributePath
+		expected   tftypes.Value
+	}{
+		"destroy": {
+			config:     tftypes.NewValue(blockType, nil),
+			priorState: object("id-123", "a", nil, []tftypes.Value{}),
+			expected:   tftypes.NewValue(blockType, nil),
+		},
+		"create": {
+			config: object(nil, nil,
+				map[string]tftypes.Value{
+					"key": nestedObject(nil, "x"),
+				},
+				[]tftypes.Value{
+					nestedObject(nil, "y"),
+				},
+			),
+			priorState: tftypes.NewValue(blockType, nil),
+			expected: object(tftypes.UnknownValue, tftypes.UnknownValue,
+				map[string]tftypes.Value{
+					"key": nestedObject(tftypes.UnknownValue, "x"),
+				},
+				[]tftypes.Value{
+					nestedObject(tftypes.UnknownValue, "y"),
+				},
+			),
+		},
+		"update-optional-computed-configured": {
+			config:     object(nil, "config", nil, []tftypes.Value{}),
+			priorState: object("id-123", "prior", nil, []tftypes.Value{}),
+			expected:   object(tftypes.UnknownValue, "config", nil, []tftypes.Value{}),
+		},
+		"update-no-changes": {
+			config: object(nil, nil,
+				map[string]tftypes.Value{
+					"key": nestedObject(nil, "x"),
+				},
+				[]tftypes.Value{
+					nestedObject(nil, "y"),
+				},
+			),
+			priorState: object("id-123", "prior",
+				map[string]tftypes.Value{
+					"key": nestedObject("c1", "x"),
+				},
+				[]tftypes.Value{
+					nestedObject("c2", "y"),
+				},
+			),
+			expected: object("id-123", "prior",
+				map[string]tftypes.Value{
+					"key": nestedObject("c1", "x"),
+				},
+				[]tftypes.Value{
+					nestedObject("c2", "y"),
+				},
+			),
+		},
+		"update-unchanged-elements": {
+			config: object(nil, nil,
+				map[string]tftypes.Value{
+					"key":   nestedObject(nil, "x"),
+					"other": nestedObject(nil, "changed"),
+				},
+				[]tftypes.Value{
+					nestedObject(nil, "y"),
+					nestedObject(nil, "z"),
+				},
+			),
+			priorState: object("id-123", "prior",
+				map[string]tftypes.Value{
+					"key":   nestedObject("c1", "x"),
+					"other": nestedObject("c2", "y"),
+				},
+				[]tftypes.Value{
+					nestedObject("c3", "y"),
+				},
+			),
+			expected: object(tftypes.UnknownValue, tftypes.UnknownValue,
+				map[string]tftypes.Value{
+					"key":   nestedObject("c1", "x"),
+					"other": nestedObject(tftypes.UnknownValue, "changed"),
+				},
+				[]tftypes.Value{
+					nestedObject("c3", "y"),
+					nestedObject(tftypes.UnknownValue, "z"),
+				},
+			),
+		},
+		"update-keep-prior-state": {
+			config: object(nil, nil,
+				map[string]tftypes.Value{
+					"key": nestedObject(nil, "changed"),
+				},
+				[]tftypes.Value{},
+			),
+			priorState: object("id-123", "prior",
+				map[string]tftypes.Value{
+					"key": nestedObject("c1", "x"),
+				},
+				[]tftypes.Value{},
+			),
+			keep: []*tftypes.AttributePath{
+				tftypes.NewAttributePath().WithAttributeName("id"),
+				tftypes.NewAttributePath().WithAttributeName("nested_map"),
+			},
+			expected: object("id-123", tftypes.UnknownValue,
+				map[string]tftypes.Value{
+					"key": nestedObject("c1", "changed"),
+				},
+				[]tftypes.Value{},
+			),
+		},
+		"update-keep-prior-state-nested": {
+			config: object(nil, nil,
+				map[string]tftypes.Value{
+					"key":   nestedObject(nil, "x2"),
+					"other": nestedObject(nil, "y2"),
+				},
+				[]tftypes.Value{},
+			),
+			priorState: object("id-123", "prior",
+				map[string]tftypes.Value{
+					"key":   nestedObject("c1", "x"),
+					"other": nestedObject("c2", "y"),
+				},
+				[]tftypes.Value{},
+			),
+			keep: []*tftypes.AttributePath{
+				tftypes.NewAttributePath().WithAttributeName("nested_map").WithElementKeyString("key").WithAttributeName("computed"),
+			},
+			expected: object(tftypes.UnknownValue, tftypes.UnknownValue,
+				map[string]tftypes.Value{
+					"key":   nestedObject("c1", "x2"),
+					"other": nestedObject(tftypes.UnknownValue, "y2"),
+				},
+				[]tftypes.Value{},
+			),
+		},
+		"update-keep-prior-state-set-elements": {
+			config: object(nil, "config",
+				nil,
+				[]tftypes.Value{
+					nestedObject(nil, "y"),
+					nestedObject(nil, "z"),
+				},
+			),
+			priorState: object("id-123", "prior",
+				nil,
+				[]tftypes.Value{
+					nestedObject("c1", "y"),
+				},
+			),
+			keep: []*tftypes.AttributePath{
+				tftypes.NewAttributePath().WithAttributeName("set_block").WithAttributeName("computed"),
+			},
+			expected: object(tftypes.UnknownValue, "config",
+				nil,
+				[]tftypes.Value{
+					nestedObject("c1", "y"),
+					nestedObject(nil, "z"),
+				},
+			),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := block.MarkComputedUnknownWithOpts(testCase.config, testCase.priorState, tfprotov6.MarkComputedUnknownOpts{
+				KeepPriorState: testCase.keep,
+			})
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestSchemaBlockMarkComputedUnknown_removedOptionalComputedNestedAttribute(t *testing.T) {
+	t.Parallel()
+
+	nestedObjectType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"computed": tftypes.String,
+			"optional": tftypes.String,
+		},
+	}
+
+	schema := &tfprotov6.Schema{
+		Block: &tfprotov6.SchemaBlock{
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name:     "id",
+					Type:     tftypes.String,
+					Computed: true,
+				},
+				{
+					Name: "nested",
+					NestedType: &tfprotov6.SchemaObject{
+						Attributes: []*tfprotov6.SchemaAttribute{
+							{
+								Name:     "computed",
+								Type:     tftypes.String,
+								Computed: true,
+							},
+							{
+								Name:     "optional",
+								Type:     tftypes.String,
+								Optional: true,
+							},
+						},
+						Nesting: tfprotov6.SchemaObjectNestingModeSingle,
+					},
+					Optional: true,
+					Computed: true,
+				},
+			},
+		},
+	}
+
+	blockType := schema.ValueType()
+
+	object := func(id interface{}, nested tftypes.Value) tftypes.Value {
+		return tftypes.NewValue(blockType, map[string]tftypes.Value{
+			"id":     tftypes.NewValue(tftypes.String, id),
+			"nested": nested,
+		})
+	}
+
+	priorState := object("id-123", tftypes.NewValue(nestedObjectType, map[string]tftypes.Value{
+		"computed": tftypes.NewValue(tftypes.String, "c"),
+		"optional": tftypes.NewValue(tftypes.String, "from-config"),
+	}))
+	config := object(nil, tftypes.NewValue(nestedObjectType, nil))
+
+	// The nested attribute was removed from the configuration, so it is
+	// planned null instead of keeping the prior value or being computed.
+	expected := object("id-123", tftypes.NewValue(nestedObjectType, nil))
+
+	proposed, err := schema.ProposedNewState(priorState, config)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	if diff := cmp.Diff(expected, proposed); diff != "" {
+		t.Errorf("unexpected proposed new state difference: %s", diff)
+	}
+
+	got, err := schema.Block.MarkComputedUnknown(config, priorState)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	expected = object(tftypes.UnknownValue, tftypes.NewValue(nestedObjectType, nil))
+
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("unexpected planned value difference: %s", diff)
+	}
+}
@@ -0,0 +1,297 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfprotov6_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSchemaAttributeAtPath(t *testing.T) {
+	t.Parallel()
+
+	schema := testSchemaPathSchema()
+	block := schema.Block
+	ruleBlock := block.BlockTypes[0]
+	timeoutsBlock := block.BlockTypes[1]
+	listenersAttribute := block.Attributes[2]
+
+	testCases := map[string]struct {
+		path              *tftypes.AttributePath
+		expected          *tfprotov6.SchemaAttribute
+		expectedNestingTo []string
+		expectedSet       []bool
+	}{
+		"root": {
+			path: tftypes.NewAttributePath(),
+		},
+		"attribute": {
+			path:     tftypes.NewAttributePath().WithAttributeName("id"),
+			expected: block.Attributes[0],
+		},
+		"collection-element": {
+			path:     tftypes.NewAttributePath().WithAttributeName("tags").WithElementKeyString("env"),
+			expected: block.Attributes[1],
+		},
+		"missing": {
+			path: tftypes.NewAttributePath().WithAttributeName("missing"),
+		},
+		"list-block-attribute": {
+			path:              tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyInt(0).WithAttributeName("port"),
+			expected:          ruleBlock.Block.Attributes[0],
+			expectedNestingTo: []string{"rule"},
+			expectedSet:       []bool{false},
+		},
+		"list-block-missing-element-step": {
+			path: tftypes.NewAttributePath().WithAttributeName("rule").WithAttributeName("port"),
+		},
+		"list-block-wrong-element-step": {
+			path: tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyString("a").WithAttributeName("port"),
+		},
+		"set-block-attribute": {
+			path: tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyInt(0).
+				WithAttributeName("target").WithElementKeyValue(tftypes.NewValue(tftypes.String, "x")).WithAttributeName("address"),
+			expected:          ruleBlock.Block.BlockTypes[0].Block.Attributes[0],
+			expectedNestingTo: []string{"rule", "target"},
+			expectedSet:       []bool{false, true},
+		},
+		"single-block-attribute": {
+			path:              tftypes.NewAttributePath().WithAttributeName("timeouts").WithAttributeName("create"),
+			expected:          timeoutsBlock.Block.Attributes[0],
+			expectedNestingTo: []string{"timeouts"},
+			expectedSet:       []bool{false},
+		},
+		"nested-attribute": {
+			path:     tftypes.NewAttributePath().WithAttributeName("listeners"),
+			expected: listenersAttribute,
+		},
+		"nested-attribute-element": {
+			path:              tftypes.NewAttributePath().WithAttributeName("listeners").WithElementKeyValue(tftypes.NewValue(tftypes.String, "x")),
+			expected:          listenersAttribute,
+			expectedNestingTo: []string{"listeners"},
+			expectedSet:       []bool{true},
+		},
+		"nested-attribute-attribute": {
+			path: tftypes.NewAttributePath().WithAttributeName("listeners").WithElementKeyValue(tftypes.NewValue(tftypes.String, "x")).
+				WithAttributeName("options").WithElementKeyString("a").WithAttributeName("secret"),
+			expected:          listenersAttribute.NestedType.Attributes[1].NestedType.Attributes[0],
+			expectedNestingTo: []string{"listeners", "options"},
+			expectedSet:       []bool{true, false},
+		},
+		"nested-attribute-single": {
+			path:              tftypes.NewAttributePath().WithAttributeName("network").WithAttributeName("cidr"),
+			expected:          block.Attributes[3].NestedType.Attributes[0],
+			expectedNestingTo: []string{"network"},
+			expectedSet:       []bool{false},
+		},
+		"block-not-attribute": {
+			path: tftypes.NewAttributePath().WithAttributeName("rule"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, nesting := schema.AttributeAtPath(testCase.path)
+
+			if got != testCase.expected {
+				t.Errorf("expected attribute %v, got %v", testCase.expected, got)
+			}
+
+			if diff := cmp.Diff(testCase.expectedNestingTo, schemaPathNestingNames(nesting)); diff != "" {
+				t.Errorf("unexpected nesting difference: %s", diff)
+			}
+
+			var gotSet []bool
+
+			for _, n := range nesting {
+				gotSet = append(gotSet, n.IsSet())
+			}
+
+			if diff := cmp.Diff(testCase.expectedSet, gotSet); diff != "" {
+				t.Errorf("unexpected set difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestSchemaBlockAtPath(t *testing.T) {
+	t.Parallel()
+
+	schema := testSchemaPathSchema()
+	ruleBlock := schema.Block.BlockTypes[0]
+	targetBlock := ruleBlock.Block.BlockTypes[0]
+
+	testCases := map[string]struct {
+		path              *tftypes.AttributePath
+		expected          *tfprotov6.SchemaNestedBlock
+		expectedNestingTo []string
+	}{
+		"block": {
+			path:     tftypes.NewAttributePath().WithAttributeName("rule"),
+			expected: ruleBlock,
+		},
+		"block-element": {
+			path:              tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyInt(1),
+			expected:          ruleBlock,
+			expectedNestingTo: []string{"rule"},
+		},
+		"nested-block": {
+			path:              tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyInt(1).WithAttributeName("target"),
+			expected:          targetBlock,
+			expectedNestingTo: []string{"rule"},
+		},
+		"nested-block-wrong-element-step": {
+			path: tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyInt(1).WithAttributeName("target").WithElementKeyInt(0),
+		},
+		"block-attribute": {
+			path:              tftypes.NewAttributePath().WithAttributeName("rule").WithElementKeyInt(1).WithAttributeName("port"),
+			expectedNestingTo: []string{"rule"},
+		},
+		"attribute": {
+			path: tftypes.NewAttributePath().WithAttributeName("id"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, nesting := schema.BlockAtPath(testCase.path)
+
+			if got != testCase.expected {
+				t.Errorf("expected block %v, got %v", testCase.expected, got)
+			}
+
+			if diff := cmp.Diff(testCase.expectedNestingTo, schemaPathNestingNames(nesting)); diff != "" {
+				t.Errorf("unexpected nesting difference: %s", diff)
+			}
+		})
+	}
+}
+
+func schemaPathNestingNames(nesting []tfprotov6.SchemaPathNesting) []string {
+	var names []string
+
+	for _, n := range nesting {
+		switch {
+		case n.Block != nil:
+			names = append(names, n.Block.TypeName)
+		case n.Attribute != nil:
+			names = append(names, n.Attribute.Name)
+		}
+	}
+
+	return names
+}
+
+func testSchemaPathSchema() *tfprotov6.Schema {
+	return &tfprotov6.Schema{
+		Block: &tfprotov6.SchemaBlock{
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name:     "id",
+					Type:     tftypes.String,
+					Computed: true,
+				},
+				{
+					Name:     "tags",
+					Type:     tftypes.Map{ElementType: tftypes.String},
+					Optional: true,
+				},
+				{
+					Name: "listeners",
+					NestedType: &tfprotov6.SchemaObject{
+						Nesting: tfprotov6.SchemaObjectNestingModeSet,
+						Attributes: []*tfprotov6.SchemaAttribute{
+							{
+								Name:     "port",
+								Type:     tftypes.Number,
+								Required: true,
+							},
+							{
+								Name: "options",
+								NestedType: &tfprotov6.SchemaObject{
+									Nesting: tfprotov6.SchemaObjectNestingModeMap,
+									Attributes: []*tfprotov6.SchemaAttribute{
+										{
+											Name:      "secret",
+											Type:      tftypes.String,
+											Optional:  true,
+											Sensitive: true,
+										},
+									},
+								},
+								Optional: true,
+							},
+						},
+					},
+					Optional: true,
+				},
+				{
+					Name: "network",
+					NestedType: &tfprotov6.SchemaObject{
+						Nesting: tfprotov6.SchemaObjectNestingModeSingle,
+						Attributes: []*tfprotov6.SchemaAttribute{
+							{
+								Name:     "cidr",
+								Type:     tftypes.String,
+								Required: true,
+							},
+						},
+					},
+					Optional: true,
+				},
+			},
+			BlockTypes: []*tfprotov6.SchemaNestedBlock{
+				{
+					TypeName: "rule",
+					Nesting:  tfprotov6.SchemaNestedBlockNestingModeList,
+					Block: &tfprotov6.SchemaBlock{
+						Attributes: []*tfprotov6.SchemaAttribute{
+							{
+								Name:     "port",
+								Type:     tftypes.Number,
+								Required: true,
+							},
+						},
+						BlockTypes: []*tfprotov6.SchemaNestedBlock{
+							{
+								TypeName: "target",
+								Nesting:  tfprotov6.SchemaNestedBlockNestingModeSet,
+								Block: &tfprotov6.SchemaBlock{
+									Attributes: []*tfprotov6.SchemaAttribute{
+										{
+											Name:     "address",
+											Type:     tftypes.String,
+											Required: true,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				{
+					TypeName: "timeouts",
+					Nesting:  tfprotov6.SchemaNestedBlockNestingModeSingle,
+					Block: &tfprotov6.SchemaBlock{
+						Attributes: []*tfprotov6.SchemaAttribute{
+							{
+								Name:     "create",
+								Type:     tftypes.String,
+								Optional: true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
@@ -0,0 +1,416 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfprotov6_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSchemaProposedNewState(t *testing.T) {
+	t.Parallel()
+
+	nestedObjectType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"computed": tftypes.String,
+			"optional": tftypes.String,
+		},
+	}
+
+	schema := &tfprotov6.Schema{
+		Block: &tfprotov6.SchemaBlock{
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name:     "id",
+					Type:     tftypes.String,
+					Computed: true,
+				},
+				{
+					Name:     "optional",
+					Type:     tftypes.String,
+					Optional: true,
+				},
+				{
+					Name:     "optional_computed",
+					Type:     tftypes.String,
+					Optional: true,
+					Computed: true,
+				},
+				{
+					Name: "nested_list",
+					NestedType: &tfprotov6.SchemaObject{
+						Attributes: []*tfprotov6.SchemaAttribute{
+							{
+								Name:     "computed",
+								Type:     tftypes.String,
+								Computed: true,
+							},
+							{
+								Name:     "optional",
+								Type:     tftypes.String,
+								Optional: true,
+							},
+						},
+						Nesting: tfprotov6.SchemaObjectNestingModeList,
+					},
+					Optional: true,
+				},
+			},
+			BlockTypes: []*tfprotov6.SchemaNestedBlock{
+				{
+					TypeName: "list_block",
+					Block: &tfprotov6.SchemaBlock{
+						Attributes: []*tfprotov6.SchemaAttribute{
+							{
+								Name:     "computed",
+								Type:     tftypes.String,
+								Computed: true,
+							},
+							{
+								Name:     "optional",
+								Type:     tftypes.String,
+								Optional: true,
+							},
+						},
+					},
+					Nesting: tfprotov6.SchemaNestedBlockNestingModeList,
+				},
+				{
+					TypeName: "set_block",
+					Block: &tfprotov6.SchemaBlock{
+						Attributes: []*tfprotov6.SchemaAttribute{
+							{
+								Name:     "computed",
+								Type:     tftypes.String,
+								Computed: true,
+							},
+							{
+								Name:     "optional",
+								Type:     tftypes.String,
+								Optional: true,
+							},
+						},
+					},
+					Nesting: tfprotov6.SchemaNestedBlockNestingModeSet,
+				},
+				{
+					TypeName: "single_block",
+					Block: &tfprotov6.SchemaBlock{
+						Attributes: []*tfprotov6.SchemaAttribute{
+							{
+								Name:     "computed",
+								Type:     tftypes.String,
+								Computed: true,
+							},
+							{
+								Name:     "optional",
+								Type:     tftypes.String,
+								Optional: true,
+							},
+						},
+					},
+					Nesting: tfprotov6.SchemaNestedBlockNestingModeSingle,
+				},
+			},
+		},
+	}
+
+	schemaType := schema.ValueType()
+
+	nestedObject := func(computed, optional *string) tftypes.Value {
+		return tftypes.NewValue(nestedObjectType, map[string]tftypes.Value{
+			"computed": tftypes.NewValue(tftypes.String, computed),
+			"optional": tftypes.NewValue(tftypes.String, optional),
+		})
+	}
+
+	object := func(id, optional, optionalComputed *string, nestedList, listBlock, setBlock, singleBlock tftypes.Value) tftypes.Value {
+		return tftypes.NewValue(schemaType, map[string]tftypes.Value{
+			"id":                tftypes.NewValue(tftypes.String, id),
+			"optional":          tftypes.NewValue(tftypes.String, optional),
+			"optional_computed": tftypes.NewValue(tftypes.String, optionalComputed),
+			"nested_list":       nestedList,
+			"list_block":        listBlock,
+			"set_block":         setBlock,
+			"single_block":      singleBlock,
+		})
+	}
+
+	list := func(vals ...tftypes.Value) tftypes.Value {
+		return tftypes.NewValue(tftypes.List{ElementType: nestedObjectType}, vals)
+	}
+
+	set := func(vals ...tftypes.Value) tftypes.Value {
+		return tftypes.NewValue(tftypes.Set{ElementType: nestedObjectType}, vals)
+	}
+
+	nullList := tftypes.NewValue(tftypes.List{ElementType: nestedObjectType}, nil)
+	nullObject := tftypes.NewValue(nestedObjectType, nil)
+
+	optionalComputedNestedSchema := &tfprotov6.Schema{
+		Block: &tfprotov6.SchemaBlock{
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name: "nested_list",
+					NestedType: &tfprotov6.SchemaObject{
+						Attributes: []*tfprotov6.SchemaAttribute{
+							{
+								Name:     "computed",
+								Type:     tftypes.String,
+								Computed: true,
+							},
+							{
+								Name:     "optional",
+								Type:     tftypes.String,
+								Optional: true,
+							},
+						},
+						Nesting: tfprotov6.SchemaObjectNestingModeList,
+					},
+					Optional: true,
+					Computed: true,
+				},
+			},
+		},
+	}
+
+	optionalComputedNested := func(nestedList tftypes.Value) tftypes.Value {
+		return tftypes.NewValue(optionalComputedNestedSchema.ValueType(), map[string]tftypes.Value{
+			"nested_list": nestedList,
+		})
+	}
+
+	testCases := map[string]struct {
+		schema        *tfprotov6.Schema
+		priorState    tftypes.Value
+		config        tftypes.Value
+		expected      tftypes.Value
+		expectedError error
+	}{
+		"destroy": {
+			schema:     schema,
+			priorState: object(pointer("id-123"), pointer("a"), pointer("b"), nullList, list(), set(), nullObject),
+			config:     tftypes.NewValue(schemaType, nil),
+			expected:   tftypes.NewValue(schemaType, nil),
+		},
+		"create": {
+			schema:     schema,
+			priorState: tftypes.NewValue(schemaType, nil),
+			config: object(nil, pointer("a"), nil,
+				list(nestedObject(nil, pointer("x"))),
+				list(nestedObject(nil, pointer("y"))),
+				set(nestedObject(nil, pointer("z"))),
+				nestedObject(nil, pointer("s")),
+			),
+			expected: object(nil, pointer("a"), nil,
+				list(nestedObject(nil, pointer("x"))),
+				list(nestedObject(nil, pointer("y"))),
+				set(nestedObject(nil, pointer("z"))),
+				nestedObject(nil, pointer("s")),
+			),
+		},
+		"update-computed-from-prior": {
+			schema: schema,
+			priorState: object(pointer("id-123"), pointer("a"), pointer("prior"),
+				list(nestedObject(pointer("c1"), pointer("x"))),
+				list(nestedObject(pointer("c2"), pointer("y"))),
+				set(
+					nestedObject(pointer("c3"), pointer("z")),
+					nestedObject(pointer("c4"), pointer("removed")),
+				),
+				nestedObject(pointer("c5"), pointer("s")),
+			),
+			config: object(nil, pointer("b"), nil,
+				list(nestedObject(nil, pointer("x")), nestedObject(nil, pointer("new"))),
+				list(nestedObject(nil, pointer("changed"))),
+				set(
+					nestedObject(nil, pointer("z")),
+					nestedObject(nil, pointer("added")),
+				),
+				nestedObject(nil, pointer("s")),
+			),
+			expected: object(pointer("id-123"), pointer("b"), pointer("prior"),
+				list(nestedObject(pointer("c1"), pointer("x")), nestedObject(nil, pointer("new"))),
+				list(nestedObject(pointer("c2"), pointer("changed"))),
+				set(
+					nestedObject(pointer("c3"), pointer("z")),
+					nestedObject(nil, pointer("added")),
+				),
+				nestedObject(pointer("c5"), pointer("s")),
+			),
+		},
+		"update-optional-computed-configured": {
+			schema:     schema,
+			priorState: object(pointer("id-123"), nil, pointer("prior"), nullList, list(), set(), nullObject),
+			config:     object(nil, nil, pointer("config"), nullList, list(), set(), nullObject),
+			expected:   object(pointer("id-123"), nil, pointer("config"), nullList, list(), set(), nullObject),
+		},
+		"update-single-block-removed": {
+			schema:     schema,
+			priorState: object(pointer("id-123"), nil, nil, nullList, list(), set(), nestedObject(pointer("c"), pointer("s"))),
+			config:     object(nil, nil, nil, nullList, list(), set(), nullObject),
+			expected:   object(pointer("id-123"), nil, nil, nullList, list(), set(), nullObject),
+		},
+		"unknown-config-values": {
+			schema:     schema,
+			priorState: object(pointer("id-123"), pointer("a"), pointer("prior"), nullList, list(), set(), nullObject),
+			config: tftypes.NewValue(schemaType, map[string]tftypes.Value{
+				"id":                tftypes.NewValue(tftypes.String, nil),
+				"optional":          tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				"optional_computed": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				"nested_list":       tftypes.NewValue(tftypes.List{ElementType: nestedObjectType}, tftypes.UnknownValue),
+				"list_block":        list(),
+				"set_block":         set(),
+				"single_block":      nullObject,
+			}),
+			expected: tftypes.NewValue(schemaType, map[string]tftypes.Value{
+				"id":                tftypes.NewValue(tftypes.String, "id-123"),
+				"optional":          tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				"optional_computed": tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+				"nested_list":       tftypes.NewValue(tftypes.List{ElementType: nestedObjectType}, tftypes.UnknownValue),
+				"list_block":        list(),
+				"set_block":         set(),
+				"single_block":      nullObject,
+			}),
+		},
+		"group-block-prior-null": {
+			schema: &tfprotov6.Schema{
+				Block: &tfprotov6.SchemaBlock{
+					BlockTypes: []*tfprotov6.SchemaNestedBlock{
+						{
+							TypeName: "group",
+							Block: &tfprotov6.SchemaBlock{
+								Attributes: []*tfprotov6.SchemaAttribute{
+									{
+										Name:     "computed",
+										Type:     tftypes.String,
+										Computed: true,
+									},
+								},
+							},
+							Nesting: tfprotov6.SchemaNestedBlockNestingModeGroup,
+						},
+					},
+				},
+			},
+			priorState: tftypes.NewValue(tftypes.Object{
+				AttributeTypes: map[string]tftypes.Type{
+					"group": tftypes.Object{
+						AttributeTypes: map[string]tftypes.Type{
+							"computed": tftypes.String,
+						},
+					},
+				},
+			}, nil),
+			config: tftypes.NewValue(tftypes.Object{
+				AttributeTypes: map[string]tftypes.Type{
+					"group": tftypes.Object{
+						AttributeTypes: map[string]tftypes.Type{
+							"computed": tftypes.String,
+						},
+					},
+				},
+			}, map[string]tftypes.Value{
+				"group": tftypes.NewValue(tftypes.Object{
+					AttributeTypes: map[string]tftypes.Type{
+						"computed": tftypes.String,
+					},
+				}, nil),
+			}),
+			expected: tftypes.NewValue(tftypes.Object{
+				AttributeTypes: map[string]tftypes.Type{
+					"group": tftypes.Object{
+						AttributeTypes: map[string]tftypes.Type{
+							"computed": tftypes.String,
+						},
+					},
+				},
+			}, map[string]tftypes.Value{
+				"group": tftypes.NewValue(tftypes.Object{
+					AttributeTypes: map[string]tftypes.Type{
+						"computed": tftypes.String,
+					},
+				}, map[string]tftypes.Value{
+					"computed": tftypes.NewValue(tftypes.String, nil),
+				}),
+			}),
+		},
+		"optional-computed-nested-attribute-removed": {
+			// The prior value has a non-computed attribute set, so it
+			// must have been configured, and removing it from the
+			// configuration removes it.
+			schema:     optionalComputedNestedSchema,
+			priorState: optionalComputedNested(list(nestedObject(pointer("computed"), pointer("optional")))),
+			config:     optionalComputedNested(nullList),
+			expected:   optionalComputedNested(nullList),
+		},
+		"optional-computed-nested-attribute-computed": {
+			// The prior value only has computed attributes set, so it
+			// was computed by the provider and is kept.
+			schema:     optionalComputedNestedSchema,
+			priorState: optionalComputedNested(list(nestedObject(pointer("computed"), nil))),
+			config:     optionalComputedNested(nullList),
+			expected:   optionalComputedNested(list(nestedObject(pointer("computed"), nil))),
+		},
+		"invalid-nesting-mode": {
+			schema: &tfprotov6.Schema{
+				Block: &tfprotov6.SchemaBlock{
+					BlockTypes: []*tfprotov6.SchemaNestedBlock{
+						{
+							TypeName: "invalid",
+							Block:    &tfprotov6.SchemaBlock{},
+						},
+					},
+				},
+			},
+			priorState: tftypes.NewValue(tftypes.Object{
+				AttributeTypes: map[string]tftypes.Type{
+					"invalid": tftypes.Object{AttributeTypes: map[string]tftypes.Type{}},
+				},
+			}, map[string]tftypes.Value{
+				"invalid": tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{}}, map[string]tftypes.Value{}),
+			}),
+			config: tftypes.NewValue(tftypes.Object{
+				AttributeTypes: map[string]tftypes.Type{
+					"invalid": tftypes.Object{AttributeTypes: map[string]tftypes.Type{}},
+				},
+			}, map[string]tftypes.Value{
+				"invalid": tftypes.NewValue(tftypes.Object{AttributeTypes: map[string]tftypes.Type{}}, map[string]tftypes.Value{}),
+			}),
+			expectedError: tftypes.NewAttributePath().WithAttributeName("invalid").NewErrorf("unsupported nested block nesting mode: INVALID"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := testCase.schema.ProposedNewState(testCase.priorState, testCase.config)
+
+			if err != nil {
+				if testCase.expectedError == nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if err.Error() != testCase.expectedError.Error() {
+					t.Fatalf("expected error %q, got: %s", testCase.expectedError, err)
+				}
+
+				return
+			}
+
+			if testCase.expectedError != nil {
+				t.Fatalf("expected error %q, got none", testCase.expectedError)
+			}
+
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
@@ -0,0 +1,29 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfprotov6
+
+import (
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// RenderDiff returns a human-readable rendering of the differences between
+// two values of the Schema, such as the prior state and planned state of a
+// resource, in the style of a Terraform plan. Attributes marked as Sensitive
+// in the Schema are rendered as "(sensitive value)". See tftypes.RenderDiff
+// for details of the rendering.
+func (s *Schema) RenderDiff(before tftypes.Value, after tftypes.Value) string {
+	var block *SchemaBlock
+
+	if s != nil {
+		block = s.Block
+	}
+
+	return tftypes.RenderDiffWithOpts(before, after, tftypes.RenderDiffOpts{
+		Sensitive: func(path *tftypes.AttributePath) bool {
+			attr := block.attributeAtPath(path.Steps())
+
+			return attr != nil && attr.Sensitive
+		},
+	})
+}
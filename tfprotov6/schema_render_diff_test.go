@@ -0,0 +1,104 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfprotov6_test
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSchemaRenderDiff(t *testing.T) {
+	t.Parallel()
+
+	schema := &tfprotov6.Schema{
+		Block: &tfprotov6.SchemaBlock{
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name:     "id",
+					Type:     tftypes.String,
+					Computed: true,
+				},
+				{
+					Name:      "password",
+					Type:      tftypes.String,
+					Optional:  true,
+					Sensitive: true,
+				},
+			},
+			BlockTypes: []*tfprotov6.SchemaNestedBlock{
+				{
+					TypeName: "credential",
+					Nesting:  tfprotov6.SchemaNestedBlockNestingModeList,
+					Block: &tfprotov6.SchemaBlock{
+						Attributes: []*tfprotov6.SchemaAttribute{
+							{
+								Name:     "name",
+								Type:     tftypes.String,
+								Required: true,
+							},
+							{
+								Name:      "secret",
+								Type:      tftypes.String,
+								Required:  true,
+								Sensitive: true,
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	typ := schema.ValueType()
+	credentialType := tftypes.Object{
+		AttributeTypes: map[string]tftypes.Type{
+			"name":   tftypes.String,
+			"secret": tftypes.String,
+		},
+	}
+
+	before := tftypes.NewValue(typ, map[string]tftypes.Value{
+		"id":       tftypes.NewValue(tftypes.String, "abc123"),
+		"password": tftypes.NewValue(tftypes.String, "hunter2"),
+		"credential": tftypes.NewValue(tftypes.List{ElementType: credentialType}, []tftypes.Value{
+			tftypes.NewValue(credentialType, map[string]tftypes.Value{
+				"name":   tftypes.NewValue(tftypes.String, "a"),
+				"secret": tftypes.NewValue(tftypes.String, "one"),
+			}),
+		}),
+	})
+
+	after := tftypes.NewValue(typ, map[string]tftypes.Value{
+		"id":       tftypes.NewValue(tftypes.String, tftypes.UnknownValue),
+		"password": tftypes.NewValue(tftypes.String, "correct horse"),
+		"credential": tftypes.NewValue(tftypes.List{ElementType: credentialType}, []tftypes.Value{
+			tftypes.NewValue(credentialType, map[string]tftypes.Value{
+				"name":   tftypes.NewValue(tftypes.String, "b"),
+				"secret": tftypes.NewValue(tftypes.String, "two"),
+			}),
+		}),
+	})
+
+	expected := `~ {
+    ~ credential = [
+        ~ {
+            ~ name   = "a" -> "b"
+            ~ secret = (sensitive value)
+          },
+      ]
+    ~ id         = "abc123" -> (known after apply)
+    ~ password   = (sensitive value)
+  }
+`
+
+	got := schema.RenderDiff(before, after)
+
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
@@ -0,0 +1,46 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfprotov6
+
+import (
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+// ValueGenerator returns a tftypes.ValueGenerator for random values of the
+// Schema, for property-based testing and fuzzing of providers. Like the values
+// Terraform sends, the generated values are never null at the root, and nested
+// blocks are only null when they use SchemaNestedBlockNestingModeSingle.
+// Nested blocks of other nesting modes are empty collections or objects
+// instead, and their elements are never null.
+func (s *Schema) ValueGenerator() tftypes.ValueGenerator {
+	var block *SchemaBlock
+
+	if s != nil {
+		block = s.Block
+	}
+
+	return tftypes.ValueGenerator{
+		Type: s.ValueType(),
+		Nullable: func(path *tftypes.AttributePath) bool {
+			steps := path.Steps()
+
+			if len(steps) == 0 {
+				return false
+			}
+
+			blockType, _ := block.BlockAtPath(path)
+
+			if blockType == nil {
+				return true
+			}
+
+			// The path refers to an element of a nested block.
+			if _, ok := steps[len(steps)-1].(tftypes.AttributeName); !ok {
+				return false
+			}
+
+			return blockType.Nesting == SchemaNestedBlockNestingModeSingle
+		},
+	}
+}
@@ -0,0 +1,163 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tfprotov6_test
+
+import (
+	"testing"
+	"testing/quick"
+
+	"github.com/google/go-cmp/cmp"
+
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+func TestSchemaValueGenerator(t *testing.T) {
+	t.Parallel()
+
+	schema := &tfprotov6.Schema{
+		Block: &tfprotov6.SchemaBlock{
+			Attributes: []*tfprotov6.SchemaAttribute{
+				{
+					Name:     "id",
+					Type:     tftypes.String,
+					Computed: true,
+				},
+				{
+					Name:     "names",
+					Type:     tftypes.List{ElementType: tftypes.String},
+					Optional: true,
+				},
+			},
+			BlockTypes: []*tfprotov6.SchemaNestedBlock{
+				{
+					TypeName: "rule",
+					Nesting:  tfprotov6.SchemaNestedBlockNestingModeList,
+					Block: &tfprotov6.SchemaBlock{
+						Attributes: []*tfprotov6.SchemaAttribute{
+							{
+								Name:     "port",
+								Type:     tftypes.Number,
+								Required: true,
+							},
+						},
+						BlockTypes: []*tfprotov6.SchemaNestedBlock{
+							{
+								TypeName: "target",
+								Nesting:  tfprotov6.SchemaNestedBlockNestingModeSet,
+								Block: &tfprotov6.SchemaBlock{
+									Attributes: []*tfprotov6.SchemaAttribute{
+										{
+											Name:     "address",
+											Type:     tftypes.String,
+											Required: true,
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+				{
+					TypeName: "settings",
+					Nesting:  tfprotov6.SchemaNestedBlockNestingModeGroup,
+					Block:    &tfprotov6.SchemaBlock{},
+				},
+				{
+					TypeName: "timeouts",
+					Nesting:  tfprotov6.SchemaNestedBlockNestingModeSingle,
+					Block:    &tfprotov6.SchemaBlock{},
+				},
+			},
+		},
+	}
+
+	nonNullPaths := map[string]bool{
+		"":                  true,
+		"rule":              true,
+		"rule[*]":           true,
+		"rule[*].target":    true,
+		"rule[*].target[*]": true,
+		"settings":          true,
+	}
+
+	nullPaths := map[string]bool{}
+
+	err := quick.Check(func(val tftypes.Value) bool {
+		dv, err := tfprotov6.NewDynamicValue(schema.ValueType(), val)
+		if err != nil {
+			t.Logf("unexpected error creating DynamicValue: %s", err)
+
+			return false
+		}
+
+		got, err := dv.Unmarshal(schema.ValueType())
+		if err != nil {
+			t.Logf("unexpected error unmarshaling DynamicValue: %s", err)
+
+			return false
+		}
+
+		if diff := cmp.Diff(val, got); diff != "" {
+			t.Logf("unexpected difference: %s", diff)
+
+			return false
+		}
+
+		ok := true
+
+		_ = tftypes.Walk(val, func(path *tftypes.AttributePath, v tftypes.Value) (bool, error) {
+			if !v.IsNull() {
+				return true, nil
+			}
+
+			key := schemaPathKey(path)
+			nullPaths[key] = true
+
+			if nonNullPaths[key] {
+				t.Logf("unexpected null value at %s", path)
+
+				ok = false
+			}
+
+			return true, nil
+		})
+
+		return ok
+	}, &quick.Config{
+		MaxCount: 500,
+		Values:   schema.ValueGenerator().QuickValues,
+	})
+
+	if err != nil {
+		t.Error(err)
+	}
+
+	for _, key := range []string{"id", "names", "names[*]", "rule[*].port", "timeouts"} {
+		if !nullPaths[key] {
+			t.Errorf("expected null values to be generated at %s", key)
+		}
+	}
+}
+
+// schemaPathKey returns the path as a string with element keys replaced by
+// [*], so it can be compared to paths in the schema.
+func schemaPathKey(path *tftypes.AttributePath) string {
+	var key string
+
+	for _, step := range path.Steps() {
+		switch step := step.(type) {
+		case tftypes.AttributeName:
+			if key != "" {
+				key += "."
+			}
+
+			key += string(step)
+		default:
+			key += "[*]"
+		}
+	}
+
+	return key
+}
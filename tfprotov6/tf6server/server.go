@@ -22,9 +22,12 @@ import (
 	"google.golang.org/grpc/status"
 
 	"github.com/hashicorp/terraform-plugin-go/internal/logging"
+	"github.com/hashicorp/terraform-plugin-go/internal/lru"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6/internal/diag"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6/internal/fromproto"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/internal/funcargs"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6/internal/funcerr"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6/internal/tf6serverlogging"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6/internal/tfplugin6"
 	"github.com/hashicorp/terraform-plugin-go/tfprotov6/internal/toproto"
@@ -114,6 +117,11 @@ type ServeConfig struct {
 	disableLogLocation   bool
 	useLoggingSink       testing.T
 	envVar               string
+
+	validateFunctionArguments bool
+
+	functionResultCacheSize int
+	uncachedFunctions       []string
 }
 
 type serveConfigFunc func(*ServeConfig) error
@@ -246,6 +254,58 @@ func WithLogEnvVarName(name string) ServeOpt {
 	})
 }
 
+// WithFunctionArgumentValidation returns a ServeOpt that makes the server
+// check the arguments of CallFunction requests against the definition of the
+// called function before calling the provider. Calls with the wrong number of
+// arguments, or with an argument that cannot be decoded with the type of its
+// parameter, is null while the parameter does not allow null values, or is
+// unknown while the parameter does not allow unknown values, get a
+// FunctionError for the argument without the provider being called.
+//
+// The function definitions are those of the GetProviderSchema or
+// GetFunctions response of the provider. If Terraform has not called either
+// RPC, the server calls GetFunctions of the provider once to get them, and
+// does not call it again if it fails. Calls of functions without a definition
+// are passed to the provider unchecked.
+func WithFunctionArgumentValidation() ServeOpt {
+	return serveConfigFunc(func(in *ServeConfig) error {
+		in.validateFunctionArguments = true
+		return nil
+	})
+}
+
+// WithFunctionResultCache returns a ServeOpt that makes the server cache the
+// results of CallFunction requests, as provider functions are meant to be
+// pure: a call of a function with the same arguments as an earlier call gets
+// the result of that call without the provider being called. The cache holds
+// at most `size` results, evicting the least recently used ones, and is keyed
+// by the function name and the canonical encoding of each argument, so that
+// equal arguments share results however Terraform encoded them.
+//
+// Only results without a FunctionError are cached. The functions named in
+// `uncachedFunctions`, such as functions that are not pure, are never cached.
+// This is the only way for functions to opt out, as the protocol has no way
+// for providers to mark functions as not pure: functions registered with
+// tf6function.WithoutResultCache are only excluded if the names returned by
+// Registry.UncachedFunctions are passed here.
+//
+// The number of cache hits and misses so far is logged with each cached
+// function call in the SDK protocol logs.
+//
+// The arguments are decoded with the function definitions, which are obtained
+// as for WithFunctionArgumentValidation. Calls of functions without a
+// definition are not cached.
+func WithFunctionResultCache(size int, uncachedFunctions ...string) ServeOpt {
+	return serveConfigFunc(func(in *ServeConfig) error {
+		if size <= 0 {
+			return errors.New("function result cache size must be positive")
+		}
+		in.functionResultCacheSize = size
+		in.uncachedFunctions = uncachedFunctions
+		return nil
+	})
+}
+
 // Serve starts a tfprotov6.ProviderServer serving, ready for Terraform to
 // connect to it. The name passed in should be the fully qualified name that
 // users will enter in the source field of the required_providers block, like
@@ -428,6 +488,28 @@ type server struct {
 
 	// protocolVersion is the protocol version for the server.
 	protocolVersion string
+
+	// validateFunctionArguments enables checking the arguments of
+	// CallFunction requests against the function definitions.
+	validateFunctionArguments bool
+
+	// functions are the function definitions of the provider by name, used
+	// to check the arguments of CallFunction requests. They are nil until
+	// the definitions are known.
+	functions   map[string]*tfprotov6.Function
+	functionsMu sync.RWMutex
+
+	// functionsFetch fetches the function definitions from the GetFunctions
+	// RPC of the provider at most once, even if the fetch fails.
+	functionsFetch sync.Once
+
+	// functionResults caches the responses of CallFunction requests by
+	// function name and arguments. It is nil if caching is disabled.
+	functionResults *lru.Cache[string, *tfprotov6.CallFunctionResponse]
+
+	// uncachedFunctions are the names of the functions whose results are
+	// never cached.
+	uncachedFunctions map[string]bool
 }
 
 func mergeStop(ctx context.Context, cancel context.CancelFunc, stopCh chan struct{}) {
@@ -497,6 +579,16 @@ func New(name string, serve tfprotov6.ProviderServer, opts ...ServeOpt) tfplugin
 	if envVar != "" {
 		options = append(options, tfsdklog.WithLogName(envVar), tflog.WithLevelFromEnv(logging.EnvTfLogProvider, envVar))
 	}
+
+	var functionResults *lru.Cache[string, *tfprotov6.CallFunctionResponse]
+	if conf.functionResultCacheSize > 0 {
+		functionResults = lru.New[string, *tfprotov6.CallFunctionResponse](conf.functionResultCacheSize)
+	}
+	uncachedFunctions := make(map[string]bool, len(conf.uncachedFunctions))
+	for _, name := range conf.uncachedFunctions {
+		uncachedFunctions[name] = true
+	}
+
 	return &server{
 		downstream:      serve,
 		stopCh:          make(chan struct{}),
@@ -507,6 +599,10 @@ func New(name string, serve tfprotov6.ProviderServer, opts ...ServeOpt) tfplugin
 		testHandle:      conf.useLoggingSink,
 		protocolDataDir: os.Getenv(logging.EnvTfLogSdkProtoDataDir),
 		protocolVersion: protocolVersion,
+
+		validateFunctionArguments: conf.validateFunctionArguments,
+		functionResults:           functionResults,
+		uncachedFunctions:         uncachedFunctions,
 	}
 }
 
@@ -559,6 +655,8 @@ func (s *server) GetProviderSchema(ctx context.Context, protoReq *tfplugin6.GetP
 	tf6serverlogging.DownstreamResponse(ctx, resp.Diagnostics)
 	tf6serverlogging.ServerCapabilities(ctx, resp.ServerCapabilities)
 
+	s.storeFunctions(resp.Functions, resp.Diagnostics)
+
 	protoResp := toproto.GetProviderSchema_Response(resp)
 
 	return protoResp, nil
@@ -1040,6 +1138,31 @@ func (s *server) CallFunction(ctx context.Context, protoReq *tfplugin6.CallFunct
 		logging.ProtocolData(ctx, s.protocolDataDir, rpc, "Request", fmt.Sprintf("Arguments_%d", position), argument)
 	}
 
+	function, values, funcErr := s.functionArguments(ctx, req)
+
+	if s.validateFunctionArguments && function != nil {
+		if funcErr == nil {
+			funcErr = funcargs.Unknown(function, values)
+		}
+
+		if funcErr != nil {
+			logging.ProtocolTrace(ctx, "Arguments do not match the function definition, not calling downstream")
+			(*funcerr.FunctionError)(funcErr).Log(ctx)
+
+			return toproto.CallFunction_Response(&tfprotov6.CallFunctionResponse{Error: funcErr}), nil
+		}
+	}
+
+	cacheKey := s.functionResultCacheKey(req.Name, values)
+
+	if cacheKey != "" {
+		if resp, ok := s.cachedFunctionResult(ctx, cacheKey); ok {
+			logging.ProtocolData(ctx, s.protocolDataDir, rpc, "Response", "Result", resp.Result)
+
+			return toproto.CallFunction_Response(resp), nil
+		}
+	}
+
 	ctx = tf6serverlogging.DownstreamRequest(ctx)
 
 	resp, err := s.downstream.CallFunction(ctx, req)
@@ -1052,6 +1175,10 @@ func (s *server) CallFunction(ctx context.Context, protoReq *tfplugin6.CallFunct
 	tf6serverlogging.DownstreamResponseWithError(ctx, resp.Error)
 	logging.ProtocolData(ctx, s.protocolDataDir, rpc, "Response", "Result", resp.Result)
 
+	if cacheKey != "" && resp.Error == nil {
+		s.functionResults.Add(cacheKey, resp)
+	}
+
 	protoResp := toproto.CallFunction_Response(resp)
 
 	return protoResp, nil
@@ -1078,6 +1205,8 @@ func (s *server) GetFunctions(ctx context.Context, protoReq *tfplugin6.GetFuncti
 
 	tf6serverlogging.DownstreamResponse(ctx, resp.Diagnostics)
 
+	s.storeFunctions(resp.Functions, resp.Diagnostics)
+
 	protoResp := toproto.GetFunctions_Response(resp)
 
 	return protoResp, nil
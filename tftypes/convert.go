@@ -0,0 +1,580 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tftypes
+
+import (
+	"fmt"
+	"math/big"
+	"sort"
+	"strconv"
+)
+
+// Convert returns a Value of Type `typ` that represents the same data as
+// `val`, following the rules Terraform uses to convert values into the types
+// expected by schemas and function parameters:
+//
+//   - Any Value can be converted to DynamicPseudoType, in which case it is
+//     returned unchanged. Known values with a DynamicPseudoType type are
+//     converted based on the data they hold.
+//
+//   - Numbers and bools can be converted to strings. Strings can be converted
+//     to numbers and bools, but the conversion fails if the string does not
+//     contain a valid number or one of "true", "false", "1", or "0".
+//
+//   - Lists, sets, and tuples can be converted to lists and sets, and lists
+//     and tuples can be converted to tuples with the same number of elements.
+//     Converting to a set removes duplicate elements.
+//
+//   - Maps and objects can be converted to maps and objects. When converting
+//     to an object, every attribute of the object type that is not in its
+//     OptionalAttributes must be present; optional attributes that are absent
+//     are set to null. Attributes of an object that are not part of the target
+//     object type are discarded, but keys of a map must all be attributes of
+//     the target object type.
+//
+// Null and unknown values are converted to null and unknown values of the
+// target type, as long as a conversion between the types is possible. The
+// OptionalAttributes of object types are not part of the returned Value's
+// type.
+//
+// If the conversion is not possible, the returned error will be an
+// AttributePathError indicating which element or attribute of `val` could not
+// be converted.
+func Convert(val Value, typ Type) (Value, error) {
+	return convert(NewAttributePath(), val, typ)
+}
+
+func convert(path *AttributePath, val Value, typ Type) (Value, error) {
+	if typ == nil {
+		return Value{}, path.NewErrorf("missing conversion type")
+	}
+
+	if val.Type() == nil {
+		return Value{}, path.NewErrorf("missing value type")
+	}
+
+	if typ.Is(DynamicPseudoType) || val.Type().Equal(typ) {
+		return val, nil
+	}
+
+	if !val.IsKnown() || val.IsNull() {
+		if !conversionExists(val.Type(), typ) {
+			return Value{}, path.NewErrorf("can't convert %s to %s", val.Type(), typ)
+		}
+
+		return NewValue(withoutOptionalAttributes(typ), val.value), nil
+	}
+
+	val, err := concreteValue(path, val)
+
+	if err != nil {
+		return Value{}, err
+	}
+
+	switch typ := typ.(type) {
+	case primitive:
+		return convertToPrimitive(path, val, typ)
+	case List:
+		elems, err := convertElements(path, val, typ.ElementType)
+
+		if err != nil {
+			return Value{}, err
+		}
+
+		elemType := withoutOptionalAttributes(typ.ElementType)
+
+		if elemType.Is(DynamicPseudoType) {
+			elems, err = unifyElements(path, elems)
+
+			if err != nil {
+				return Value{}, err
+			}
+		}
+
+		return newConvertedValue(path, List{ElementType: elemType}, elems)
+	case Set:
+		elems, err := convertElements(path, val, typ.ElementType)
+
+		if err != nil {
+			return Value{}, err
+		}
+
+		elemType := withoutOptionalAttributes(typ.ElementType)
+
+		if elemType.Is(DynamicPseudoType) {
+			elems, err = unifyElements(path, elems)
+
+			if err != nil {
+				return Value{}, err
+			}
+		}
+
+		elems, err = uniqueSetElements(elems, false)
+
+		if err != nil {
+			return Value{}, err
+		}
+
+		return newConvertedValue(path, Set{ElementType: elemType}, elems)
+	case Tuple:
+		return convertToTuple(path, val, typ)
+	case Map:
+		return convertToMap(path, val, typ)
+	case Object:
+		return convertToObject(path, val, typ)
+	default:
+		return Value{}, path.NewErrorf("unexpected conversion type %s", typ)
+	}
+}
+
+// concreteValue returns `val` with a concrete type when it is a known value
+// created with DynamicPseudoType, inferring the type from the data it holds.
+func concreteValue(path *AttributePath, val Value) (Value, error) {
+	if !val.Type().Is(DynamicPseudoType) {
+		return val, nil
+	}
+
+	switch v := val.value.(type) {
+	case string:
+		return Value{typ: String, value: v}, nil
+	case *big.Float, int64, uint64, float64:
+		return Value{typ: Number, value: v}, nil
+	case bool:
+		return Value{typ: Bool, value: v}, nil
+	case map[string]Value:
+		attrTypes := make(map[string]Type, len(v))
+
+		for k, el := range v {
+			attrTypes[k] = el.Type()
+		}
+
+		return Value{typ: Object{AttributeTypes: attrTypes}, value: v}, nil
+	case []Value:
+		elemTypes := make([]Type, 0, len(v))
+
+		for _, el := range v {
+			elemTypes = append(elemTypes, el.Type())
+		}
+
+		return Value{typ: Tuple{ElementTypes: elemTypes}, value: v}, nil
+	default:
+		return Value{}, path.NewErrorf("unexpected value %T for %s", val.value, val.Type())
+	}
+}
+
+func convertToPrimitive(path *AttributePath, val Value, typ primitive) (Value, error) {
+	if val.Type().Is(typ) {
+		return val, nil
+	}
+
+	switch typ.name {
+	case String.name:
+		if n, ok := numberBigFloat(val.value); ok {
+			return NewValue(String, n.Text('f', -1)), nil
+		}
+
+		if v, ok := val.value.(bool); ok {
+			return NewValue(String, strconv.FormatBool(v)), nil
+		}
+	case Number.name:
+		if v, ok := val.value.(string); ok {
+			n, _, err := big.ParseFloat(v, 10, 512, big.ToNearestEven)
+
+			if err != nil {
+				return Value{}, path.NewErrorf("can't convert %q to %s: a number is required", v, typ)
+			}
+
+			return NewValue(Number, n), nil
+		}
+	case Bool.name:
+		if v, ok := val.value.(string); ok {
+			switch v {
+			case "true", "1":
+				return NewValue(Bool, true), nil
+			case "false", "0":
+				return NewValue(Bool, false), nil
+			}
+
+			return Value{}, path.NewErrorf("can't convert %q to %s: a bool is required", v, typ)
+		}
+	}
+
+	return Value{}, path.NewErrorf("can't convert %s to %s", val.Type(), typ)
+}
+
+// convertElements converts every element of a list, set, or tuple Value to
+// `elemType`.
+func convertElements(path *AttributePath, val Value, elemType Type) ([]Value, error) {
+	if !val.Type().Is(List{}) && !val.Type().Is(Set{}) && !val.Type().Is(Tuple{}) {
+		return nil, path.NewErrorf("can't convert %s to a collection of %s", val.Type(), elemType)
+	}
+
+	elems, ok := val.value.([]Value)
+
+	if !ok {
+		return nil, path.NewErrorf("cannot convert %T into []tftypes.Value", val.value)
+	}
+
+	result := make([]Value, 0, len(elems))
+
+	for pos, el := range elems {
+		elPath := path.WithElementKeyInt(pos)
+
+		if val.Type().Is(Set{}) {
+			elPath = path.WithElementKeyValue(el)
+		}
+
+		newEl, err := convert(elPath, el, elemType)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, newEl)
+	}
+
+	return result, nil
+}
+
+// unifyElements ensures the elements of a list or set converted to a
+// collection of DynamicPseudoType all have the same type. Mixed primitive
+// elements are all converted to strings, as Terraform does.
+func unifyElements(path *AttributePath, elems []Value) ([]Value, error) {
+	if _, err := TypeFromElements(elems); err == nil {
+		return elems, nil
+	}
+
+	result := make([]Value, 0, len(elems))
+
+	for pos, el := range elems {
+		if _, ok := el.Type().(primitive); !ok {
+			return nil, path.NewErrorf("all elements must have the same type, saw %s and %s", elems[0].Type(), el.Type())
+		}
+
+		newEl, err := convert(path.WithElementKeyInt(pos), el, String)
+
+		if err != nil {
+			return nil, err
+		}
+
+		result = append(result, newEl)
+	}
+
+	return result, nil
+}
+
+func convertToTuple(path *AttributePath, val Value, typ Tuple) (Value, error) {
+	if !val.Type().Is(List{}) && !val.Type().Is(Tuple{}) {
+		return Value{}, path.NewErrorf("can't convert %s to %s", val.Type(), typ)
+	}
+
+	elems, ok := val.value.([]Value)
+
+	if !ok {
+		return Value{}, path.NewErrorf("cannot convert %T into []tftypes.Value", val.value)
+	}
+
+	if len(elems) != len(typ.ElementTypes) {
+		return Value{}, path.NewErrorf("can't convert %s with %d elements to %s, which requires %d elements", val.Type(), len(elems), typ, len(typ.ElementTypes))
+	}
+
+	result := make([]Value, 0, len(elems))
+	types := make([]Type, 0, len(elems))
+
+	for pos, el := range elems {
+		newEl, err := convert(path.WithElementKeyInt(pos), el, typ.ElementTypes[pos])
+
+		if err != nil {
+			return Value{}, err
+		}
+
+		result = append(result, newEl)
+		types = append(types, withoutOptionalAttributes(typ.ElementTypes[pos]))
+	}
+
+	return newConvertedValue(path, Tuple{ElementTypes: types}, result)
+}
+
+func convertToMap(path *AttributePath, val Value, typ Map) (Value, error) {
+	if !val.Type().Is(Map{}) && !val.Type().Is(Object{}) {
+		return Value{}, path.NewErrorf("can't convert %s to %s", val.Type(), typ)
+	}
+
+	elems, ok := val.value.(map[string]Value)
+
+	if !ok {
+		return Value{}, path.NewErrorf("cannot convert %T into map[string]tftypes.Value", val.value)
+	}
+
+	// Sort the keys so any error is reported consistently.
+	keys := make([]string, 0, len(elems))
+
+	for k := range elems {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	result := make(map[string]Value, len(elems))
+	resultList := make([]Value, 0, len(elems))
+
+	for _, k := range keys {
+		newEl, err := convert(path.WithElementKeyString(k), elems[k], typ.ElementType)
+
+		if err != nil {
+			return Value{}, err
+		}
+
+		result[k] = newEl
+		resultList = append(resultList, newEl)
+	}
+
+	elemType := withoutOptionalAttributes(typ.ElementType)
+
+	if elemType.Is(DynamicPseudoType) {
+		unified, err := unifyElements(path, resultList)
+
+		if err != nil {
+			return Value{}, err
+		}
+
+		for pos, k := range keys {
+			result[k] = unified[pos]
+		}
+	}
+
+	return newConvertedValue(path, Map{ElementType: elemType}, result)
+}
+
+func convertToObject(path *AttributePath, val Value, typ Object) (Value, error) {
+	_, fromMap := val.Type().(Map)
+
+	if !fromMap && !val.Type().Is(Object{}) {
+		return Value{}, path.NewErrorf("can't convert %s to %s", val.Type(), typ)
+	}
+
+	attrs, ok := val.value.(map[string]Value)
+
+	if !ok {
+		return Value{}, path.NewErrorf("cannot convert %T into map[string]tftypes.Value", val.value)
+	}
+
+	if fromMap {
+		keys := make([]string, 0, len(attrs))
+
+		for k := range attrs {
+			keys = append(keys, k)
+		}
+
+		sort.Strings(keys)
+
+		for _, k := range keys {
+			if _, ok := typ.AttributeTypes[k]; !ok {
+				return Value{}, path.WithElementKeyString(k).NewErrorf("unsupported attribute %q for %s", k, typ)
+			}
+		}
+	}
+
+	names := make([]string, 0, len(typ.AttributeTypes))
+
+	for name := range typ.AttributeTypes {
+		names = append(names, name)
+	}
+
+	sort.Strings(names)
+
+	result := make(map[string]Value, len(typ.AttributeTypes))
+	types := make(map[string]Type, len(typ.AttributeTypes))
+
+	for _, name := range names {
+		attrType := typ.AttributeTypes[name]
+		types[name] = withoutOptionalAttributes(attrType)
+
+		attrPath := path.WithAttributeName(name)
+
+		if fromMap {
+			attrPath = path.WithElementKeyString(name)
+		}
+
+		attr, ok := attrs[name]
+
+		if !ok {
+			if !typ.attrIsOptional(name) {
+				return Value{}, attrPath.NewErrorf("attribute %q is required", name)
+			}
+
+			result[name] = NewValue(types[name], nil)
+
+			continue
+		}
+
+		newAttr, err := convert(attrPath, attr, attrType)
+
+		if err != nil {
+			return Value{}, err
+		}
+
+		result[name] = newAttr
+	}
+
+	return newConvertedValue(path, Object{AttributeTypes: types}, result)
+}
+
+// conversionExists returns true if values of Type `from` can be converted to
+// Type `to`, which is used to validate the conversion of null and unknown
+// values. Conversions that depend on the data, such as the length of a list
+// converted to a tuple, are assumed to be possible.
+func conversionExists(from, to Type) bool {
+	if from.Is(DynamicPseudoType) || to.Is(DynamicPseudoType) {
+		return true
+	}
+
+	switch to := to.(type) {
+	case primitive:
+		from, ok := from.(primitive)
+
+		if !ok {
+			return false
+		}
+
+		if from.name == to.name {
+			return true
+		}
+
+		// Numbers and bools convert to strings, strings convert to
+		// either, but numbers and bools do not convert to each other.
+		return from.name == String.name || to.name == String.name
+	case List:
+		return collectionConversionExists(from, to.ElementType)
+	case Set:
+		return collectionConversionExists(from, to.ElementType)
+	case Map:
+		switch from := from.(type) {
+		case Map:
+			return conversionExists(from.ElementType, to.ElementType)
+		case Object:
+			for _, attrType := range from.AttributeTypes {
+				if !conversionExists(attrType, to.ElementType) {
+					return false
+				}
+			}
+
+			return true
+		}
+	case Object:
+		switch from := from.(type) {
+		case Map:
+			for _, attrType := range to.AttributeTypes {
+				if !conversionExists(from.ElementType, attrType) {
+					return false
+				}
+			}
+
+			return true
+		case Object:
+			for name, attrType := range to.AttributeTypes {
+				fromType, ok := from.AttributeTypes[name]
+
+				if !ok {
+					if to.attrIsOptional(name) {
+						continue
+					}
+
+					return false
+				}
+
+				if !conversionExists(fromType, attrType) {
+					return false
+				}
+			}
+
+			return true
+		}
+	case Tuple:
+		switch from := from.(type) {
+		case List:
+			for _, elemType := range to.ElementTypes {
+				if !conversionExists(from.ElementType, elemType) {
+					return false
+				}
+			}
+
+			return true
+		case Tuple:
+			if len(from.ElementTypes) != len(to.ElementTypes) {
+				return false
+			}
+
+			for pos, elemType := range to.ElementTypes {
+				if !conversionExists(from.ElementTypes[pos], elemType) {
+					return false
+				}
+			}
+
+			return true
+		}
+	}
+
+	return false
+}
+
+func collectionConversionExists(from Type, elemType Type) bool {
+	switch from := from.(type) {
+	case List:
+		return conversionExists(from.ElementType, elemType)
+	case Set:
+		return conversionExists(from.ElementType, elemType)
+	case Tuple:
+		for _, fromElemType := range from.ElementTypes {
+			if !conversionExists(fromElemType, elemType) {
+				return false
+			}
+		}
+
+		return true
+	}
+
+	return false
+}
+
+// withoutOptionalAttributes returns `typ` with the OptionalAttributes of all
+// object types removed, which is required for the type of a Value.
+func withoutOptionalAttributes(typ Type) Type {
+	switch typ := typ.(type) {
+	case List:
+		return List{ElementType: withoutOptionalAttributes(typ.ElementType)}
+	case Set:
+		return Set{ElementType: withoutOptionalAttributes(typ.ElementType)}
+	case Map:
+		return Map{ElementType: withoutOptionalAttributes(typ.ElementType)}
+	case Tuple:
+		types := make([]Type, 0, len(typ.ElementTypes))
+
+		for _, elemType := range typ.ElementTypes {
+			types = append(types, withoutOptionalAttributes(elemType))
+		}
+
+		return Tuple{ElementTypes: types}
+	case Object:
+		types := make(map[string]Type, len(typ.AttributeTypes))
+
+		for name, attrType := range typ.AttributeTypes {
+			types[name] = withoutOptionalAttributes(attrType)
+		}
+
+		return Object{AttributeTypes: types}
+	default:
+		return typ
+	}
+}
+
+func newConvertedValue(path *AttributePath, typ Type, val interface{}) (Value, error) {
+	result, err := newValue(typ, val)
+
+	if err != nil {
+		return Value{}, path.NewError(fmt.Errorf("can't create converted value: %w", err))
+	}
+
+	return result, nil
+}
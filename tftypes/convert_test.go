@@ -0,0 +1,246 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tftypes
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestConvert(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		val           Value
+		typ           Type
+		expected      Value
+		expectedError error
+	}{
+		"identity": {
+			val:      NewValue(String, "hello"),
+			typ:      String,
+			expected: NewValue(String, "hello"),
+		},
+		"to-dynamic": {
+			val:      NewValue(Number, 1),
+			typ:      DynamicPseudoType,
+			expected: NewValue(Number, 1),
+		},
+		"number-to-string": {
+			val:      NewValue(Number, big.NewFloat(1.5)),
+			typ:      String,
+			expected: NewValue(String, "1.5"),
+		},
+		"bool-to-string": {
+			val:      NewValue(Bool, true),
+			typ:      String,
+			expected: NewValue(String, "true"),
+		},
+		"string-to-number": {
+			val:      NewValue(String, "42"),
+			typ:      Number,
+			expected: NewValue(Number, 42),
+		},
+		"string-to-number-invalid": {
+			val:           NewValue(String, "forty-two"),
+			typ:           Number,
+			expectedError: NewAttributePath().NewErrorf(`can't convert "forty-two" to tftypes.Number: a number is required`),
+		},
+		"string-to-bool": {
+			val:      NewValue(String, "false"),
+			typ:      Bool,
+			expected: NewValue(Bool, false),
+		},
+		"number-to-bool": {
+			val:           NewValue(Number, 1),
+			typ:           Bool,
+			expectedError: NewAttributePath().NewErrorf("can't convert tftypes.Number to tftypes.Bool"),
+		},
+		"null": {
+			val:      NewValue(Number, nil),
+			typ:      String,
+			expected: NewValue(String, nil),
+		},
+		"unknown": {
+			val:      NewValue(List{ElementType: String}, UnknownValue),
+			typ:      Set{ElementType: String},
+			expected: NewValue(Set{ElementType: String}, UnknownValue),
+		},
+		"null-invalid": {
+			val:           NewValue(List{ElementType: String}, nil),
+			typ:           Map{ElementType: String},
+			expectedError: NewAttributePath().NewErrorf("can't convert tftypes.List[tftypes.String] to tftypes.Map[tftypes.String]"),
+		},
+		"dynamic-null": {
+			val:      NewValue(DynamicPseudoType, nil),
+			typ:      List{ElementType: String},
+			expected: NewValue(List{ElementType: String}, nil),
+		},
+		"dynamic-known": {
+			val: NewValue(DynamicPseudoType, []Value{
+				NewValue(String, "a"),
+				NewValue(String, "b"),
+			}),
+			typ: List{ElementType: String},
+			expected: NewValue(List{ElementType: String}, []Value{
+				NewValue(String, "a"),
+				NewValue(String, "b"),
+			}),
+		},
+		"tuple-to-list": {
+			val: NewValue(Tuple{ElementTypes: []Type{String, Number}}, []Value{
+				NewValue(String, "a"),
+				NewValue(Number, 1),
+			}),
+			typ: List{ElementType: String},
+			expected: NewValue(List{ElementType: String}, []Value{
+				NewValue(String, "a"),
+				NewValue(String, "1"),
+			}),
+		},
+		"tuple-to-list-dynamic": {
+			val: NewValue(Tuple{ElementTypes: []Type{String, Bool}}, []Value{
+				NewValue(String, "a"),
+				NewValue(Bool, true),
+			}),
+			typ: List{ElementType: DynamicPseudoType},
+			expected: NewValue(List{ElementType: DynamicPseudoType}, []Value{
+				NewValue(String, "a"),
+				NewValue(String, "true"),
+			}),
+		},
+		"tuple-to-list-invalid-element": {
+			val: NewValue(Tuple{ElementTypes: []Type{String, Bool}}, []Value{
+				NewValue(String, "a"),
+				NewValue(Bool, true),
+			}),
+			typ:           List{ElementType: Number},
+			expectedError: NewAttributePath().WithElementKeyInt(0).NewErrorf(`can't convert "a" to tftypes.Number: a number is required`),
+		},
+		"list-to-set-dedupe": {
+			val: NewValue(List{ElementType: String}, []Value{
+				NewValue(String, "a"),
+				NewValue(String, "b"),
+				NewValue(String, "a"),
+			}),
+			typ: Set{ElementType: String},
+			expected: NewValue(Set{ElementType: String}, []Value{
+				NewValue(String, "a"),
+				NewValue(String, "b"),
+			}),
+		},
+		"list-to-tuple": {
+			val: NewValue(List{ElementType: String}, []Value{
+				NewValue(String, "a"),
+				NewValue(String, "1"),
+			}),
+			typ: Tuple{ElementTypes: []Type{String, Number}},
+			expected: NewValue(Tuple{ElementTypes: []Type{String, Number}}, []Value{
+				NewValue(String, "a"),
+				NewValue(Number, 1),
+			}),
+		},
+		"list-to-tuple-length": {
+			val: NewValue(List{ElementType: String}, []Value{
+				NewValue(String, "a"),
+			}),
+			typ:           Tuple{ElementTypes: []Type{String, Number}},
+			expectedError: NewAttributePath().NewErrorf("can't convert tftypes.List[tftypes.String] with 1 elements to tftypes.Tuple[tftypes.String, tftypes.Number], which requires 2 elements"),
+		},
+		"object-to-map": {
+			val: NewValue(Object{AttributeTypes: map[string]Type{"a": String, "b": Number}}, map[string]Value{
+				"a": NewValue(String, "x"),
+				"b": NewValue(Number, 2),
+			}),
+			typ: Map{ElementType: String},
+			expected: NewValue(Map{ElementType: String}, map[string]Value{
+				"a": NewValue(String, "x"),
+				"b": NewValue(String, "2"),
+			}),
+		},
+		"map-to-object": {
+			val: NewValue(Map{ElementType: String}, map[string]Value{
+				"name": NewValue(String, "x"),
+				"port": NewValue(String, "80"),
+			}),
+			typ: Object{
+				AttributeTypes: map[string]Type{
+					"name":    String,
+					"port":    Number,
+					"enabled": Bool,
+				},
+				OptionalAttributes: map[string]struct{}{
+					"enabled": {},
+				},
+			},
+			expected: NewValue(Object{AttributeTypes: map[string]Type{"name": String, "port": Number, "enabled": Bool}}, map[string]Value{
+				"name":    NewValue(String, "x"),
+				"port":    NewValue(Number, 80),
+				"enabled": NewValue(Bool, nil),
+			}),
+		},
+		"map-to-object-unsupported-key": {
+			val: NewValue(Map{ElementType: String}, map[string]Value{
+				"name":  NewValue(String, "x"),
+				"other": NewValue(String, "y"),
+			}),
+			typ:           Object{AttributeTypes: map[string]Type{"name": String}},
+			expectedError: NewAttributePath().WithElementKeyString("other").NewErrorf(`unsupported attribute "other" for tftypes.Object["name":tftypes.String]`),
+		},
+		"object-to-object-missing-attribute": {
+			val: NewValue(Object{AttributeTypes: map[string]Type{"nested": Object{AttributeTypes: map[string]Type{"a": String}}}}, map[string]Value{
+				"nested": NewValue(Object{AttributeTypes: map[string]Type{"a": String}}, map[string]Value{
+					"a": NewValue(String, "x"),
+				}),
+			}),
+			typ:           Object{AttributeTypes: map[string]Type{"nested": Object{AttributeTypes: map[string]Type{"a": String, "b": String}}}},
+			expectedError: NewAttributePath().WithAttributeName("nested").WithAttributeName("b").NewErrorf(`attribute "b" is required`),
+		},
+		"object-to-object-extra-attribute": {
+			val: NewValue(Object{AttributeTypes: map[string]Type{"a": String, "b": Number}}, map[string]Value{
+				"a": NewValue(String, "x"),
+				"b": NewValue(Number, 1),
+			}),
+			typ: Object{AttributeTypes: map[string]Type{"a": String}},
+			expected: NewValue(Object{AttributeTypes: map[string]Type{"a": String}}, map[string]Value{
+				"a": NewValue(String, "x"),
+			}),
+		},
+		"missing-type": {
+			val:           Value{},
+			typ:           String,
+			expectedError: NewAttributePath().NewErrorf("missing value type"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := Convert(testCase.val, testCase.typ)
+
+			if err != nil {
+				if testCase.expectedError == nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if diff := cmp.Diff(testCase.expectedError, err); diff != "" {
+					t.Fatalf("unexpected error difference: %s", diff)
+				}
+
+				return
+			}
+
+			if testCase.expectedError != nil {
+				t.Fatalf("expected error %q, got none", testCase.expectedError)
+			}
+
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
@@ -93,7 +93,7 @@ func (val1 Value) Diff(val2 Value) ([]ValueDiff, error) {
 
 	// make sure everything in val2 is also in val1
 	err := Walk(val2, func(path *AttributePath, value2 Value) (bool, error) {
-		_, _, err := WalkAttributePath(val1, path)
+		value1I, _, err := WalkAttributePath(val1, path)
 		if err != nil && err != ErrInvalidStep {
 			return false, fmt.Errorf("Error walking %q: %w", path, err)
 		} else if err == ErrInvalidStep {
@@ -104,6 +104,27 @@ func (val1 Value) Diff(val2 Value) ([]ValueDiff, error) {
 			})
 			return false, nil
 		}
+
+		// Looking up each set element by path would compare it to every
+		// element of the other set, so sets are compared by hash instead.
+		if value2.Type().Is(Set{}) {
+			value1, ok := value1I.(Value)
+			if !ok {
+				return false, fmt.Errorf("unexpected type %T in Diff", value1I)
+			}
+			missing, err := missingSetElements(value2, value1)
+			if err != nil {
+				return false, fmt.Errorf("Error comparing %q: %w", path, err)
+			}
+			for _, el := range missing {
+				diffs = append(diffs, ValueDiff{
+					Path:   path.WithElementKeyValue(el),
+					Value1: nil,
+					Value2: &el,
+				})
+			}
+			return false, nil
+		}
 		return true, nil
 	})
 	if err != nil {
@@ -242,7 +263,45 @@ func (val1 Value) Diff(val2 Value) ([]ValueDiff, error) {
 				})
 			}
 			return false, nil
-		case value1.Type().Is(List{}), value1.Type().Is(Set{}), value1.Type().Is(Tuple{}):
+		case value1.Type().Is(Set{}):
+			var s1, s2 []Value
+			err := value1.As(&s1)
+			if err != nil {
+				return false, fmt.Errorf("Error converting %q: %w", path, err)
+			}
+			err = value2.As(&s2)
+			if err != nil {
+				return false, fmt.Errorf("Error converting %q: %w", path, err)
+			}
+			if len(s1) != len(s2) {
+				diffs = append(diffs, ValueDiff{
+					Path:   path,
+					Value1: &value1,
+					Value2: &value2,
+				})
+			}
+			// Elements which are also in val2 are equal, so only the
+			// missing elements, and everything in them, are diffs.
+			missing, err := missingSetElements(value1, value2)
+			if err != nil {
+				return false, fmt.Errorf("Error comparing %q: %w", path, err)
+			}
+			for _, el := range missing {
+				elPath := path.WithElementKeyValue(el)
+				err := Walk(el, func(elemPath *AttributePath, elemValue Value) (bool, error) {
+					diffs = append(diffs, ValueDiff{
+						Path:   NewAttributePathWithSteps(append(elPath.Steps(), elemPath.Steps()...)),
+						Value1: &elemValue,
+						Value2: nil,
+					})
+					return true, nil
+				})
+				if err != nil {
+					return false, err
+				}
+			}
+			return false, nil
+		case value1.Type().Is(List{}), value1.Type().Is(Tuple{}):
 			var s1, s2 []Value
 			err := value1.As(&s1)
 			if err != nil {
@@ -0,0 +1,575 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tftypes
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// RenderDiffOpts contains options that can be used to modify the behaviour of
+// RenderDiffWithOpts.
+type RenderDiffOpts struct {
+	// Sensitive, when set, is called with the AttributePath of every
+	// Value that is rendered. Returning true renders the Value as
+	// "(sensitive value)", without any of its elements or attributes.
+	Sensitive func(*AttributePath) bool
+
+	// ShowUnchanged, when set to true, renders elements and attributes
+	// that are the same in both Values. By default they are hidden and
+	// only counted, like in a Terraform plan.
+	ShowUnchanged bool
+}
+
+// RenderDiff returns a human-readable rendering of the differences between
+// `val1` and `val2`, in the style of a Terraform plan. Elements and attributes
+// are marked with "+" when they are only set in `val2`, "-" when they are only
+// set in `val1`, and "~" when they are set in both but are different. Unknown
+// values are rendered as "(known after apply)".
+//
+// An empty string is returned if there are no differences. Unlike Diff, the
+// Values do not need to be of the same type.
+func RenderDiff(val1, val2 Value) string {
+	return RenderDiffWithOpts(val1, val2, RenderDiffOpts{})
+}
+
+// RenderDiffWithOpts is identical to RenderDiff but also accepts a
+// RenderDiffOpts which contains options that can be used to mask sensitive
+// values and to render unchanged values.
+func RenderDiffWithOpts(val1, val2 Value, opts RenderDiffOpts) string {
+	r := &diffRenderer{opts: opts}
+
+	var before, after *Value
+
+	if val1.Type() != nil {
+		before = &val1
+	}
+
+	if val2.Type() != nil {
+		after = &val2
+	}
+
+	action := diffAction(before, after)
+
+	if action == diffActionNoOp && !opts.ShowUnchanged {
+		return ""
+	}
+
+	r.renderChange(0, NewAttributePath(), action, "", before, after, "")
+
+	return r.buf.String()
+}
+
+const (
+	diffActionNoOp   byte = ' '
+	diffActionCreate byte = '+'
+	diffActionDelete byte = '-'
+	diffActionUpdate byte = '~'
+)
+
+// diffAction returns the action that changes `before` into `after`. A nil
+// Value is not set at all.
+func diffAction(before, after *Value) byte {
+	beforeNull := before == nil || before.IsNull()
+	afterNull := after == nil || after.IsNull()
+
+	switch {
+	case beforeNull && afterNull:
+		return diffActionNoOp
+	case beforeNull:
+		return diffActionCreate
+	case afterNull:
+		return diffActionDelete
+	case before.Equal(*after):
+		return diffActionNoOp
+	default:
+		return diffActionUpdate
+	}
+}
+
+type diffRenderer struct {
+	opts RenderDiffOpts
+	buf  strings.Builder
+}
+
+func (r *diffRenderer) line(depth int, action byte, text string) {
+	r.buf.WriteString(strings.Repeat("    ", depth))
+	r.buf.WriteByte(action)
+	r.buf.WriteByte(' ')
+	r.buf.WriteString(text)
+	r.buf.WriteByte('\n')
+}
+
+// renderChange renders the change of a single Value at `path`. The `label`,
+// such as `name = `, is rendered before the Value and the `suffix`, such as
+// the comma after list elements, after it.
+func (r *diffRenderer) renderChange(depth int, path *AttributePath, action byte, label string, before, after *Value, suffix string) {
+	if r.opts.Sensitive != nil && r.opts.Sensitive(path) {
+		r.line(depth, action, label+"(sensitive value)"+suffix)
+
+		return
+	}
+
+	// Deleted elements of lists, sets, and tuples have no label and are
+	// only marked as deleted, without "-> null".
+	deleted := " -> null"
+
+	if label == "" {
+		deleted = ""
+	}
+
+	beforeKind := diffCollectionKind(before)
+	afterKind := diffCollectionKind(after)
+
+	if beforeKind != "" && afterKind != "" && beforeKind != afterKind {
+		r.line(depth, action, label+before.String()+" -> "+after.String()+suffix)
+
+		return
+	}
+
+	if !diffHasElements(before) && !diffHasElements(after) {
+		switch action {
+		case diffActionCreate, diffActionNoOp:
+			r.line(depth, action, label+renderDiffValue(after)+suffix)
+		case diffActionDelete:
+			r.line(depth, action, label+renderDiffValue(before)+deleted+suffix)
+		default:
+			r.line(depth, action, label+renderDiffValue(before)+" -> "+renderDiffValue(after)+suffix)
+		}
+
+		return
+	}
+
+	kind := afterKind
+
+	if kind == "" {
+		kind = beforeKind
+	}
+
+	opening, closing := "[", "]"
+
+	if kind == "object" || kind == "map" {
+		opening, closing = "{", "}"
+	}
+
+	if beforeKind != "" || action == diffActionCreate {
+		r.line(depth, action, label+opening)
+	} else {
+		r.line(depth, action, label+renderDiffValue(before)+" -> "+opening)
+	}
+
+	var childBefore, childAfter *Value
+
+	if beforeKind != "" {
+		childBefore = before
+	}
+
+	if afterKind != "" {
+		childAfter = after
+	}
+
+	switch kind {
+	case "object", "map":
+		r.renderAttributes(depth+1, path, kind == "object", childBefore, childAfter)
+	case "list":
+		r.renderListElements(depth+1, path, childBefore, childAfter)
+	case "tuple":
+		r.renderTupleElements(depth+1, path, childBefore, childAfter)
+	case "set":
+		r.renderSetElements(depth+1, path, childBefore, childAfter)
+	}
+
+	switch {
+	case afterKind != "" || action == diffActionNoOp:
+		r.line(depth, ' ', closing+suffix)
+	case action == diffActionDelete:
+		r.line(depth, ' ', closing+deleted+suffix)
+	default:
+		r.line(depth, ' ', closing+" -> "+renderDiffValue(after)+suffix)
+	}
+}
+
+// renderHidden renders the number of unchanged elements or attributes that
+// were not rendered.
+func (r *diffRenderer) renderHidden(depth int, hidden int, noun string) {
+	if hidden == 0 {
+		return
+	}
+
+	if hidden > 1 {
+		noun += "s"
+	}
+
+	r.line(depth, ' ', fmt.Sprintf("# (%d unchanged %s hidden)", hidden, noun))
+}
+
+func (r *diffRenderer) renderAttributes(depth int, path *AttributePath, isObject bool, before, after *Value) {
+	beforeAttrs := diffAttributes(before)
+	afterAttrs := diffAttributes(after)
+
+	keys := make([]string, 0, len(beforeAttrs)+len(afterAttrs))
+
+	for k := range beforeAttrs {
+		keys = append(keys, k)
+	}
+
+	for k := range afterAttrs {
+		if _, ok := beforeAttrs[k]; !ok {
+			keys = append(keys, k)
+		}
+	}
+
+	sort.Strings(keys)
+
+	type attributeChange struct {
+		key           string
+		label         string
+		action        byte
+		before, after *Value
+	}
+
+	var changes []attributeChange
+
+	var hidden, labelWidth int
+
+	for _, k := range keys {
+		change := attributeChange{
+			key:   k,
+			label: k,
+		}
+
+		if !isObject {
+			change.label = strconv.Quote(k)
+		}
+
+		if v, ok := beforeAttrs[k]; ok {
+			change.before = &v
+		}
+
+		if v, ok := afterAttrs[k]; ok {
+			change.after = &v
+		}
+
+		change.action = diffAction(change.before, change.after)
+
+		if change.action == diffActionNoOp && !r.opts.ShowUnchanged {
+			hidden++
+
+			continue
+		}
+
+		labelWidth = max(labelWidth, len(change.label))
+		changes = append(changes, change)
+	}
+
+	for _, change := range changes {
+		childPath := path.WithElementKeyString(change.key)
+
+		if isObject {
+			childPath = path.WithAttributeName(change.key)
+		}
+
+		label := fmt.Sprintf("%-*s = ", labelWidth, change.label)
+
+		r.renderChange(depth, childPath, change.action, label, change.before, change.after, "")
+	}
+
+	if isObject {
+		r.renderHidden(depth, hidden, "attribute")
+	} else {
+		r.renderHidden(depth, hidden, "element")
+	}
+}
+
+// listDiffOp is a change to an element of a list. The indexes of elements that
+// are not in the list before or after the change are -1.
+type listDiffOp struct {
+	action byte
+	before int
+	after  int
+}
+
+// diffListOps aligns the elements of two lists by their longest common
+// subsequence. Deleted elements directly followed by created elements with
+// elements or attributes are paired into updates, so their differences are
+// rendered.
+func diffListOps(before, after []Value) []listDiffOp {
+	// lcs[i][j] is the length of the longest common subsequence of
+	// before[i:] and after[j:].
+	lcs := make([][]int, len(before)+1)
+
+	for i := range lcs {
+		lcs[i] = make([]int, len(after)+1)
+	}
+
+	for i := len(before) - 1; i >= 0; i-- {
+		for j := len(after) - 1; j >= 0; j-- {
+			if before[i].Equal(after[j]) {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var ops []listDiffOp
+
+	var deleted, created []int
+
+	flush := func() {
+		for len(deleted) > 0 && len(created) > 0 && diffHasElements(&before[deleted[0]]) && diffHasElements(&after[created[0]]) {
+			ops = append(ops, listDiffOp{action: diffActionUpdate, before: deleted[0], after: created[0]})
+			deleted, created = deleted[1:], created[1:]
+		}
+
+		for _, i := range deleted {
+			ops = append(ops, listDiffOp{action: diffActionDelete, before: i, after: -1})
+		}
+
+		for _, j := range created {
+			ops = append(ops, listDiffOp{action: diffActionCreate, before: -1, after: j})
+		}
+
+		deleted, created = nil, nil
+	}
+
+	i, j := 0, 0
+
+	for i < len(before) && j < len(after) {
+		switch {
+		case before[i].Equal(after[j]):
+			flush()
+			ops = append(ops, listDiffOp{action: diffActionNoOp, before: i, after: j})
+			i++
+			j++
+		case lcs[i+1][j] >= lcs[i][j+1]:
+			deleted = append(deleted, i)
+			i++
+		default:
+			created = append(created, j)
+			j++
+		}
+	}
+
+	for ; i < len(before); i++ {
+		deleted = append(deleted, i)
+	}
+
+	for ; j < len(after); j++ {
+		created = append(created, j)
+	}
+
+	flush()
+
+	return ops
+}
+
+func (r *diffRenderer) renderListElements(depth int, path *AttributePath, before, after *Value) {
+	beforeElems := diffElements(before)
+	afterElems := diffElements(after)
+
+	var hidden int
+
+	for _, op := range diffListOps(beforeElems, afterElems) {
+		if op.action == diffActionNoOp && !r.opts.ShowUnchanged {
+			hidden++
+
+			continue
+		}
+
+		var elemBefore, elemAfter *Value
+
+		index := op.after
+
+		if op.before >= 0 {
+			elemBefore = &beforeElems[op.before]
+		}
+
+		if op.after >= 0 {
+			elemAfter = &afterElems[op.after]
+		} else {
+			index = op.before
+		}
+
+		r.renderChange(depth, path.WithElementKeyInt(index), op.action, "", elemBefore, elemAfter, ",")
+	}
+
+	r.renderHidden(depth, hidden, "element")
+}
+
+func (r *diffRenderer) renderTupleElements(depth int, path *AttributePath, before, after *Value) {
+	beforeElems := diffElements(before)
+	afterElems := diffElements(after)
+
+	var hidden int
+
+	for pos := 0; pos < max(len(beforeElems), len(afterElems)); pos++ {
+		var elemBefore, elemAfter *Value
+
+		if pos < len(beforeElems) {
+			elemBefore = &beforeElems[pos]
+		}
+
+		if pos < len(afterElems) {
+			elemAfter = &afterElems[pos]
+		}
+
+		action := diffAction(elemBefore, elemAfter)
+
+		if action == diffActionNoOp && !r.opts.ShowUnchanged {
+			hidden++
+
+			continue
+		}
+
+		r.renderChange(depth, path.WithElementKeyInt(pos), action, "", elemBefore, elemAfter, ",")
+	}
+
+	r.renderHidden(depth, hidden, "element")
+}
+
+func (r *diffRenderer) renderSetElements(depth int, path *AttributePath, before, after *Value) {
+	beforeElems := diffElements(before)
+	afterElems := diffElements(after)
+
+	beforeIdx := newSetIndex(beforeElems)
+	afterIdx := newSetIndex(afterElems)
+
+	var hidden int
+
+	// Elements of the set before the change are rendered first, in
+	// order, followed by the created elements.
+	for pos := range beforeElems {
+		el := &beforeElems[pos]
+
+		// Errors comparing elements can only be caused by invalid
+		// Values, which are rendered as deleted and created.
+		found, _ := afterIdx.contains(*el)
+
+		action := diffActionDelete
+
+		if found {
+			action = diffActionNoOp
+
+			if !r.opts.ShowUnchanged {
+				hidden++
+
+				continue
+			}
+		}
+
+		var elemAfter *Value
+
+		if found {
+			elemAfter = el
+		}
+
+		r.renderChange(depth, path.WithElementKeyValue(*el), action, "", el, elemAfter, ",")
+	}
+
+	for pos := range afterElems {
+		el := &afterElems[pos]
+
+		if found, _ := beforeIdx.contains(*el); found {
+			continue
+		}
+
+		r.renderChange(depth, path.WithElementKeyValue(*el), diffActionCreate, "", nil, el, ",")
+	}
+
+	r.renderHidden(depth, hidden, "element")
+}
+
+// diffCollectionKind returns the kind of collection of a known, non-null Value
+// with elements or attributes, or an empty string for any other Value.
+func diffCollectionKind(val *Value) string {
+	if val == nil || !val.IsKnown() || val.IsNull() {
+		return ""
+	}
+
+	switch val.Type().(type) {
+	case Object:
+		return "object"
+	case Map:
+		return "map"
+	case List:
+		return "list"
+	case Set:
+		return "set"
+	case Tuple:
+		return "tuple"
+	default:
+		return ""
+	}
+}
+
+// diffHasElements returns true if the Value is a collection with at least one
+// element or attribute.
+func diffHasElements(val *Value) bool {
+	if diffCollectionKind(val) == "" {
+		return false
+	}
+
+	switch v := val.value.(type) {
+	case []Value:
+		return len(v) > 0
+	case map[string]Value:
+		return len(v) > 0
+	default:
+		return false
+	}
+}
+
+func diffAttributes(val *Value) map[string]Value {
+	if val == nil {
+		return nil
+	}
+
+	attrs, _ := val.value.(map[string]Value)
+
+	return attrs
+}
+
+func diffElements(val *Value) []Value {
+	if val == nil {
+		return nil
+	}
+
+	elems, _ := val.value.([]Value)
+
+	return elems
+}
+
+// renderDiffValue renders a Value without elements or attributes.
+func renderDiffValue(val *Value) string {
+	switch {
+	case val == nil || val.IsNull():
+		return "null"
+	case !val.IsKnown():
+		return "(known after apply)"
+	}
+
+	switch v := val.value.(type) {
+	case string:
+		return strconv.Quote(v)
+	case bool:
+		return strconv.FormatBool(v)
+	case []Value:
+		if len(v) == 0 {
+			return "[]"
+		}
+	case map[string]Value:
+		if len(v) == 0 {
+			return "{}"
+		}
+	}
+
+	if n, ok := numberBigFloat(val.value); ok {
+		return n.Text('f', -1)
+	}
+
+	return val.String()
+}
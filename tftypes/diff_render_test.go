@@ -0,0 +1,224 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tftypes
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRenderDiff(t *testing.T) {
+	t.Parallel()
+
+	ruleType := Object{AttributeTypes: map[string]Type{"port": Number}}
+	typ := Object{
+		AttributeTypes: map[string]Type{
+			"id":    String,
+			"name":  String,
+			"old":   String,
+			"tags":  Map{ElementType: String},
+			"names": List{ElementType: String},
+			"rules": Set{ElementType: ruleType},
+			"pair":  Tuple{ElementTypes: []Type{String, Bool}},
+		},
+	}
+
+	before := NewValue(typ, map[string]Value{
+		"id":   NewValue(String, "abc123"),
+		"name": NewValue(String, "a"),
+		"old":  NewValue(String, "bye"),
+		"tags": NewValue(Map{ElementType: String}, map[string]Value{
+			"env":  NewValue(String, "test"),
+			"team": NewValue(String, "infra"),
+		}),
+		"names": NewValue(List{ElementType: String}, []Value{
+			NewValue(String, "a"),
+			NewValue(String, "b"),
+			NewValue(String, "c"),
+		}),
+		"rules": NewValue(Set{ElementType: ruleType}, []Value{
+			NewValue(ruleType, map[string]Value{"port": NewValue(Number, 22)}),
+			NewValue(ruleType, map[string]Value{"port": NewValue(Number, 80)}),
+		}),
+		"pair": NewValue(Tuple{ElementTypes: []Type{String, Bool}}, []Value{
+			NewValue(String, "a"),
+			NewValue(Bool, false),
+		}),
+	})
+
+	after := NewValue(typ, map[string]Value{
+		"id":   NewValue(String, UnknownValue),
+		"name": NewValue(String, "b"),
+		"old":  NewValue(String, nil),
+		"tags": NewValue(Map{ElementType: String}, map[string]Value{
+			"env":  NewValue(String, "prod"),
+			"team": NewValue(String, "infra"),
+		}),
+		"names": NewValue(List{ElementType: String}, []Value{
+			NewValue(String, "a"),
+			NewValue(String, "c"),
+			NewValue(String, "d"),
+		}),
+		"rules": NewValue(Set{ElementType: ruleType}, []Value{
+			NewValue(ruleType, map[string]Value{"port": NewValue(Number, 80)}),
+			NewValue(ruleType, map[string]Value{"port": NewValue(Number, 443)}),
+		}),
+		"pair": NewValue(Tuple{ElementTypes: []Type{String, Bool}}, []Value{
+			NewValue(String, "a"),
+			NewValue(Bool, true),
+		}),
+	})
+
+	testCases := map[string]struct {
+		val1     Value
+		val2     Value
+		opts     RenderDiffOpts
+		expected string
+	}{
+		"equal": {
+			val1:     before,
+			val2:     before,
+			expected: "",
+		},
+		"primitive": {
+			val1:     NewValue(Number, 1),
+			val2:     NewValue(Number, 1.5),
+			expected: "~ 1 -> 1.5\n",
+		},
+		"update": {
+			val1: before,
+			val2: after,
+			expected: `~ {
+    ~ id    = "abc123" -> (known after apply)
+    ~ name  = "a" -> "b"
+    ~ names = [
+        - "b",
+        + "d",
+          # (2 unchanged elements hidden)
+      ]
+    - old   = "bye" -> null
+    ~ pair  = [
+        ~ false -> true,
+          # (1 unchanged element hidden)
+      ]
+    ~ rules = [
+        - {
+            - port = 22 -> null
+          },
+        + {
+            + port = 443
+          },
+          # (1 unchanged element hidden)
+      ]
+    ~ tags  = {
+        ~ "env" = "test" -> "prod"
+          # (1 unchanged element hidden)
+      }
+  }
+`,
+		},
+		"create": {
+			val1: NewValue(Map{ElementType: List{ElementType: String}}, nil),
+			val2: NewValue(Map{ElementType: List{ElementType: String}}, map[string]Value{
+				"a": NewValue(List{ElementType: String}, []Value{NewValue(String, "x")}),
+				"b": NewValue(List{ElementType: String}, []Value{}),
+			}),
+			expected: `+ {
+    + "a" = [
+        + "x",
+      ]
+    + "b" = []
+  }
+`,
+		},
+		"unknown": {
+			val1: NewValue(List{ElementType: String}, []Value{NewValue(String, "x")}),
+			val2: NewValue(List{ElementType: String}, UnknownValue),
+			expected: `~ [
+    - "x",
+  ] -> (known after apply)
+`,
+		},
+		"list-element-update": {
+			val1: NewValue(List{ElementType: ruleType}, []Value{
+				NewValue(ruleType, map[string]Value{"port": NewValue(Number, 22)}),
+			}),
+			val2: NewValue(List{ElementType: ruleType}, []Value{
+				NewValue(ruleType, map[string]Value{"port": NewValue(Number, 2222)}),
+			}),
+			expected: `~ [
+    ~ {
+        ~ port = 22 -> 2222
+      },
+  ]
+`,
+		},
+		"sensitive": {
+			val1: before,
+			val2: after,
+			opts: RenderDiffOpts{
+				Sensitive: func(path *AttributePath) bool {
+					return path.Equal(NewAttributePath().WithAttributeName("tags")) ||
+						path.Equal(NewAttributePath().WithAttributeName("names").WithElementKeyInt(2))
+				},
+			},
+			expected: `~ {
+    ~ id    = "abc123" -> (known after apply)
+    ~ name  = "a" -> "b"
+    ~ names = [
+        - "b",
+        + (sensitive value),
+          # (2 unchanged elements hidden)
+      ]
+    - old   = "bye" -> null
+    ~ pair  = [
+        ~ false -> true,
+          # (1 unchanged element hidden)
+      ]
+    ~ rules = [
+        - {
+            - port = 22 -> null
+          },
+        + {
+            + port = 443
+          },
+          # (1 unchanged element hidden)
+      ]
+    ~ tags  = (sensitive value)
+  }
+`,
+		},
+		"show-unchanged": {
+			val1: NewValue(Object{AttributeTypes: map[string]Type{"a": String, "bb": String}}, map[string]Value{
+				"a":  NewValue(String, "x"),
+				"bb": NewValue(String, "y"),
+			}),
+			val2: NewValue(Object{AttributeTypes: map[string]Type{"a": String, "bb": String}}, map[string]Value{
+				"a":  NewValue(String, "x"),
+				"bb": NewValue(String, "z"),
+			}),
+			opts: RenderDiffOpts{
+				ShowUnchanged: true,
+			},
+			expected: `~ {
+      a  = "x"
+    ~ bb = "y" -> "z"
+  }
+`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := RenderDiffWithOpts(testCase.val1, testCase.val2, testCase.opts)
+
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
@@ -556,6 +556,61 @@ func TestValueDiffDiff(t *testing.T) {
 				},
 			},
 		},
+		"setNoDiffReordered": {
+			val1: NewValue(Set{ElementType: String}, []Value{
+				NewValue(String, "foo"),
+				NewValue(String, "bar"),
+			}),
+			val2: NewValue(Set{ElementType: String}, []Value{
+				NewValue(String, "bar"),
+				NewValue(String, "foo"),
+			}),
+		},
+		"setObjectElementDiff": {
+			val1: NewValue(Set{ElementType: Object{AttributeTypes: map[string]Type{"port": Number}}}, []Value{
+				NewValue(Object{AttributeTypes: map[string]Type{"port": Number}}, map[string]Value{
+					"port": NewValue(Number, 80),
+				}),
+				NewValue(Object{AttributeTypes: map[string]Type{"port": Number}}, map[string]Value{
+					"port": NewValue(Number, 443),
+				}),
+			}),
+			val2: NewValue(Set{ElementType: Object{AttributeTypes: map[string]Type{"port": Number}}}, []Value{
+				NewValue(Object{AttributeTypes: map[string]Type{"port": Number}}, map[string]Value{
+					"port": NewValue(Number, 443),
+				}),
+				NewValue(Object{AttributeTypes: map[string]Type{"port": Number}}, map[string]Value{
+					"port": NewValue(Number, 8080),
+				}),
+			}),
+			diffs: []ValueDiff{
+				{
+					Path: NewAttributePath().WithElementKeyValue(NewValue(Object{AttributeTypes: map[string]Type{"port": Number}}, map[string]Value{
+						"port": NewValue(Number, 8080),
+					})),
+					Value1: nil,
+					Value2: valuePointer(NewValue(Object{AttributeTypes: map[string]Type{"port": Number}}, map[string]Value{
+						"port": NewValue(Number, 8080),
+					})),
+				},
+				{
+					Path: NewAttributePath().WithElementKeyValue(NewValue(Object{AttributeTypes: map[string]Type{"port": Number}}, map[string]Value{
+						"port": NewValue(Number, 80),
+					})),
+					Value1: valuePointer(NewValue(Object{AttributeTypes: map[string]Type{"port": Number}}, map[string]Value{
+						"port": NewValue(Number, 80),
+					})),
+					Value2: nil,
+				},
+				{
+					Path: NewAttributePath().WithElementKeyValue(NewValue(Object{AttributeTypes: map[string]Type{"port": Number}}, map[string]Value{
+						"port": NewValue(Number, 80),
+					})).WithAttributeName("port"),
+					Value1: valuePointer(NewValue(Number, 80)),
+					Value2: nil,
+				},
+			},
+		},
 	}
 	for name, test := range tests {
 		t.Run(name, func(t *testing.T) {
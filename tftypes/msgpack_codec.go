@@ -0,0 +1,862 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tftypes
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"hash/maphash"
+	"math"
+	"math/big"
+	"sort"
+
+	msgpack "github.com/vmihailenco/msgpack/v5"
+	msgpackCodes "github.com/vmihailenco/msgpack/v5/msgpcode"
+
+	"github.com/hashicorp/terraform-plugin-go/internal/lru"
+)
+
+// MsgPackCodec decodes and encodes Values of a single Type to and from
+// MessagePack, the encoding Terraform uses for values sent over the protocol.
+//
+// Unlike ValueFromMsgPack and Value.MarshalMsgPack, which inspect the Type
+// again for every Value they handle, a MsgPackCodec does this once when it is
+// created, and builds AttributePaths only when an error needs one. The Values
+// it decodes and the bytes it encodes are the same as theirs.
+//
+// A MsgPackCodec is safe for concurrent use.
+type MsgPackCodec struct {
+	typ    Type
+	decode msgpackDecodeFunc
+	encode msgpackEncodeFunc
+}
+
+// msgPackCodecCacheSize is the number of MsgPackCodecs kept in the cache, which
+// is enough for the resource, data source, and other schema types of the
+// largest providers.
+const msgPackCodecCacheSize = 4096
+
+// msgPackCodecs caches MsgPackCodecs by the msgPackCodecKey of their Type.
+var msgPackCodecs = lru.New[uint64, *MsgPackCodec](msgPackCodecCacheSize)
+
+// msgPackCodecSeed is the seed of the msgPackCodecKey hashes.
+var msgPackCodecSeed = maphash.MakeSeed()
+
+// NewMsgPackCodec returns a MsgPackCodec for `typ`. The MsgPackCodecs of
+// recently used Types are cached, so calling NewMsgPackCodec with a Type equal
+// to one it was called with before usually returns the existing MsgPackCodec.
+// This makes it cheap to call NewMsgPackCodec for every request with a schema
+// type.
+func NewMsgPackCodec(typ Type) *MsgPackCodec {
+	key := msgPackCodecKey(typ)
+
+	// Types with the same key are almost certainly equal, but a
+	// collision must not return the MsgPackCodec of another Type.
+	if codec, ok := msgPackCodecs.Get(key); ok && codec.typ.Equal(typ) {
+		return codec
+	}
+
+	codec := &MsgPackCodec{
+		typ:    typ,
+		decode: compileMsgPackDecoder(typ),
+		encode: compileMsgPackEncoder(typ),
+	}
+
+	msgPackCodecs.Add(key, codec)
+
+	return codec
+}
+
+// msgPackCodecKey returns a hash of the Type, for looking up its MsgPackCodec
+// in the cache without allocating, as encoding the Type would.
+func msgPackCodecKey(typ Type) uint64 {
+	var h maphash.Hash
+
+	h.SetSeed(msgPackCodecSeed)
+
+	writeKey := func(typ Type) {
+		writeMsgPackCodecKeyUint64(&h, msgPackCodecKey(typ))
+	}
+
+	switch typ := typ.(type) {
+	case nil:
+		_ = h.WriteByte(0)
+	case primitive:
+		_ = h.WriteByte(1)
+		_, _ = h.WriteString(typ.name)
+	case List:
+		_ = h.WriteByte(2)
+		writeKey(typ.ElementType)
+	case Set:
+		_ = h.WriteByte(3)
+		writeKey(typ.ElementType)
+	case Map:
+		_ = h.WriteByte(4)
+		writeKey(typ.ElementType)
+	case Tuple:
+		_ = h.WriteByte(5)
+
+		for _, elemType := range typ.ElementTypes {
+			writeKey(elemType)
+		}
+	case Object:
+		_ = h.WriteByte(6)
+
+		// The attributes are combined by adding their hashes, so the
+		// key does not depend on the map iteration order.
+		var attrs uint64
+
+		for name, attrType := range typ.AttributeTypes {
+			var attrHash maphash.Hash
+
+			attrHash.SetSeed(msgPackCodecSeed)
+			_, _ = attrHash.WriteString(name)
+
+			if typ.attrIsOptional(name) {
+				_ = attrHash.WriteByte(1)
+			}
+
+			writeMsgPackCodecKeyUint64(&attrHash, msgPackCodecKey(attrType))
+
+			attrs += attrHash.Sum64()
+		}
+
+		writeMsgPackCodecKeyUint64(&h, attrs)
+	default:
+		_ = h.WriteByte(7)
+		_, _ = h.WriteString(typ.String())
+	}
+
+	return h.Sum64()
+}
+
+func writeMsgPackCodecKeyUint64(h *maphash.Hash, v uint64) {
+	var buf [8]byte
+
+	binary.LittleEndian.PutUint64(buf[:], v)
+	_, _ = h.Write(buf[:])
+}
+
+// Type returns the Type the MsgPackCodec decodes and encodes.
+func (c *MsgPackCodec) Type() Type {
+	return c.typ
+}
+
+// Unmarshal returns a Value from the MessagePack-encoded bytes, the same as
+// ValueFromMsgPack with the Type of the MsgPackCodec.
+func (c *MsgPackCodec) Unmarshal(data []byte) (Value, error) {
+	dec := msgpack.NewDecoder(bytes.NewReader(data))
+
+	val, err := c.decode(dec)
+
+	if err != nil {
+		return Value{}, msgpackCodecPathError(err)
+	}
+
+	return val, nil
+}
+
+// Marshal returns the MessagePack encoding of `val`, the same as
+// Value.MarshalMsgPack with the Type of the MsgPackCodec.
+func (c *MsgPackCodec) Marshal(val Value) ([]byte, error) {
+	var buf bytes.Buffer
+
+	enc := msgpack.NewEncoder(&buf)
+
+	err := c.encode(val, enc)
+
+	if err != nil {
+		return nil, msgpackCodecPathError(err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+type msgpackDecodeFunc func(dec *msgpack.Decoder) (Value, error)
+
+type msgpackEncodeFunc func(val Value, enc *msgpack.Encoder) error
+
+// msgpackCodecError is an error from a compiled decoder or encoder. Instead of
+// an AttributePath, it holds the steps to the Value with the error in reverse
+// order, so each enclosing decoder or encoder can add its step without
+// copying the steps, and the AttributePath is only built once.
+type msgpackCodecError struct {
+	reversedSteps []AttributePathStep
+	err           error
+}
+
+func (e *msgpackCodecError) Error() string {
+	return e.err.Error()
+}
+
+func (e *msgpackCodecError) Unwrap() error {
+	return e.err
+}
+
+func msgpackCodecErrorf(f string, args ...interface{}) error {
+	return &msgpackCodecError{err: fmt.Errorf(f, args...)}
+}
+
+// withMsgPackCodecStep adds the step to the Value containing the Value with
+// the error.
+func withMsgPackCodecStep(err error, step AttributePathStep) error {
+	codecErr, ok := err.(*msgpackCodecError) //nolint:errorlint // only unwrapped errors are returned by compiled funcs
+
+	if !ok {
+		codecErr = &msgpackCodecError{err: err}
+	}
+
+	codecErr.reversedSteps = append(codecErr.reversedSteps, step)
+
+	return codecErr
+}
+
+// msgpackCodecPathError converts an error from a compiled decoder or encoder
+// into an AttributePathError.
+func msgpackCodecPathError(err error) error {
+	codecErr, ok := err.(*msgpackCodecError) //nolint:errorlint // only unwrapped errors are returned by compiled funcs
+
+	if !ok {
+		return err
+	}
+
+	steps := make([]AttributePathStep, 0, len(codecErr.reversedSteps))
+
+	for i := len(codecErr.reversedSteps) - 1; i >= 0; i-- {
+		steps = append(steps, codecErr.reversedSteps[i])
+	}
+
+	// Errors from the DynamicPseudoType fallbacks and NewValue already
+	// have a path, relative to the Value they were called for.
+	if pathErr, ok := codecErr.err.(AttributePathError); ok { //nolint:errorlint // only the path of the outermost error is relative
+		return AttributePathError{
+			Path: NewAttributePathWithSteps(append(steps, pathErr.Path.Steps()...)),
+			err:  pathErr.err,
+		}
+	}
+
+	return NewAttributePathWithSteps(steps).NewError(codecErr.err)
+}
+
+// typeHasDynamicPseudoType returns true if DynamicPseudoType is used anywhere
+// in `typ`. Values of those types are created with NewValue, to get the same
+// validation as ValueFromMsgPack. Values of other types are created directly,
+// as their elements are guaranteed to have the right types.
+func typeHasDynamicPseudoType(typ Type) bool {
+	switch typ := typ.(type) {
+	case primitive:
+		return typ.Is(DynamicPseudoType)
+	case List:
+		return typeHasDynamicPseudoType(typ.ElementType)
+	case Set:
+		return typeHasDynamicPseudoType(typ.ElementType)
+	case Map:
+		return typeHasDynamicPseudoType(typ.ElementType)
+	case Tuple:
+		for _, elemType := range typ.ElementTypes {
+			if typeHasDynamicPseudoType(elemType) {
+				return true
+			}
+		}
+	case Object:
+		for _, attrType := range typ.AttributeTypes {
+			if typeHasDynamicPseudoType(attrType) {
+				return true
+			}
+		}
+	}
+
+	return false
+}
+
+func compileMsgPackDecoder(typ Type) msgpackDecodeFunc {
+	// Values are immutable, so null and unknown Values can be shared.
+	unknown := NewValue(typ, UnknownValue)
+	null := NewValue(typ, nil)
+
+	var decodeKnown msgpackDecodeFunc
+
+	switch typ := typ.(type) {
+	case primitive:
+		switch typ.name {
+		case DynamicPseudoType.name:
+			return func(dec *msgpack.Decoder) (Value, error) {
+				peek, err := dec.PeekCode()
+				if err != nil {
+					return Value{}, msgpackCodecErrorf("error peeking next byte: %w", err)
+				}
+				if msgpackCodes.IsExt(peek) {
+					err := dec.Skip()
+					if err != nil {
+						return Value{}, msgpackCodecErrorf("error skipping extension byte: %w", err)
+					}
+					return unknown, nil
+				}
+				val, err := msgpackUnmarshalDynamic(dec, NewAttributePath())
+				if err != nil {
+					return Value{}, &msgpackCodecError{err: err}
+				}
+				return val, nil
+			}
+		case String.name:
+			decodeKnown = decodeMsgPackString
+		case Number.name:
+			decodeKnown = decodeMsgPackNumber
+		case Bool.name:
+			decodeKnown = decodeMsgPackBool
+		}
+	case List:
+		decodeKnown = compileMsgPackListDecoder(typ)
+	case Set:
+		decodeKnown = compileMsgPackSetDecoder(typ)
+	case Map:
+		decodeKnown = compileMsgPackMapDecoder(typ)
+	case Tuple:
+		decodeKnown = compileMsgPackTupleDecoder(typ)
+	case Object:
+		decodeKnown = compileMsgPackObjectDecoder(typ)
+	}
+
+	if decodeKnown == nil {
+		return func(_ *msgpack.Decoder) (Value, error) {
+			return Value{}, msgpackCodecErrorf("unsupported type %s", typ.String())
+		}
+	}
+
+	return func(dec *msgpack.Decoder) (Value, error) {
+		peek, err := dec.PeekCode()
+		if err != nil {
+			return Value{}, msgpackCodecErrorf("error peeking next byte: %w", err)
+		}
+		if msgpackCodes.IsExt(peek) {
+			// as with go-cty, assume all extensions are unknown values
+			err := dec.Skip()
+			if err != nil {
+				return Value{}, msgpackCodecErrorf("error skipping extension byte: %w", err)
+			}
+			return unknown, nil
+		}
+		if peek == msgpackCodes.Nil {
+			err := dec.Skip()
+			if err != nil {
+				return Value{}, msgpackCodecErrorf("error skipping nil byte: %w", err)
+			}
+			return null, nil
+		}
+		return decodeKnown(dec)
+	}
+}
+
+func decodeMsgPackString(dec *msgpack.Decoder) (Value, error) {
+	rv, err := dec.DecodeString()
+	if err != nil {
+		return Value{}, msgpackCodecErrorf("error decoding string: %w", err)
+	}
+	return Value{typ: String, value: rv}, nil
+}
+
+func decodeMsgPackNumber(dec *msgpack.Decoder) (Value, error) {
+	peek, err := dec.PeekCode()
+	if err != nil {
+		return Value{}, msgpackCodecErrorf("couldn't peek number: %w", err)
+	}
+	switch {
+	case msgpackCodes.IsFixedNum(peek), peek == msgpackCodes.Int8, peek == msgpackCodes.Int16, peek == msgpackCodes.Int32, peek == msgpackCodes.Int64:
+		rv, err := dec.DecodeInt64()
+		if err != nil {
+			return Value{}, msgpackCodecErrorf("couldn't decode number as int64: %w", err)
+		}
+		return Value{typ: Number, value: rv}, nil
+	case peek == msgpackCodes.Uint8, peek == msgpackCodes.Uint16, peek == msgpackCodes.Uint32, peek == msgpackCodes.Uint64:
+		rv, err := dec.DecodeUint64()
+		if err != nil {
+			return Value{}, msgpackCodecErrorf("couldn't decode number as uint64: %w", err)
+		}
+		return Value{typ: Number, value: rv}, nil
+	case peek == msgpackCodes.Float, peek == msgpackCodes.Double:
+		rv, err := dec.DecodeFloat64()
+		if err != nil {
+			return Value{}, msgpackCodecErrorf("couldn't decode number as float64: %w", err)
+		}
+		if math.IsNaN(rv) {
+			return Value{}, msgpackCodecErrorf("couldn't decode number: NaN is not a valid number")
+		}
+		return Value{typ: Number, value: rv}, nil
+	default:
+		rv, err := dec.DecodeString()
+		if err != nil {
+			return Value{}, msgpackCodecErrorf("couldn't decode number as string: %w", err)
+		}
+		// See msgpackUnmarshal for the choice of base, precision, and
+		// rounding mode.
+		fv, _, err := big.ParseFloat(rv, 10, 512, big.ToNearestEven)
+		if err != nil {
+			return Value{}, msgpackCodecErrorf("error parsing %q as number: %w", rv, err)
+		}
+		return Value{typ: Number, value: fv}, nil
+	}
+}
+
+func decodeMsgPackBool(dec *msgpack.Decoder) (Value, error) {
+	rv, err := dec.DecodeBool()
+	if err != nil {
+		return Value{}, msgpackCodecErrorf("couldn't decode bool: %w", err)
+	}
+	return Value{typ: Bool, value: rv}, nil
+}
+
+// decodeMsgPackElements decodes `length` elements, adding ElementKeyInt steps
+// to errors.
+func decodeMsgPackElements(dec *msgpack.Decoder, length int, decodeElement func(int) msgpackDecodeFunc) ([]Value, error) {
+	vals := make([]Value, 0, length)
+	for i := 0; i < length; i++ {
+		val, err := decodeElement(i)(dec)
+		if err != nil {
+			return nil, withMsgPackCodecStep(err, ElementKeyInt(i))
+		}
+		vals = append(vals, val)
+	}
+	return vals, nil
+}
+
+func compileMsgPackListDecoder(typ List) msgpackDecodeFunc {
+	decodeElement := compileMsgPackDecoder(typ.ElementType)
+	elementDecoder := func(int) msgpackDecodeFunc { return decodeElement }
+	dynamic := typeHasDynamicPseudoType(typ.ElementType)
+
+	return func(dec *msgpack.Decoder) (Value, error) {
+		length, err := dec.DecodeArrayLen()
+		if err != nil {
+			return Value{}, msgpackCodecErrorf("error decoding list length: %w", err)
+		}
+
+		switch {
+		case length < 0:
+			return NewValue(typ, nil), nil
+		case length == 0:
+			// Values do not copy their elements, so each empty
+			// Value needs its own slice.
+			return Value{typ: typ, value: []Value{}}, nil
+		}
+
+		vals, err := decodeMsgPackElements(dec, length, elementDecoder)
+		if err != nil {
+			return Value{}, err
+		}
+
+		if !dynamic {
+			return Value{typ: typ, value: vals}, nil
+		}
+
+		elTyp := typ.ElementType
+		if elTyp.Is(DynamicPseudoType) {
+			elTyp, err = TypeFromElements(vals)
+			if err != nil {
+				return Value{}, &msgpackCodecError{err: err}
+			}
+		}
+
+		val, err := newValue(List{ElementType: elTyp}, vals)
+		if err != nil {
+			return Value{}, &msgpackCodecError{err: err}
+		}
+		return val, nil
+	}
+}
+
+func compileMsgPackSetDecoder(typ Set) msgpackDecodeFunc {
+	decodeElement := compileMsgPackDecoder(typ.ElementType)
+	elementDecoder := func(int) msgpackDecodeFunc { return decodeElement }
+
+	return func(dec *msgpack.Decoder) (Value, error) {
+		length, err := dec.DecodeArrayLen()
+		if err != nil {
+			return Value{}, msgpackCodecErrorf("error decoding set length: %w", err)
+		}
+
+		switch {
+		case length < 0:
+			return NewValue(typ, nil), nil
+		case length == 0:
+			// Values do not copy their elements, so each empty
+			// Value needs its own slice.
+			return Value{typ: typ, value: []Value{}}, nil
+		}
+
+		vals, err := decodeMsgPackElements(dec, length, elementDecoder)
+		if err != nil {
+			return Value{}, err
+		}
+
+		// Like msgpackUnmarshalSet, use the type of the decoded
+		// elements, which may differ from the ElementType for objects
+		// with optional attributes.
+		elTyp, err := TypeFromElements(vals)
+		if err != nil {
+			return Value{}, &msgpackCodecError{err: err}
+		}
+
+		return Value{typ: Set{ElementType: elTyp}, value: vals}, nil
+	}
+}
+
+func compileMsgPackMapDecoder(typ Map) msgpackDecodeFunc {
+	decodeElement := compileMsgPackDecoder(typ.ElementType)
+	dynamic := typeHasDynamicPseudoType(typ.ElementType)
+
+	return func(dec *msgpack.Decoder) (Value, error) {
+		length, err := dec.DecodeMapLen()
+		if err != nil {
+			return Value{}, msgpackCodecErrorf("error decoding map length: %w", err)
+		}
+
+		switch {
+		case length < 0:
+			return NewValue(typ, nil), nil
+		case length == 0:
+			// Values do not copy their elements, so each empty
+			// Value needs its own map.
+			return Value{typ: typ, value: map[string]Value{}}, nil
+		}
+
+		vals := make(map[string]Value, length)
+		for i := 0; i < length; i++ {
+			key, err := dec.DecodeString()
+			if err != nil {
+				return Value{}, msgpackCodecErrorf("error decoding map key: %w", err)
+			}
+			val, err := decodeElement(dec)
+			if err != nil {
+				return Value{}, withMsgPackCodecStep(err, ElementKeyString(key))
+			}
+			vals[key] = val
+		}
+
+		if !dynamic {
+			return Value{typ: typ, value: vals}, nil
+		}
+
+		val, err := newValue(typ, vals)
+		if err != nil {
+			return Value{}, &msgpackCodecError{err: err}
+		}
+		return val, nil
+	}
+}
+
+func compileMsgPackTupleDecoder(typ Tuple) msgpackDecodeFunc {
+	decodeElements := make([]msgpackDecodeFunc, 0, len(typ.ElementTypes))
+	for _, elemType := range typ.ElementTypes {
+		decodeElements = append(decodeElements, compileMsgPackDecoder(elemType))
+	}
+	elementDecoder := func(i int) msgpackDecodeFunc { return decodeElements[i] }
+	dynamic := typeHasDynamicPseudoType(typ)
+
+	return func(dec *msgpack.Decoder) (Value, error) {
+		length, err := dec.DecodeArrayLen()
+		if err != nil {
+			return Value{}, msgpackCodecErrorf("error decoding tuple length: %w", err)
+		}
+
+		switch {
+		case length < 0:
+			return NewValue(typ, nil), nil
+		case length != len(typ.ElementTypes):
+			return Value{}, msgpackCodecErrorf("error decoding tuple; expected %d items, got %d", len(typ.ElementTypes), length)
+		}
+
+		vals, err := decodeMsgPackElements(dec, length, elementDecoder)
+		if err != nil {
+			return Value{}, err
+		}
+
+		if !dynamic {
+			return Value{typ: typ, value: vals}, nil
+		}
+
+		val, err := newValue(typ, vals)
+		if err != nil {
+			return Value{}, &msgpackCodecError{err: err}
+		}
+		return val, nil
+	}
+}
+
+func compileMsgPackObjectDecoder(typ Object) msgpackDecodeFunc {
+	// Like msgpackUnmarshalObject, decoded objects do not have optional
+	// attributes.
+	valTyp := Object{AttributeTypes: typ.AttributeTypes}
+	decodeAttributes := make(map[string]msgpackDecodeFunc, len(typ.AttributeTypes))
+	for name, attrType := range typ.AttributeTypes {
+		decodeAttributes[name] = compileMsgPackDecoder(attrType)
+	}
+	dynamic := typeHasDynamicPseudoType(typ)
+
+	return func(dec *msgpack.Decoder) (Value, error) {
+		length, err := dec.DecodeMapLen()
+		if err != nil {
+			return Value{}, msgpackCodecErrorf("error decoding object length: %w", err)
+		}
+
+		switch {
+		case length < 0:
+			return NewValue(valTyp, nil), nil
+		case length != len(typ.AttributeTypes):
+			return Value{}, msgpackCodecErrorf("error decoding object; expected %d attributes, got %d", len(typ.AttributeTypes), length)
+		}
+
+		vals := make(map[string]Value, length)
+		for i := 0; i < length; i++ {
+			key, err := dec.DecodeString()
+			if err != nil {
+				return Value{}, msgpackCodecErrorf("error decoding object key: %w", err)
+			}
+			decodeAttribute, exists := decodeAttributes[key]
+			if !exists {
+				return Value{}, msgpackCodecErrorf("unknown attribute %q", key)
+			}
+			val, err := decodeAttribute(dec)
+			if err != nil {
+				return Value{}, withMsgPackCodecStep(err, AttributeName(key))
+			}
+			vals[key] = val
+		}
+
+		if !dynamic {
+			return Value{typ: valTyp, value: vals}, nil
+		}
+
+		val, err := newValue(valTyp, vals)
+		if err != nil {
+			return Value{}, &msgpackCodecError{err: err}
+		}
+		return val, nil
+	}
+}
+
+func compileMsgPackEncoder(typ Type) msgpackEncodeFunc {
+	var encodeKnown msgpackEncodeFunc
+
+	switch typ := typ.(type) {
+	case primitive:
+		switch typ.name {
+		case DynamicPseudoType.name:
+			// The Type of the Value is only known when encoding.
+			return func(val Value, enc *msgpack.Encoder) error {
+				err := marshalMsgPack(val, typ, NewAttributePath(), enc)
+				if err != nil {
+					return &msgpackCodecError{err: err}
+				}
+				return nil
+			}
+		case String.name:
+			encodeKnown = func(val Value, enc *msgpack.Encoder) error {
+				return msgpackCodecEncodeError(marshalMsgPackString(val, typ, nil, enc))
+			}
+		case Number.name:
+			encodeKnown = func(val Value, enc *msgpack.Encoder) error {
+				return msgpackCodecEncodeError(marshalMsgPackNumber(val, typ, nil, enc))
+			}
+		case Bool.name:
+			encodeKnown = func(val Value, enc *msgpack.Encoder) error {
+				return msgpackCodecEncodeError(marshalMsgPackBool(val, typ, nil, enc))
+			}
+		}
+	case List:
+		encodeKnown = compileMsgPackListEncoder(typ)
+	case Set:
+		encodeKnown = compileMsgPackSetEncoder(typ)
+	case Map:
+		encodeKnown = compileMsgPackMapEncoder(typ)
+	case Tuple:
+		encodeKnown = compileMsgPackTupleEncoder(typ)
+	case Object:
+		encodeKnown = compileMsgPackObjectEncoder(typ)
+	}
+
+	if encodeKnown == nil {
+		return func(_ Value, _ *msgpack.Encoder) error {
+			return &msgpackCodecError{err: fmt.Errorf("unknown type %s", typ)}
+		}
+	}
+
+	return func(val Value, enc *msgpack.Encoder) error {
+		if !val.IsKnown() {
+			err := enc.Encode(msgPackUnknownVal)
+			if err != nil {
+				return msgpackCodecErrorf("error encoding UnknownValue: %w", err)
+			}
+			return nil
+		}
+		if val.IsNull() {
+			err := enc.EncodeNil()
+			if err != nil {
+				return msgpackCodecErrorf("error encoding null value: %w", err)
+			}
+			return nil
+		}
+		return encodeKnown(val, enc)
+	}
+}
+
+// msgpackCodecEncodeError converts an error from the marshalMsgPack functions
+// for primitives, which were called with a nil AttributePath, into a
+// msgpackCodecError.
+func msgpackCodecEncodeError(err error) error {
+	if err == nil {
+		return nil
+	}
+
+	if pathErr, ok := err.(AttributePathError); ok { //nolint:errorlint // the primitive functions return AttributePathErrors directly
+		err = pathErr.err
+	}
+
+	return &msgpackCodecError{err: err}
+}
+
+func compileMsgPackListEncoder(typ List) msgpackEncodeFunc {
+	encodeElement := compileMsgPackEncoder(typ.ElementType)
+
+	return func(val Value, enc *msgpack.Encoder) error {
+		l, ok := val.value.([]Value)
+		if !ok {
+			return msgpackCodecEncodeError(unexpectedValueTypeError(nil, l, val.value, typ))
+		}
+		err := enc.EncodeArrayLen(len(l))
+		if err != nil {
+			return msgpackCodecErrorf("error encoding list length: %w", err)
+		}
+		for pos, el := range l {
+			err := encodeElement(el, enc)
+			if err != nil {
+				return withMsgPackCodecStep(err, ElementKeyInt(pos))
+			}
+		}
+		return nil
+	}
+}
+
+func compileMsgPackSetEncoder(typ Set) msgpackEncodeFunc {
+	encodeElement := compileMsgPackEncoder(typ.ElementType)
+
+	return func(val Value, enc *msgpack.Encoder) error {
+		s, ok := val.value.([]Value)
+		if !ok {
+			return msgpackCodecEncodeError(unexpectedValueTypeError(nil, s, val.value, typ))
+		}
+		err := enc.EncodeArrayLen(len(s))
+		if err != nil {
+			return msgpackCodecErrorf("error encoding set length: %w", err)
+		}
+		for _, el := range s {
+			err := encodeElement(el, enc)
+			if err != nil {
+				return withMsgPackCodecStep(err, ElementKeyValue(el))
+			}
+		}
+		return nil
+	}
+}
+
+func compileMsgPackMapEncoder(typ Map) msgpackEncodeFunc {
+	encodeElement := compileMsgPackEncoder(typ.ElementType)
+
+	return func(val Value, enc *msgpack.Encoder) error {
+		m, ok := val.value.(map[string]Value)
+		if !ok {
+			return msgpackCodecEncodeError(unexpectedValueTypeError(nil, m, val.value, typ))
+		}
+		err := enc.EncodeMapLen(len(m))
+		if err != nil {
+			return msgpackCodecErrorf("error encoding map length: %w", err)
+		}
+		for k, v := range m {
+			err := enc.EncodeString(k)
+			if err != nil {
+				return withMsgPackCodecStep(msgpackCodecErrorf("error encoding map key: error encoding string value: %w", err), ElementKeyString(k))
+			}
+			// Like marshalMsgPackMap, errors in map values are
+			// associated with the map.
+			err = encodeElement(v, enc)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func compileMsgPackTupleEncoder(typ Tuple) msgpackEncodeFunc {
+	encodeElements := make([]msgpackEncodeFunc, 0, len(typ.ElementTypes))
+	for _, elemType := range typ.ElementTypes {
+		encodeElements = append(encodeElements, compileMsgPackEncoder(elemType))
+	}
+
+	return func(val Value, enc *msgpack.Encoder) error {
+		t, ok := val.value.([]Value)
+		if !ok {
+			return msgpackCodecEncodeError(unexpectedValueTypeError(nil, t, val.value, typ))
+		}
+		err := enc.EncodeArrayLen(len(encodeElements))
+		if err != nil {
+			return msgpackCodecErrorf("error encoding tuple length: %w", err)
+		}
+		for pos, el := range t {
+			if pos >= len(encodeElements) {
+				return msgpackCodecErrorf("unexpected tuple element %d, %s has %d elements", pos, typ, len(encodeElements))
+			}
+			err := encodeElements[pos](el, enc)
+			if err != nil {
+				return withMsgPackCodecStep(err, ElementKeyInt(pos))
+			}
+		}
+		return nil
+	}
+}
+
+func compileMsgPackObjectEncoder(typ Object) msgpackEncodeFunc {
+	keys := make([]string, 0, len(typ.AttributeTypes))
+	for k := range typ.AttributeTypes {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	// The attribute names are always the same, so they are only encoded
+	// once.
+	encodedKeys := make([][]byte, 0, len(keys))
+	encodeAttributes := make([]msgpackEncodeFunc, 0, len(keys))
+	for _, k := range keys {
+		// Encoding a string into memory cannot fail
+		encodedKey, _ := msgpack.Marshal(k)
+		encodedKeys = append(encodedKeys, encodedKey)
+		encodeAttributes = append(encodeAttributes, compileMsgPackEncoder(typ.AttributeTypes[k]))
+	}
+
+	return func(val Value, enc *msgpack.Encoder) error {
+		o, ok := val.value.(map[string]Value)
+		if !ok {
+			return msgpackCodecEncodeError(unexpectedValueTypeError(nil, o, val.value, typ))
+		}
+		err := enc.EncodeMapLen(len(keys))
+		if err != nil {
+			return msgpackCodecErrorf("error encoding object length: %w", err)
+		}
+		for pos, k := range keys {
+			v, ok := o[k]
+			if !ok {
+				return withMsgPackCodecStep(msgpackCodecErrorf("no value set"), AttributeName(k))
+			}
+			err := enc.Encode(msgpack.RawMessage(encodedKeys[pos]))
+			if err != nil {
+				return withMsgPackCodecStep(msgpackCodecErrorf("error encoding string value: %w", err), AttributeName(k))
+			}
+			err = encodeAttributes[pos](v, enc)
+			if err != nil {
+				return withMsgPackCodecStep(err, AttributeName(k))
+			}
+		}
+		return nil
+	}
+}
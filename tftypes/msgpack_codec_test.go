@@ -0,0 +1,424 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tftypes
+
+import (
+	"encoding/hex"
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMsgPackCodec(t *testing.T) {
+	t.Parallel()
+
+	objectType := Object{
+		AttributeTypes: map[string]Type{
+			"id":    String,
+			"count": Number,
+			"tags":  Map{ElementType: String},
+			"rules": Set{ElementType: Object{AttributeTypes: map[string]Type{"port": Number}}},
+		},
+	}
+
+	testCases := map[string]struct {
+		typ   Type
+		value Value
+	}{
+		"string": {
+			typ:   String,
+			value: NewValue(String, "hello"),
+		},
+		"string-null": {
+			typ:   String,
+			value: NewValue(String, nil),
+		},
+		"string-unknown": {
+			typ:   String,
+			value: NewValue(String, UnknownValue),
+		},
+		"number-int": {
+			typ:   Number,
+			value: NewValue(Number, -123),
+		},
+		"number-uint": {
+			typ:   Number,
+			value: NewValue(Number, uint64(math.MaxUint64)),
+		},
+		"number-float": {
+			typ:   Number,
+			value: NewValue(Number, 1.5),
+		},
+		"number-infinity": {
+			typ:   Number,
+			value: NewValue(Number, math.Inf(-1)),
+		},
+		"number-big": {
+			typ:   Number,
+			value: NewValue(Number, mustParseFloat("123456789012345678901234567890.5")),
+		},
+		"bool": {
+			typ:   Bool,
+			value: NewValue(Bool, true),
+		},
+		"list": {
+			typ: List{ElementType: String},
+			value: NewValue(List{ElementType: String}, []Value{
+				NewValue(String, "a"),
+				NewValue(String, nil),
+				NewValue(String, UnknownValue),
+			}),
+		},
+		"list-empty": {
+			typ:   List{ElementType: String},
+			value: NewValue(List{ElementType: String}, []Value{}),
+		},
+		"list-dynamic": {
+			typ: List{ElementType: DynamicPseudoType},
+			value: NewValue(List{ElementType: Number}, []Value{
+				NewValue(Number, 1),
+				NewValue(Number, 2),
+			}),
+		},
+		"set": {
+			typ: Set{ElementType: Bool},
+			value: NewValue(Set{ElementType: Bool}, []Value{
+				NewValue(Bool, true),
+				NewValue(Bool, false),
+			}),
+		},
+		"map": {
+			typ: Map{ElementType: Number},
+			value: NewValue(Map{ElementType: Number}, map[string]Value{
+				"a": NewValue(Number, 1),
+			}),
+		},
+		"map-dynamic": {
+			typ: Map{ElementType: DynamicPseudoType},
+			value: NewValue(Map{ElementType: DynamicPseudoType}, map[string]Value{
+				"a": NewValue(String, "one"),
+			}),
+		},
+		"tuple": {
+			typ: Tuple{ElementTypes: []Type{String, DynamicPseudoType}},
+			value: NewValue(Tuple{ElementTypes: []Type{String, DynamicPseudoType}}, []Value{
+				NewValue(String, "a"),
+				NewValue(List{ElementType: Bool}, []Value{NewValue(Bool, true)}),
+			}),
+		},
+		"dynamic": {
+			typ:   DynamicPseudoType,
+			value: NewValue(String, "hello"),
+		},
+		"dynamic-unknown": {
+			typ:   DynamicPseudoType,
+			value: NewValue(DynamicPseudoType, UnknownValue),
+		},
+		"object": {
+			typ: objectType,
+			value: NewValue(objectType, map[string]Value{
+				"id":    NewValue(String, "abc"),
+				"count": NewValue(Number, 3),
+				"tags": NewValue(Map{ElementType: String}, map[string]Value{
+					"env": NewValue(String, "test"),
+				}),
+				"rules": NewValue(Set{ElementType: Object{AttributeTypes: map[string]Type{"port": Number}}}, []Value{
+					NewValue(Object{AttributeTypes: map[string]Type{"port": Number}}, map[string]Value{
+						"port": NewValue(Number, 80),
+					}),
+					NewValue(Object{AttributeTypes: map[string]Type{"port": Number}}, map[string]Value{
+						"port": NewValue(Number, 443),
+					}),
+				}),
+			}),
+		},
+		"object-null": {
+			typ:   objectType,
+			value: NewValue(objectType, nil),
+		},
+		"object-optional-attributes": {
+			typ: Object{
+				AttributeTypes:     map[string]Type{"a": String},
+				OptionalAttributes: map[string]struct{}{"a": {}},
+			},
+			value: NewValue(Object{AttributeTypes: map[string]Type{"a": String}}, map[string]Value{
+				"a": NewValue(String, "one"),
+			}),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			codec := NewMsgPackCodec(testCase.typ)
+
+			expectedData, err := testCase.value.MarshalMsgPack(testCase.typ) //nolint:staticcheck
+
+			if err != nil {
+				t.Fatalf("unexpected MarshalMsgPack error: %s", err)
+			}
+
+			data, err := codec.Marshal(testCase.value)
+
+			if err != nil {
+				t.Fatalf("unexpected Marshal error: %s", err)
+			}
+
+			if diff := cmp.Diff(hex.EncodeToString(expectedData), hex.EncodeToString(data)); diff != "" {
+				t.Errorf("unexpected encoding difference: %s", diff)
+			}
+
+			expected, err := ValueFromMsgPack(data, testCase.typ) //nolint:staticcheck
+
+			if err != nil {
+				t.Fatalf("unexpected ValueFromMsgPack error: %s", err)
+			}
+
+			got, err := codec.Unmarshal(data)
+
+			if err != nil {
+				t.Fatalf("unexpected Unmarshal error: %s", err)
+			}
+
+			if diff := cmp.Diff(expected, got); diff != "" {
+				t.Errorf("unexpected value difference: %s", diff)
+			}
+
+			if !expected.Type().Equal(got.Type()) {
+				t.Errorf("expected type %s, got %s", expected.Type(), got.Type())
+			}
+		})
+	}
+}
+
+func TestMsgPackCodecUnmarshal_errors(t *testing.T) {
+	t.Parallel()
+
+	objectType := Object{
+		AttributeTypes: map[string]Type{
+			"id": String,
+			"nested": List{ElementType: Object{
+				AttributeTypes: map[string]Type{"port": Number},
+			}},
+		},
+	}
+
+	testCases := map[string]struct {
+		typ Type
+		hex string
+	}{
+		"wrong-primitive": {
+			typ: String,
+			hex: "c3",
+		},
+		"nested-wrong-primitive": {
+			// {"id": "a", "nested": [{"port": true}]}
+			typ: objectType,
+			hex: "82a26964a161a66e65737465649181a4706f7274c3",
+		},
+		"unknown-attribute": {
+			// {"id": "a", "other": 1}
+			typ: objectType,
+			hex: "82a26964a161a56f7468657201",
+		},
+		"object-length": {
+			// {"id": "a"}
+			typ: objectType,
+			hex: "81a26964a161",
+		},
+		"tuple-length": {
+			// ["a"]
+			typ: Tuple{ElementTypes: []Type{String, String}},
+			hex: "91a161",
+		},
+		"map-element": {
+			// {"a": "b"}
+			typ: Map{ElementType: Number},
+			hex: "81a161a162",
+		},
+		"dynamic-type": {
+			// [["a", [true]]]
+			typ: List{ElementType: DynamicPseudoType},
+			hex: "9192c40822737472696e6722c3",
+		},
+		"truncated": {
+			typ: List{ElementType: String},
+			hex: "92a161",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			data, err := hex.DecodeString(testCase.hex)
+
+			if err != nil {
+				t.Fatalf("unexpected error decoding hex: %s", err)
+			}
+
+			_, expectedErr := ValueFromMsgPack(data, testCase.typ) //nolint:staticcheck
+
+			if expectedErr == nil {
+				t.Fatal("expected ValueFromMsgPack error, got none")
+			}
+
+			_, err = NewMsgPackCodec(testCase.typ).Unmarshal(data)
+
+			if err == nil {
+				t.Fatal("expected Unmarshal error, got none")
+			}
+
+			if diff := cmp.Diff(expectedErr.Error(), err.Error()); diff != "" {
+				t.Errorf("unexpected error difference: %s", diff)
+			}
+
+			var expectedPathErr, pathErr AttributePathError
+
+			if !errors.As(expectedErr, &expectedPathErr) || !errors.As(err, &pathErr) {
+				t.Fatalf("expected AttributePathErrors, got %#v and %#v", expectedErr, err)
+			}
+
+			if diff := cmp.Diff(expectedPathErr.Path.String(), pathErr.Path.String()); diff != "" {
+				t.Errorf("unexpected path difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestMsgPackCodecMarshal_errors(t *testing.T) {
+	t.Parallel()
+
+	typ := Object{
+		AttributeTypes: map[string]Type{
+			"list": List{ElementType: Object{
+				AttributeTypes: map[string]Type{"a": String, "b": String},
+			}},
+		},
+	}
+
+	val := Value{
+		typ: typ,
+		value: map[string]Value{
+			"list": {
+				typ: typ.AttributeTypes["list"],
+				value: []Value{
+					{
+						typ:   typ.AttributeTypes["list"].(List).ElementType,
+						value: map[string]Value{"a": NewValue(String, "one")},
+					},
+				},
+			},
+		},
+	}
+
+	_, err := NewMsgPackCodec(typ).Marshal(val)
+
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	var pathErr AttributePathError
+
+	if !errors.As(err, &pathErr) {
+		t.Fatalf("expected AttributePathError, got %#v", err)
+	}
+
+	expectedPath := NewAttributePath().WithAttributeName("list").WithElementKeyInt(0).WithAttributeName("b")
+
+	if !pathErr.Path.Equal(expectedPath) {
+		t.Errorf("expected path %s, got %s", expectedPath, pathErr.Path)
+	}
+
+	if diff := cmp.Diff("no value set", pathErr.Unwrap().Error()); diff != "" {
+		t.Errorf("unexpected error difference: %s", diff)
+	}
+}
+
+func TestNewMsgPackCodec_cache(t *testing.T) {
+	t.Parallel()
+
+	codec1 := NewMsgPackCodec(Object{AttributeTypes: map[string]Type{"a": String}})
+	codec2 := NewMsgPackCodec(Object{AttributeTypes: map[string]Type{"a": String}})
+
+	if codec1 != codec2 {
+		t.Error("expected the same codec for equal types")
+	}
+
+	if codec3 := NewMsgPackCodec(Object{AttributeTypes: map[string]Type{"a": Number}}); codec1 == codec3 {
+		t.Error("expected different codecs for different types")
+	}
+}
+
+func TestNewMsgPackCodec_cacheOptionalAttributes(t *testing.T) {
+	t.Parallel()
+
+	codec1 := NewMsgPackCodec(Object{
+		AttributeTypes:     map[string]Type{"a": String, "b": String},
+		OptionalAttributes: map[string]struct{}{"a": {}},
+	})
+	codec2 := NewMsgPackCodec(Object{
+		AttributeTypes:     map[string]Type{"a": String, "b": String},
+		OptionalAttributes: map[string]struct{}{"b": {}},
+	})
+
+	if codec1 == codec2 {
+		t.Error("expected different codecs for different optional attributes")
+	}
+}
+
+func TestMsgPackCodecUnmarshal_emptyMapNotShared(t *testing.T) {
+	t.Parallel()
+
+	typ := Map{ElementType: String}
+	codec := NewMsgPackCodec(typ)
+
+	data, err := NewValue(typ, map[string]Value{}).MarshalMsgPack(typ)
+
+	if err != nil {
+		t.Fatalf("unexpected error marshaling: %s", err)
+	}
+
+	decoded, err := codec.Unmarshal(data)
+
+	if err != nil {
+		t.Fatalf("unexpected error unmarshaling: %s", err)
+	}
+
+	var m map[string]Value
+
+	if err := decoded.As(&m); err != nil {
+		t.Fatalf("unexpected error converting: %s", err)
+	}
+
+	// Mutating the map of one decoded Value must not affect Values
+	// decoded later.
+	m["injected"] = NewValue(String, "injected")
+
+	got, err := codec.Unmarshal(data)
+
+	if err != nil {
+		t.Fatalf("unexpected error unmarshaling: %s", err)
+	}
+
+	expected := NewValue(typ, map[string]Value{})
+
+	if !got.Equal(expected) {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func mustParseFloat(s string) *big.Float {
+	f, _, err := big.ParseFloat(s, 10, 512, big.ToNearestEven)
+
+	if err != nil {
+		panic(err)
+	}
+
+	return f
+}
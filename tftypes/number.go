@@ -0,0 +1,167 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tftypes
+
+import (
+	"math"
+	"math/big"
+	"strconv"
+)
+
+// Number values created from Go integers or float64s keep them in a compact
+// representation: an int64, a uint64, or a float64. They are only promoted to
+// a *big.Float when one is required, such as by Value.As. The promoted
+// *big.Float is the same as the one that would have been created up front: it
+// has a precision of 64 bits for integers and 53 bits for floats.
+//
+// The compact numbers are still boxed in the interface{} of the Value, so this
+// saves one of the two allocations of a *big.Float per number, not both. The
+// gain is modest: decoding a MessagePack list of 1000 objects with three
+// numbers each (BenchmarkValueFromMsgPack1000) goes from about 56.3k to 53.1k
+// allocations and 2.34MB to 2.20MB, with no measurable change in time, as most
+// of the allocations are for attribute paths, strings, and maps. Storing the
+// numbers unboxed would make every Value larger.
+//
+// Number values created from a *big.Float, or decoded from a string or JSON,
+// keep the *big.Float.
+
+// isNumberValue returns true if `v` is one of the representations of a known
+// Number value.
+func isNumberValue(v interface{}) bool {
+	switch v.(type) {
+	case *big.Float, int64, uint64, float64:
+		return true
+	default:
+		return false
+	}
+}
+
+// numberBigFloat returns the Number value `v` as a *big.Float. The result must
+// not be modified, as it may be the *big.Float stored in the Value.
+func numberBigFloat(v interface{}) (*big.Float, bool) {
+	switch n := v.(type) {
+	case *big.Float:
+		return n, true
+	case int64:
+		return new(big.Float).SetInt64(n), true
+	case uint64:
+		return new(big.Float).SetUint64(n), true
+	case float64:
+		return big.NewFloat(n), true
+	default:
+		return nil, false
+	}
+}
+
+// setNumberBigFloat sets `target` to the Number value `v`, with the same
+// precision, rounding mode, and accuracy as if `v` had been promoted to a
+// *big.Float and copied into `target`, without the intermediate *big.Float.
+func setNumberBigFloat(target *big.Float, v interface{}) bool {
+	switch n := v.(type) {
+	case *big.Float:
+		target.Copy(n)
+	case int64:
+		target.SetMode(big.ToNearestEven).SetPrec(0).SetInt64(n)
+	case uint64:
+		target.SetMode(big.ToNearestEven).SetPrec(0).SetUint64(n)
+	case float64:
+		target.SetMode(big.ToNearestEven).SetPrec(0).SetFloat64(n)
+	default:
+		return false
+	}
+
+	return true
+}
+
+// numberInt64 returns the Number value `v` as an int64, if it is an integer
+// that can be represented exactly by one.
+func numberInt64(v interface{}) (int64, bool) {
+	switch n := v.(type) {
+	case int64:
+		return n, true
+	case uint64:
+		if n > math.MaxInt64 {
+			return 0, false
+		}
+
+		return int64(n), true
+	case float64:
+		if math.IsInf(n, 0) || math.Trunc(n) != n || n < math.MinInt64 || n >= math.MaxInt64 {
+			return 0, false
+		}
+
+		return int64(n), true
+	case *big.Float:
+		i, acc := n.Int64()
+
+		return i, acc == big.Exact
+	default:
+		return 0, false
+	}
+}
+
+// compareNumbers compares two Number values like big.Float.Cmp, without
+// promoting them to *big.Float when both are in the same compact
+// representation.
+func compareNumbers(v1, v2 interface{}) (int, bool) {
+	switch n1 := v1.(type) {
+	case int64:
+		if n2, ok := v2.(int64); ok {
+			return cmpOrdered(n1, n2), true
+		}
+	case uint64:
+		if n2, ok := v2.(uint64); ok {
+			return cmpOrdered(n1, n2), true
+		}
+	case float64:
+		if n2, ok := v2.(float64); ok {
+			return cmpOrdered(n1, n2), true
+		}
+	}
+
+	f1, ok := numberBigFloat(v1)
+
+	if !ok {
+		return 0, false
+	}
+
+	f2, ok := numberBigFloat(v2)
+
+	if !ok {
+		return 0, false
+	}
+
+	return f1.Cmp(f2), true
+}
+
+func cmpOrdered[T int64 | uint64 | float64](n1, n2 T) int {
+	switch {
+	case n1 < n2:
+		return -1
+	case n1 > n2:
+		return 1
+	default:
+		return 0
+	}
+}
+
+// numberKey returns a string that is the same for all numerically equal
+// Number values, regardless of their representation or precision, for use in
+// hashes and canonical encodings.
+func numberKey(v interface{}) (string, bool) {
+	if i, ok := numberInt64(v); ok {
+		return strconv.FormatInt(i, 10), true
+	}
+
+	n, ok := numberBigFloat(v)
+
+	if !ok {
+		return "", false
+	}
+
+	// The binary mantissa and exponent format is exact and does not depend
+	// on the precision of the big.Float. Zero is handled as an integer
+	// above, so positive and negative zero are not distinguished.
+	return n.Text('p', 0), true
+}
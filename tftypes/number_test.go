@@ -0,0 +1,119 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tftypes
+
+import (
+	"bytes"
+	"math"
+	"math/big"
+	"testing"
+)
+
+// TestNumberCompact ensures Number values kept in a compact representation
+// behave exactly like the *big.Float they would previously have been promoted
+// to on creation.
+func TestNumberCompact(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		compact  interface{}
+		expected *big.Float
+	}{
+		"int64": {
+			compact:  int64(8080),
+			expected: new(big.Float).SetInt64(8080),
+		},
+		"int64-min": {
+			compact:  int64(math.MinInt64),
+			expected: new(big.Float).SetInt64(math.MinInt64),
+		},
+		"uint64-max": {
+			compact:  uint64(math.MaxUint64),
+			expected: new(big.Float).SetUint64(math.MaxUint64),
+		},
+		"float64": {
+			compact:  1.5,
+			expected: big.NewFloat(1.5),
+		},
+		"float64-integer": {
+			compact:  float64(42),
+			expected: big.NewFloat(42),
+		},
+		"float64-large-integer": {
+			compact:  1e20,
+			expected: big.NewFloat(1e20),
+		},
+		"float64-negative-zero": {
+			compact:  math.Copysign(0, -1),
+			expected: big.NewFloat(math.Copysign(0, -1)),
+		},
+		"float64-infinity": {
+			compact:  math.Inf(1),
+			expected: big.NewFloat(math.Inf(1)),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			compact := NewValue(Number, testCase.compact)
+			expected := NewValue(Number, testCase.expected)
+
+			if _, ok := compact.value.(*big.Float); ok {
+				t.Fatalf("expected compact representation, got *big.Float")
+			}
+
+			// Use a target with a different precision and rounding
+			// mode, which As must overwrite.
+			got := new(big.Float).SetPrec(512).SetMode(big.ToZero)
+
+			if err := compact.As(&got); err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if got.Cmp(testCase.expected) != 0 || got.Prec() != testCase.expected.Prec() || got.Mode() != testCase.expected.Mode() || got.Signbit() != testCase.expected.Signbit() {
+				t.Errorf("expected %s with precision %d and mode %s, got %s with precision %d and mode %s", testCase.expected.Text('g', -1), testCase.expected.Prec(), testCase.expected.Mode(), got.Text('g', -1), got.Prec(), got.Mode())
+			}
+
+			if !compact.Equal(expected) || !expected.Equal(compact) {
+				t.Errorf("expected %s to equal %s", compact, expected)
+			}
+
+			if compact.Hash() != expected.Hash() {
+				t.Errorf("expected hashes of %s and %s to be equal", compact, expected)
+			}
+
+			compactMsgPack, err := compact.MarshalMsgPack(Number) //nolint:staticcheck
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			expectedMsgPack, err := expected.MarshalMsgPack(Number) //nolint:staticcheck
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if !bytes.Equal(compactMsgPack, expectedMsgPack) {
+				t.Errorf("expected MessagePack %x, got %x", expectedMsgPack, compactMsgPack)
+			}
+		})
+	}
+}
+
+func TestNumberCompact_NaN(t *testing.T) {
+	t.Parallel()
+
+	err := ValidateValue(Number, math.NaN())
+
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	expected := "tftypes.NewValue can't use NaN as a tftypes.Number"
+
+	if err.Error() != expected {
+		t.Errorf("expected error %q, got %q", expected, err)
+	}
+}
@@ -0,0 +1,547 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tftypes
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+)
+
+// PatchOperationType is the kind of change a PatchOperation makes.
+type PatchOperationType string
+
+const (
+	// PatchOperationAdd adds an element to a map, list, or set. The
+	// AttributePath of the operation ends with the ElementKeyString of the
+	// new map element, the ElementKeyInt the new list element is inserted
+	// at, or the ElementKeyValue of the new set element.
+	PatchOperationAdd PatchOperationType = "add"
+
+	// PatchOperationRemove removes the element of a map, list, or set at
+	// the AttributePath of the operation.
+	PatchOperationRemove PatchOperationType = "remove"
+
+	// PatchOperationReplace replaces the Value at the AttributePath of the
+	// operation, which may be any Value, including the root Value.
+	PatchOperationReplace PatchOperationType = "replace"
+)
+
+// PatchOperation is a single change to a Value.
+type PatchOperation struct {
+	// Type is the kind of change.
+	Type PatchOperationType
+
+	// Path is the location of the change, relative to the root Value.
+	Path *AttributePath
+
+	// Value is the Value to add or replace. It is not set for
+	// PatchOperationRemove.
+	Value Value
+}
+
+func (o PatchOperation) String() string {
+	if o.Type == PatchOperationRemove {
+		return fmt.Sprintf("%s %s", o.Type, o.Path)
+	}
+
+	return fmt.Sprintf("%s %s: %s", o.Type, o.Path, o.Value)
+}
+
+// Patch expresses a change between two Values as a list of operations, which
+// can be applied to a Value to make the same change to it.
+//
+// Patches can be serialized to JSON and back with encoding/json. Values in
+// the JSON representation include their Type, so a Patch can be decoded
+// without knowing the Type of the Value it applies to. Unknown values cannot
+// be represented in JSON.
+type Patch struct {
+	Operations []PatchOperation
+}
+
+// NewPatch returns the Patch that changes `val1` into `val2`. Changes are
+// made to the most deeply nested elements and attributes possible, so
+// elements and attributes that did not change are not included in the
+// Patch. An empty Patch means the Values are equal.
+func NewPatch(val1, val2 Value) (Patch, error) {
+	if val1.Type() == nil {
+		return Patch{}, errors.New("cannot create patch from value missing type")
+	}
+
+	if val2.Type() == nil {
+		return Patch{}, errors.New("cannot create patch to value missing type")
+	}
+
+	var patch Patch
+
+	patch.diff(NewAttributePath(), val1, val2)
+
+	return patch, nil
+}
+
+func (p *Patch) diff(path *AttributePath, val1, val2 Value) {
+	if val1.Equal(val2) {
+		return
+	}
+
+	if !val1.IsKnown() || val1.IsNull() || !val2.IsKnown() || val2.IsNull() || !val1.Type().Equal(val2.Type()) {
+		p.replace(path, val2)
+
+		return
+	}
+
+	switch val1.Type().(type) {
+	case Object:
+		//nolint:forcetypeassert // Object values are always map[string]Value
+		attrs1, attrs2 := val1.value.(map[string]Value), val2.value.(map[string]Value)
+
+		for _, k := range sortedPatchKeys(attrs1) {
+			p.diff(path.WithAttributeName(k), attrs1[k], attrs2[k])
+		}
+	case Map:
+		//nolint:forcetypeassert // Map values are always map[string]Value
+		elems1, elems2 := val1.value.(map[string]Value), val2.value.(map[string]Value)
+
+		for _, k := range sortedPatchKeys(elems1) {
+			if _, ok := elems2[k]; !ok {
+				p.Operations = append(p.Operations, PatchOperation{
+					Type: PatchOperationRemove,
+					Path: path.WithElementKeyString(k),
+				})
+			}
+		}
+
+		for _, k := range sortedPatchKeys(elems2) {
+			el1, ok := elems1[k]
+
+			if !ok {
+				p.Operations = append(p.Operations, PatchOperation{
+					Type:  PatchOperationAdd,
+					Path:  path.WithElementKeyString(k),
+					Value: elems2[k],
+				})
+
+				continue
+			}
+
+			p.diff(path.WithElementKeyString(k), el1, elems2[k])
+		}
+	case List, Tuple:
+		//nolint:forcetypeassert // List and Tuple values are always []Value
+		elems1, elems2 := val1.value.([]Value), val2.value.([]Value)
+
+		for pos := 0; pos < min(len(elems1), len(elems2)); pos++ {
+			p.diff(path.WithElementKeyInt(pos), elems1[pos], elems2[pos])
+		}
+
+		// Elements are removed from the end, so the indexes of the
+		// remaining elements do not change.
+		for pos := len(elems1) - 1; pos >= len(elems2); pos-- {
+			p.Operations = append(p.Operations, PatchOperation{
+				Type: PatchOperationRemove,
+				Path: path.WithElementKeyInt(pos),
+			})
+		}
+
+		for pos := len(elems1); pos < len(elems2); pos++ {
+			p.Operations = append(p.Operations, PatchOperation{
+				Type:  PatchOperationAdd,
+				Path:  path.WithElementKeyInt(pos),
+				Value: elems2[pos],
+			})
+		}
+	case Set:
+		removed, err := missingSetElements(val1, val2)
+
+		if err != nil {
+			p.replace(path, val2)
+
+			return
+		}
+
+		added, err := missingSetElements(val2, val1)
+
+		if err != nil {
+			p.replace(path, val2)
+
+			return
+		}
+
+		for _, el := range removed {
+			p.Operations = append(p.Operations, PatchOperation{
+				Type: PatchOperationRemove,
+				Path: path.WithElementKeyValue(el),
+			})
+		}
+
+		for _, el := range added {
+			p.Operations = append(p.Operations, PatchOperation{
+				Type:  PatchOperationAdd,
+				Path:  path.WithElementKeyValue(el),
+				Value: el,
+			})
+		}
+	default:
+		p.replace(path, val2)
+	}
+}
+
+func (p *Patch) replace(path *AttributePath, val Value) {
+	p.Operations = append(p.Operations, PatchOperation{
+		Type:  PatchOperationReplace,
+		Path:  path,
+		Value: val,
+	})
+}
+
+func sortedPatchKeys(m map[string]Value) []string {
+	keys := make([]string, 0, len(m))
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// IsEmpty returns true if the Patch has no operations.
+func (p Patch) IsEmpty() bool {
+	return len(p.Operations) == 0
+}
+
+func (p Patch) String() string {
+	var res strings.Builder
+
+	for pos, op := range p.Operations {
+		if pos != 0 {
+			res.WriteString("\n")
+		}
+
+		res.WriteString(op.String())
+	}
+
+	return res.String()
+}
+
+// Apply returns the result of applying the operations of the Patch to `val`,
+// in order. If an operation does not apply, because an element or attribute
+// it changes does not exist, an element it adds already exists, or the
+// Value it adds or replaces has the wrong type, an AttributePathError with
+// the AttributePath of the operation is returned, which describes the
+// operation and why it does not apply.
+func (p Patch) Apply(val Value) (Value, error) {
+	for pos, op := range p.Operations {
+		var err error
+
+		val, err = applyPatchOperation(val, op, op.Path.Steps())
+
+		if err != nil {
+			return Value{}, AttributePathError{
+				Path: NewAttributePathWithSteps(op.Path.Steps()),
+				err:  fmt.Errorf("patch operation %d (%s) does not apply: %w", pos, op.Type, err),
+			}
+		}
+	}
+
+	return val, nil
+}
+
+func applyPatchOperation(val Value, op PatchOperation, steps []AttributePathStep) (Value, error) {
+	if len(steps) == 0 {
+		if op.Type != PatchOperationReplace {
+			return Value{}, fmt.Errorf("%s operations require the path of an element", op.Type)
+		}
+
+		return op.Value, nil
+	}
+
+	if !val.IsKnown() || val.IsNull() {
+		return Value{}, ErrInvalidStep
+	}
+
+	if len(steps) == 1 && op.Type != PatchOperationReplace {
+		return applyPatchElementOperation(val, op, steps[0])
+	}
+
+	child, err := val.ApplyTerraform5AttributePathStep(steps[0])
+
+	if err != nil {
+		return Value{}, err
+	}
+
+	//nolint:forcetypeassert // Value.ApplyTerraform5AttributePathStep always returns a Value
+	newChild, err := applyPatchOperation(child.(Value), op, steps[1:])
+
+	if err != nil {
+		return Value{}, err
+	}
+
+	var newVal interface{}
+
+	switch v := val.value.(type) {
+	case map[string]Value:
+		elems := make(map[string]Value, len(v))
+
+		for k, el := range v {
+			elems[k] = el
+		}
+
+		switch step := steps[0].(type) {
+		case AttributeName:
+			elems[string(step)] = newChild
+		case ElementKeyString:
+			elems[string(step)] = newChild
+		}
+
+		newVal = elems
+	case []Value:
+		elems := slices.Clone(v)
+
+		switch step := steps[0].(type) {
+		case ElementKeyInt:
+			elems[step] = newChild
+		case ElementKeyValue:
+			for pos, el := range elems {
+				if el.Equal(Value(step)) {
+					elems[pos] = newChild
+
+					break
+				}
+			}
+		}
+
+		newVal = elems
+	}
+
+	return newValue(val.Type(), newVal)
+}
+
+// applyPatchElementOperation applies an add or remove operation to the element
+// of `val` at `step`.
+func applyPatchElementOperation(val Value, op PatchOperation, step AttributePathStep) (Value, error) {
+	var newVal interface{}
+
+	switch typ := val.Type().(type) {
+	case Map:
+		key, ok := step.(ElementKeyString)
+
+		if !ok {
+			return Value{}, ErrInvalidStep
+		}
+
+		//nolint:forcetypeassert // Map values are always map[string]Value
+		elems := val.value.(map[string]Value)
+		_, exists := elems[string(key)]
+
+		switch {
+		case op.Type == PatchOperationAdd && exists:
+			return Value{}, errors.New("map element already exists")
+		case op.Type == PatchOperationRemove && !exists:
+			return Value{}, ErrInvalidStep
+		}
+
+		newElems := make(map[string]Value, len(elems)+1)
+
+		for k, el := range elems {
+			newElems[k] = el
+		}
+
+		if op.Type == PatchOperationAdd {
+			newElems[string(key)] = op.Value
+		} else {
+			delete(newElems, string(key))
+		}
+
+		newVal = newElems
+	case List:
+		index, ok := step.(ElementKeyInt)
+
+		if !ok {
+			return Value{}, ErrInvalidStep
+		}
+
+		//nolint:forcetypeassert // List values are always []Value
+		elems := val.value.([]Value)
+
+		// Elements can be added after the last element.
+		last := int64(len(elems)) - 1
+
+		if op.Type == PatchOperationAdd {
+			last++
+		}
+
+		if int64(index) < 0 || int64(index) > last {
+			return Value{}, ErrInvalidStep
+		}
+
+		if op.Type == PatchOperationAdd {
+			newVal = slices.Insert(slices.Clone(elems), int(index), op.Value)
+		} else {
+			newVal = slices.Delete(slices.Clone(elems), int(index), int(index)+1)
+		}
+	case Set:
+		key, ok := step.(ElementKeyValue)
+
+		if !ok {
+			return Value{}, ErrInvalidStep
+		}
+
+		//nolint:forcetypeassert // Set values are always []Value
+		elems := val.value.([]Value)
+		pos := slices.IndexFunc(elems, func(el Value) bool {
+			return el.Equal(Value(key))
+		})
+
+		switch {
+		case op.Type == PatchOperationAdd && pos >= 0:
+			return Value{}, errors.New("set element already exists")
+		case op.Type == PatchOperationAdd && !op.Value.Equal(Value(key)):
+			return Value{}, errors.New("added set element must match the path")
+		case op.Type == PatchOperationRemove && pos < 0:
+			return Value{}, ErrInvalidStep
+		}
+
+		if op.Type == PatchOperationAdd {
+			newVal = append(slices.Clone(elems), op.Value)
+		} else {
+			newVal = slices.Delete(slices.Clone(elems), pos, pos+1)
+		}
+	default:
+		return Value{}, fmt.Errorf("%s operations can only be applied to elements of maps, lists, and sets, not %s", op.Type, typ)
+	}
+
+	return newValue(val.Type(), newVal)
+}
+
+type patchOperationJSON struct {
+	Op    PatchOperationType `json:"op"`
+	Path  []patchStepJSON    `json:"path"`
+	Value json.RawMessage    `json:"value,omitempty"`
+}
+
+type patchStepJSON struct {
+	AttributeName    *string         `json:"attribute_name,omitempty"`
+	ElementKeyString *string         `json:"element_key_string,omitempty"`
+	ElementKeyInt    *int64          `json:"element_key_int,omitempty"`
+	ElementKeyValue  json.RawMessage `json:"element_key_value,omitempty"`
+}
+
+// MarshalJSON returns the JSON representation of the Patch: an array of
+// operations, each with the "op" type, the "path" as an array of steps, and
+// for add and replace operations, the "value" with its type.
+func (p Patch) MarshalJSON() ([]byte, error) {
+	ops := make([]patchOperationJSON, 0, len(p.Operations))
+
+	for pos, op := range p.Operations {
+		opJSON := patchOperationJSON{
+			Op:   op.Type,
+			Path: []patchStepJSON{},
+		}
+
+		for _, step := range op.Path.Steps() {
+			var stepJSON patchStepJSON
+
+			switch step := step.(type) {
+			case AttributeName:
+				name := string(step)
+				stepJSON.AttributeName = &name
+			case ElementKeyString:
+				key := string(step)
+				stepJSON.ElementKeyString = &key
+			case ElementKeyInt:
+				index := int64(step)
+				stepJSON.ElementKeyInt = &index
+			case ElementKeyValue:
+				value, err := jsonMarshalDynamic(Value(step), NewAttributePath())
+				if err != nil {
+					return nil, fmt.Errorf("error encoding path of patch operation %d: %w", pos, err)
+				}
+				stepJSON.ElementKeyValue = value
+			default:
+				return nil, fmt.Errorf("error encoding path of patch operation %d: unexpected AttributePathStep type %T", pos, step)
+			}
+
+			opJSON.Path = append(opJSON.Path, stepJSON)
+		}
+
+		if op.Type != PatchOperationRemove {
+			value, err := jsonMarshalDynamic(op.Value, op.Path)
+			if err != nil {
+				return nil, fmt.Errorf("error encoding value of patch operation %d: %w", pos, err)
+			}
+			opJSON.Value = value
+		}
+
+		ops = append(ops, opJSON)
+	}
+
+	return json.Marshal(ops)
+}
+
+// UnmarshalJSON decodes the JSON representation of a Patch, as returned by
+// MarshalJSON.
+func (p *Patch) UnmarshalJSON(data []byte) error {
+	var ops []patchOperationJSON
+
+	err := json.Unmarshal(data, &ops)
+	if err != nil {
+		return fmt.Errorf("error decoding patch: %w", err)
+	}
+
+	operations := make([]PatchOperation, 0, len(ops))
+
+	for pos, opJSON := range ops {
+		op := PatchOperation{
+			Type: opJSON.Op,
+			Path: NewAttributePath(),
+		}
+
+		switch op.Type {
+		case PatchOperationAdd, PatchOperationRemove, PatchOperationReplace:
+		default:
+			return fmt.Errorf("error decoding patch operation %d: unknown operation %q", pos, op.Type)
+		}
+
+		for _, stepJSON := range opJSON.Path {
+			switch {
+			case stepJSON.AttributeName != nil:
+				op.Path = op.Path.WithAttributeName(*stepJSON.AttributeName)
+			case stepJSON.ElementKeyString != nil:
+				op.Path = op.Path.WithElementKeyString(*stepJSON.ElementKeyString)
+			case stepJSON.ElementKeyInt != nil:
+				op.Path = op.Path.WithElementKeyInt(int(*stepJSON.ElementKeyInt))
+			case stepJSON.ElementKeyValue != nil:
+				value, err := ValueFromJSON(stepJSON.ElementKeyValue, DynamicPseudoType)
+				if err != nil {
+					return fmt.Errorf("error decoding path of patch operation %d: %w", pos, err)
+				}
+				op.Path = op.Path.WithElementKeyValue(value)
+			default:
+				return fmt.Errorf("error decoding path of patch operation %d: empty step", pos)
+			}
+		}
+
+		if op.Type != PatchOperationRemove {
+			if opJSON.Value == nil {
+				return fmt.Errorf("error decoding patch operation %d: missing value", pos)
+			}
+
+			op.Value, err = ValueFromJSON(opJSON.Value, DynamicPseudoType)
+			if err != nil {
+				return fmt.Errorf("error decoding value of patch operation %d: %w", pos, err)
+			}
+		}
+
+		operations = append(operations, op)
+	}
+
+	p.Operations = operations
+
+	return nil
+}
@@ -0,0 +1,412 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tftypes
+
+import (
+	"encoding/json"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func testPatchValues() (Object, Value, Value) {
+	ruleType := Object{AttributeTypes: map[string]Type{"port": Number}}
+	typ := Object{
+		AttributeTypes: map[string]Type{
+			"id":      String,
+			"tags":    Map{ElementType: String},
+			"names":   List{ElementType: String},
+			"rules":   Set{ElementType: ruleType},
+			"pair":    Tuple{ElementTypes: []Type{String, Number}},
+			"dynamic": DynamicPseudoType,
+			"ratio":   Number,
+		},
+	}
+
+	val1 := NewValue(typ, map[string]Value{
+		"id": NewValue(String, "abc123"),
+		"tags": NewValue(Map{ElementType: String}, map[string]Value{
+			"env":  NewValue(String, "test"),
+			"team": NewValue(String, "infra"),
+		}),
+		"names": NewValue(List{ElementType: String}, []Value{
+			NewValue(String, "a"),
+			NewValue(String, "b"),
+			NewValue(String, "c"),
+		}),
+		"rules": NewValue(Set{ElementType: ruleType}, []Value{
+			NewValue(ruleType, map[string]Value{"port": NewValue(Number, 22)}),
+			NewValue(ruleType, map[string]Value{"port": NewValue(Number, 80)}),
+		}),
+		"pair": NewValue(Tuple{ElementTypes: []Type{String, Number}}, []Value{
+			NewValue(String, "a"),
+			NewValue(Number, 1),
+		}),
+		"dynamic": NewValue(String, "x"),
+		"ratio":   NewValue(Number, 0.1),
+	})
+
+	val2 := NewValue(typ, map[string]Value{
+		"id": NewValue(String, "abc123"),
+		"tags": NewValue(Map{ElementType: String}, map[string]Value{
+			"env":   NewValue(String, "prod"),
+			"owner": NewValue(String, "me"),
+		}),
+		"names": NewValue(List{ElementType: String}, []Value{
+			NewValue(String, "a"),
+		}),
+		"rules": NewValue(Set{ElementType: ruleType}, []Value{
+			NewValue(ruleType, map[string]Value{"port": NewValue(Number, 80)}),
+			NewValue(ruleType, map[string]Value{"port": NewValue(Number, 443)}),
+		}),
+		"pair": NewValue(Tuple{ElementTypes: []Type{String, Number}}, []Value{
+			NewValue(String, "a"),
+			NewValue(Number, 2),
+		}),
+		"dynamic": NewValue(List{ElementType: Bool}, []Value{NewValue(Bool, true)}),
+		"ratio":   NewValue(Number, 0.25),
+	})
+
+	return typ, val1, val2
+}
+
+func TestNewPatch(t *testing.T) {
+	t.Parallel()
+
+	typ, val1, val2 := testPatchValues()
+	ruleType := typ.AttributeTypes["rules"].(Set).ElementType //nolint:forcetypeassert
+
+	testCases := map[string]struct {
+		val1     Value
+		val2     Value
+		expected Patch
+	}{
+		"equal": {
+			val1:     val1,
+			val2:     val1,
+			expected: Patch{},
+		},
+		"root": {
+			val1: NewValue(String, "a"),
+			val2: NewValue(String, "b"),
+			expected: Patch{
+				Operations: []PatchOperation{
+					{
+						Type:  PatchOperationReplace,
+						Path:  NewAttributePath(),
+						Value: NewValue(String, "b"),
+					},
+				},
+			},
+		},
+		"null": {
+			val1: NewValue(typ, nil),
+			val2: val2,
+			expected: Patch{
+				Operations: []PatchOperation{
+					{
+						Type:  PatchOperationReplace,
+						Path:  NewAttributePath(),
+						Value: val2,
+					},
+				},
+			},
+		},
+		"nested": {
+			val1: val1,
+			val2: val2,
+			expected: Patch{
+				Operations: []PatchOperation{
+					{
+						Type:  PatchOperationReplace,
+						Path:  NewAttributePath().WithAttributeName("dynamic"),
+						Value: NewValue(List{ElementType: Bool}, []Value{NewValue(Bool, true)}),
+					},
+					{
+						Type: PatchOperationRemove,
+						Path: NewAttributePath().WithAttributeName("names").WithElementKeyInt(2),
+					},
+					{
+						Type: PatchOperationRemove,
+						Path: NewAttributePath().WithAttributeName("names").WithElementKeyInt(1),
+					},
+					{
+						Type:  PatchOperationReplace,
+						Path:  NewAttributePath().WithAttributeName("pair").WithElementKeyInt(1),
+						Value: NewValue(Number, 2),
+					},
+					{
+						Type:  PatchOperationReplace,
+						Path:  NewAttributePath().WithAttributeName("ratio"),
+						Value: NewValue(Number, 0.25),
+					},
+					{
+						Type: PatchOperationRemove,
+						Path: NewAttributePath().WithAttributeName("rules").WithElementKeyValue(
+							NewValue(ruleType, map[string]Value{"port": NewValue(Number, 22)}),
+						),
+					},
+					{
+						Type: PatchOperationAdd,
+						Path: NewAttributePath().WithAttributeName("rules").WithElementKeyValue(
+							NewValue(ruleType, map[string]Value{"port": NewValue(Number, 443)}),
+						),
+						Value: NewValue(ruleType, map[string]Value{"port": NewValue(Number, 443)}),
+					},
+					{
+						Type: PatchOperationRemove,
+						Path: NewAttributePath().WithAttributeName("tags").WithElementKeyString("team"),
+					},
+					{
+						Type:  PatchOperationReplace,
+						Path:  NewAttributePath().WithAttributeName("tags").WithElementKeyString("env"),
+						Value: NewValue(String, "prod"),
+					},
+					{
+						Type:  PatchOperationAdd,
+						Path:  NewAttributePath().WithAttributeName("tags").WithElementKeyString("owner"),
+						Value: NewValue(String, "me"),
+					},
+				},
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := NewPatch(testCase.val1, testCase.val2)
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+
+			applied, err := got.Apply(testCase.val1)
+
+			if err != nil {
+				t.Fatalf("unexpected Apply error: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.val2, applied); diff != "" {
+				t.Errorf("unexpected difference applying patch: %s", diff)
+			}
+		})
+	}
+}
+
+func TestPatchApply_errors(t *testing.T) {
+	t.Parallel()
+
+	typ, val1, _ := testPatchValues()
+
+	testCases := map[string]struct {
+		op            PatchOperation
+		expectedPath  *AttributePath
+		expectedError string
+	}{
+		"missing-attribute": {
+			op: PatchOperation{
+				Type:  PatchOperationReplace,
+				Path:  NewAttributePath().WithAttributeName("missing"),
+				Value: NewValue(String, "x"),
+			},
+			expectedError: "patch operation 0 (replace) does not apply: step cannot be applied to this value",
+		},
+		"wrong-type": {
+			op: PatchOperation{
+				Type:  PatchOperationReplace,
+				Path:  NewAttributePath().WithAttributeName("id"),
+				Value: NewValue(Number, 1),
+			},
+			expectedError: "patch operation 0 (replace) does not apply: ",
+		},
+		"add-existing-map-element": {
+			op: PatchOperation{
+				Type:  PatchOperationAdd,
+				Path:  NewAttributePath().WithAttributeName("tags").WithElementKeyString("env"),
+				Value: NewValue(String, "x"),
+			},
+			expectedError: "patch operation 0 (add) does not apply: map element already exists",
+		},
+		"remove-missing-list-element": {
+			op: PatchOperation{
+				Type: PatchOperationRemove,
+				Path: NewAttributePath().WithAttributeName("names").WithElementKeyInt(3),
+			},
+			expectedError: "patch operation 0 (remove) does not apply: step cannot be applied to this value",
+		},
+		"remove-missing-set-element": {
+			op: PatchOperation{
+				Type: PatchOperationRemove,
+				Path: NewAttributePath().WithAttributeName("rules").WithElementKeyValue(
+					NewValue(typ.AttributeTypes["rules"].(Set).ElementType, map[string]Value{"port": NewValue(Number, 1)}), //nolint:forcetypeassert
+				),
+			},
+			expectedError: "patch operation 0 (remove) does not apply: step cannot be applied to this value",
+		},
+		"remove-attribute": {
+			op: PatchOperation{
+				Type: PatchOperationRemove,
+				Path: NewAttributePath().WithAttributeName("id"),
+			},
+			expectedError: "patch operation 0 (remove) does not apply: remove operations can only be applied to elements of maps, lists, and sets, not tftypes.Object",
+		},
+		"add-root": {
+			op: PatchOperation{
+				Type:  PatchOperationAdd,
+				Path:  NewAttributePath(),
+				Value: NewValue(String, "x"),
+			},
+			expectedError: "patch operation 0 (add) does not apply: add operations require the path of an element",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			_, err := Patch{Operations: []PatchOperation{testCase.op}}.Apply(val1)
+
+			if err == nil {
+				t.Fatal("expected error, got none")
+			}
+
+			var pathErr AttributePathError
+
+			if !errors.As(err, &pathErr) {
+				t.Fatalf("expected AttributePathError, got %#v", err)
+			}
+
+			if !pathErr.Path.Equal(testCase.op.Path) {
+				t.Errorf("expected path %s, got %s", testCase.op.Path, pathErr.Path)
+			}
+
+			if !strings.HasPrefix(pathErr.Unwrap().Error(), testCase.expectedError) {
+				t.Errorf("expected error %q, got %q", testCase.expectedError, pathErr.Unwrap().Error())
+			}
+		})
+	}
+}
+
+func TestPatchJSON(t *testing.T) {
+	t.Parallel()
+
+	_, val1, val2 := testPatchValues()
+
+	patch, err := NewPatch(val1, val2)
+
+	if err != nil {
+		t.Fatalf("unexpected error: %s", err)
+	}
+
+	data, err := json.Marshal(patch)
+
+	if err != nil {
+		t.Fatalf("unexpected Marshal error: %s", err)
+	}
+
+	expectedJSON := `[` +
+		`{"op":"replace","path":[{"attribute_name":"dynamic"}],"value":{"type":["list","bool"],"value":[true]}},` +
+		`{"op":"remove","path":[{"attribute_name":"names"},{"element_key_int":2}]},` +
+		`{"op":"remove","path":[{"attribute_name":"names"},{"element_key_int":1}]},` +
+		`{"op":"replace","path":[{"attribute_name":"pair"},{"element_key_int":1}],"value":{"type":"number","value":2}},` +
+		`{"op":"replace","path":[{"attribute_name":"ratio"}],"value":{"type":"number","value":0.25}},` +
+		`{"op":"remove","path":[{"attribute_name":"rules"},{"element_key_value":{"type":["object",{"port":"number"}],"value":{"port":22}}}]},` +
+		`{"op":"add","path":[{"attribute_name":"rules"},{"element_key_value":{"type":["object",{"port":"number"}],"value":{"port":443}}}],"value":{"type":["object",{"port":"number"}],"value":{"port":443}}},` +
+		`{"op":"remove","path":[{"attribute_name":"tags"},{"element_key_string":"team"}]},` +
+		`{"op":"replace","path":[{"attribute_name":"tags"},{"element_key_string":"env"}],"value":{"type":"string","value":"prod"}},` +
+		`{"op":"add","path":[{"attribute_name":"tags"},{"element_key_string":"owner"}],"value":{"type":"string","value":"me"}}` +
+		`]`
+
+	if diff := cmp.Diff(expectedJSON, string(data)); diff != "" {
+		t.Errorf("unexpected JSON difference: %s", diff)
+	}
+
+	var decoded Patch
+
+	err = json.Unmarshal(data, &decoded)
+
+	if err != nil {
+		t.Fatalf("unexpected Unmarshal error: %s", err)
+	}
+
+	if diff := cmp.Diff(patch, decoded); diff != "" {
+		t.Errorf("unexpected decoded difference: %s", diff)
+	}
+
+	applied, err := decoded.Apply(val1)
+
+	if err != nil {
+		t.Fatalf("unexpected Apply error: %s", err)
+	}
+
+	if diff := cmp.Diff(val2, applied); diff != "" {
+		t.Errorf("unexpected difference applying decoded patch: %s", diff)
+	}
+}
+
+func TestPatchJSON_exactNumbers(t *testing.T) {
+	t.Parallel()
+
+	patch := Patch{
+		Operations: []PatchOperation{
+			{
+				Type:  PatchOperationReplace,
+				Path:  NewAttributePath(),
+				Value: NewValue(Number, 0.1),
+			},
+		},
+	}
+
+	data, err := json.Marshal(patch)
+
+	if err != nil {
+		t.Fatalf("unexpected Marshal error: %s", err)
+	}
+
+	var decoded Patch
+
+	err = json.Unmarshal(data, &decoded)
+
+	if err != nil {
+		t.Fatalf("unexpected Unmarshal error: %s", err)
+	}
+
+	if !decoded.Operations[0].Value.Equal(NewValue(Number, 0.1)) {
+		t.Errorf("expected %s, got %s", NewValue(Number, 0.1), decoded.Operations[0].Value)
+	}
+}
+
+func TestPatchJSON_unknown(t *testing.T) {
+	t.Parallel()
+
+	patch := Patch{
+		Operations: []PatchOperation{
+			{
+				Type:  PatchOperationReplace,
+				Path:  NewAttributePath().WithAttributeName("id"),
+				Value: NewValue(String, UnknownValue),
+			},
+		},
+	}
+
+	_, err := json.Marshal(patch)
+
+	if err == nil {
+		t.Fatal("expected error, got none")
+	}
+
+	expected := `error encoding value of patch operation 0: AttributeName("id"): unknown values cannot be encoded as JSON`
+
+	if !strings.Contains(err.Error(), expected) {
+		t.Errorf("expected error containing %q, got %q", expected, err.Error())
+	}
+}
@@ -4,7 +4,9 @@
 package tftypes
 
 import (
+	"errors"
 	"fmt"
+	"math"
 	"math/big"
 )
 
@@ -190,7 +192,7 @@ func valueFromNumber(in interface{}) (Value, error) {
 	case uint:
 		return Value{
 			typ:   Number,
-			value: new(big.Float).SetUint64(uint64(value)),
+			value: uint64(value),
 		}, nil
 	case *uint:
 		if value == nil {
@@ -201,12 +203,12 @@ func valueFromNumber(in interface{}) (Value, error) {
 		}
 		return Value{
 			typ:   Number,
-			value: new(big.Float).SetUint64(uint64(*value)),
+			value: uint64(*value),
 		}, nil
 	case uint8:
 		return Value{
 			typ:   Number,
-			value: new(big.Float).SetUint64(uint64(value)),
+			value: uint64(value),
 		}, nil
 	case *uint8:
 		if value == nil {
@@ -217,12 +219,12 @@ func valueFromNumber(in interface{}) (Value, error) {
 		}
 		return Value{
 			typ:   Number,
-			value: new(big.Float).SetUint64(uint64(*value)),
+			value: uint64(*value),
 		}, nil
 	case uint16:
 		return Value{
 			typ:   Number,
-			value: new(big.Float).SetUint64(uint64(value)),
+			value: uint64(value),
 		}, nil
 	case *uint16:
 		if value == nil {
@@ -233,12 +235,12 @@ func valueFromNumber(in interface{}) (Value, error) {
 		}
 		return Value{
 			typ:   Number,
-			value: new(big.Float).SetUint64(uint64(*value)),
+			value: uint64(*value),
 		}, nil
 	case uint32:
 		return Value{
 			typ:   Number,
-			value: new(big.Float).SetUint64(uint64(value)),
+			value: uint64(value),
 		}, nil
 	case *uint32:
 		if value == nil {
@@ -249,12 +251,13 @@ func valueFromNumber(in interface{}) (Value, error) {
 		}
 		return Value{
 			typ:   Number,
-			value: new(big.Float).SetUint64(uint64(*value)),
+			value: uint64(*value),
 		}, nil
 	case uint64:
+		// Reuse the interface value, to avoid allocating a new one.
 		return Value{
 			typ:   Number,
-			value: new(big.Float).SetUint64(value),
+			value: in,
 		}, nil
 	case *uint64:
 		if value == nil {
@@ -265,12 +268,12 @@ func valueFromNumber(in interface{}) (Value, error) {
 		}
 		return Value{
 			typ:   Number,
-			value: new(big.Float).SetUint64(*value),
+			value: *value,
 		}, nil
 	case int:
 		return Value{
 			typ:   Number,
-			value: new(big.Float).SetInt64(int64(value)),
+			value: int64(value),
 		}, nil
 	case *int:
 		if value == nil {
@@ -281,12 +284,12 @@ func valueFromNumber(in interface{}) (Value, error) {
 		}
 		return Value{
 			typ:   Number,
-			value: new(big.Float).SetInt64(int64(*value)),
+			value: int64(*value),
 		}, nil
 	case int8:
 		return Value{
 			typ:   Number,
-			value: new(big.Float).SetInt64(int64(value)),
+			value: int64(value),
 		}, nil
 	case *int8:
 		if value == nil {
@@ -297,12 +300,12 @@ func valueFromNumber(in interface{}) (Value, error) {
 		}
 		return Value{
 			typ:   Number,
-			value: new(big.Float).SetInt64(int64(*value)),
+			value: int64(*value),
 		}, nil
 	case int16:
 		return Value{
 			typ:   Number,
-			value: new(big.Float).SetInt64(int64(value)),
+			value: int64(value),
 		}, nil
 	case *int16:
 		if value == nil {
@@ -313,12 +316,12 @@ func valueFromNumber(in interface{}) (Value, error) {
 		}
 		return Value{
 			typ:   Number,
-			value: new(big.Float).SetInt64(int64(*value)),
+			value: int64(*value),
 		}, nil
 	case int32:
 		return Value{
 			typ:   Number,
-			value: new(big.Float).SetInt64(int64(value)),
+			value: int64(value),
 		}, nil
 	case *int32:
 		if value == nil {
@@ -329,12 +332,13 @@ func valueFromNumber(in interface{}) (Value, error) {
 		}
 		return Value{
 			typ:   Number,
-			value: new(big.Float).SetInt64(int64(*value)),
+			value: int64(*value),
 		}, nil
 	case int64:
+		// Reuse the interface value, to avoid allocating a new one.
 		return Value{
 			typ:   Number,
-			value: new(big.Float).SetInt64(value),
+			value: in,
 		}, nil
 	case *int64:
 		if value == nil {
@@ -345,12 +349,15 @@ func valueFromNumber(in interface{}) (Value, error) {
 		}
 		return Value{
 			typ:   Number,
-			value: new(big.Float).SetInt64(*value),
+			value: *value,
 		}, nil
 	case float64:
+		if math.IsNaN(value) {
+			return Value{}, errors.New("tftypes.NewValue can't use NaN as a tftypes.Number")
+		}
 		return Value{
 			typ:   Number,
-			value: big.NewFloat(value),
+			value: in,
 		}, nil
 	case *float64:
 		if value == nil {
@@ -359,10 +366,7 @@ func valueFromNumber(in interface{}) (Value, error) {
 				value: nil,
 			}, nil
 		}
-		return Value{
-			typ:   Number,
-			value: big.NewFloat(*value),
-		}, nil
+		return valueFromNumber(*value)
 	default:
 		return Value{}, fmt.Errorf("tftypes.NewValue can't use %T as a tftypes.Number; expected types are: %s", in, formattedSupportedGoTypes(Number))
 	}
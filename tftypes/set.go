@@ -82,6 +82,46 @@ func (s Set) supportedGoTypes() []string {
 	return []string{"[]tftypes.Value"}
 }
 
+// SetDuplicates determines how NewSetValue handles duplicate elements.
+type SetDuplicates int
+
+const (
+	// SetDuplicatesReject makes NewSetValue return an error if any
+	// elements are duplicates of each other.
+	SetDuplicatesReject SetDuplicates = iota
+
+	// SetDuplicatesRemove makes NewSetValue remove duplicate elements,
+	// keeping the first instance of each element.
+	SetDuplicatesRemove
+)
+
+// NewSetValue returns a Value of a Set with the passed ElementType and
+// elements. Unlike NewValue, which does not check the elements of a Set for
+// uniqueness, duplicate elements are either rejected or removed, depending on
+// `duplicates`. Elements are compared using their Hash, so checking a set for
+// duplicates takes near-linear time. Elements with unknown values are
+// compared like any other, so equal unknown elements are duplicates.
+func NewSetValue(elementType Type, elements []Value, duplicates SetDuplicates) (Value, error) {
+	val, err := newValue(Set{ElementType: elementType}, elements)
+
+	if err != nil {
+		return Value{}, err
+	}
+
+	unique, err := uniqueSetElements(elements, duplicates == SetDuplicatesReject)
+
+	if err != nil {
+		return Value{}, err
+	}
+
+	val.value = unique
+
+	return val, nil
+}
+
+// valueFromSet does not check the elements for duplicates, as NewValue has no
+// way to report them and must not drop elements decoded from Terraform. See
+// NewSetValue for the duplicate checks.
 func valueFromSet(typ Type, in interface{}) (Value, error) {
 	switch value := in.(type) {
 	case []Value:
@@ -0,0 +1,595 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tftypes
+
+import (
+	"errors"
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ParseTypeConstraint returns a Type from its representation in Terraform's
+// type constraint syntax, as used in the type argument of variable blocks,
+// such as:
+//
+//	list(object({name=string, port=optional(number)}))
+//
+// The primitive type keywords are string, number, bool, and any, which is
+// parsed as DynamicPseudoType. The collection and structural type constructors
+// are list(...), set(...), map(...), object({...}), and tuple([...]).
+// Attributes of an object type constructor may be wrapped in optional(...) to
+// populate the OptionalAttributes of the Object. Default values for optional
+// attributes, as in optional(number, 80), are not supported, as they are not
+// part of a Type.
+func ParseTypeConstraint(constraint string) (Type, error) {
+	p := &typeConstraintParser{input: constraint}
+
+	typ, err := p.parseType()
+
+	if err != nil {
+		return nil, err
+	}
+
+	p.skipSpace()
+
+	if p.pos < len(p.input) {
+		return nil, p.errorf("unexpected %q after type constraint", p.input[p.pos:])
+	}
+
+	return typ, nil
+}
+
+// FormatTypeConstraint returns the representation of a Type in Terraform's
+// type constraint syntax. It is the inverse of ParseTypeConstraint. Object
+// attributes are sorted by name, and attributes in OptionalAttributes are
+// wrapped in optional(...). DynamicPseudoType is formatted as any.
+//
+// An error is returned if the Type, or any Type within it, is nil or is not
+// one of the Types of this package.
+func FormatTypeConstraint(typ Type) (string, error) {
+	var b strings.Builder
+
+	if err := writeTypeConstraint(&b, typ); err != nil {
+		return "", err
+	}
+
+	return b.String(), nil
+}
+
+func writeTypeConstraint(b *strings.Builder, typ Type) error {
+	switch typ := typ.(type) {
+	case nil:
+		return errors.New("cannot format missing type as type constraint")
+	case primitive:
+		switch typ.name {
+		case String.name:
+			b.WriteString("string")
+		case Number.name:
+			b.WriteString("number")
+		case Bool.name:
+			b.WriteString("bool")
+		case DynamicPseudoType.name:
+			b.WriteString("any")
+		default:
+			return fmt.Errorf("cannot format type %s as type constraint", typ)
+		}
+	case List:
+		return writeTypeConstraintCollection(b, "list", typ.ElementType)
+	case Set:
+		return writeTypeConstraintCollection(b, "set", typ.ElementType)
+	case Map:
+		return writeTypeConstraintCollection(b, "map", typ.ElementType)
+	case Tuple:
+		b.WriteString("tuple([")
+
+		for pos, elemType := range typ.ElementTypes {
+			if pos > 0 {
+				b.WriteString(", ")
+			}
+
+			if err := writeTypeConstraint(b, elemType); err != nil {
+				return err
+			}
+		}
+
+		b.WriteString("])")
+	case Object:
+		names := make([]string, 0, len(typ.AttributeTypes))
+
+		for name := range typ.AttributeTypes {
+			names = append(names, name)
+		}
+
+		sort.Strings(names)
+
+		b.WriteString("object({")
+
+		for pos, name := range names {
+			if pos > 0 {
+				b.WriteString(", ")
+			}
+
+			if isTypeConstraintIdentifier(name) {
+				b.WriteString(name)
+			} else {
+				writeHCLString(b, name)
+			}
+
+			b.WriteString("=")
+
+			if typ.attrIsOptional(name) {
+				b.WriteString("optional(")
+			}
+
+			if err := writeTypeConstraint(b, typ.AttributeTypes[name]); err != nil {
+				return err
+			}
+
+			if typ.attrIsOptional(name) {
+				b.WriteString(")")
+			}
+		}
+
+		b.WriteString("})")
+	default:
+		return fmt.Errorf("cannot format type %s as type constraint", typ)
+	}
+
+	return nil
+}
+
+func writeTypeConstraintCollection(b *strings.Builder, keyword string, elemType Type) error {
+	b.WriteString(keyword)
+	b.WriteString("(")
+
+	if err := writeTypeConstraint(b, elemType); err != nil {
+		return err
+	}
+
+	b.WriteString(")")
+
+	return nil
+}
+
+// writeHCLString writes `s` as an HCL quoted string. Unlike Go, HCL only
+// supports the \n, \r, \t, \", \\, \uNNNN, and \UNNNNNNNN escape sequences,
+// and template sequences are escaped by doubling their $ or % character.
+func writeHCLString(b *strings.Builder, s string) {
+	b.WriteByte('"')
+
+	for pos, r := range s {
+		switch {
+		case r == '"':
+			b.WriteString(`\"`)
+		case r == '\\':
+			b.WriteString(`\\`)
+		case r == '\n':
+			b.WriteString(`\n`)
+		case r == '\r':
+			b.WriteString(`\r`)
+		case r == '\t':
+			b.WriteString(`\t`)
+		case (r == '$' || r == '%') && strings.HasPrefix(s[pos+1:], "{"):
+			b.WriteRune(r)
+			b.WriteRune(r)
+		case !unicode.IsPrint(r):
+			if r > 0xFFFF {
+				fmt.Fprintf(b, `\U%08X`, r)
+			} else {
+				fmt.Fprintf(b, `\u%04X`, r)
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+
+	b.WriteByte('"')
+}
+
+// isTypeConstraintIdentifier returns true if the attribute name can be written
+// without quotes.
+func isTypeConstraintIdentifier(name string) bool {
+	if name == "" {
+		return false
+	}
+
+	for pos, r := range name {
+		if unicode.IsLetter(r) || r == '_' {
+			continue
+		}
+
+		if pos > 0 && (unicode.IsDigit(r) || r == '-') {
+			continue
+		}
+
+		return false
+	}
+
+	return true
+}
+
+// typeConstraintParser is a recursive descent parser for Terraform's type
+// constraint syntax.
+type typeConstraintParser struct {
+	input string
+	pos   int
+}
+
+func (p *typeConstraintParser) errorf(f string, args ...interface{}) error {
+	return fmt.Errorf("invalid type constraint at offset %d: %s", p.pos, fmt.Sprintf(f, args...))
+}
+
+// skipSpace skips any whitespace, returning whether it included a newline.
+func (p *typeConstraintParser) skipSpace() bool {
+	newline := false
+
+	for p.pos < len(p.input) {
+		r, size := utf8.DecodeRuneInString(p.input[p.pos:])
+
+		if !unicode.IsSpace(r) {
+			break
+		}
+
+		newline = newline || r == '\n'
+		p.pos += size
+	}
+
+	return newline
+}
+
+// peek returns the next non-space byte without consuming it, or 0 at the end
+// of the input.
+func (p *typeConstraintParser) peek() byte {
+	p.skipSpace()
+
+	if p.pos >= len(p.input) {
+		return 0
+	}
+
+	return p.input[p.pos]
+}
+
+func (p *typeConstraintParser) expect(c byte) error {
+	if p.peek() != c {
+		if p.pos >= len(p.input) {
+			return p.errorf("expected %q, got end of input", c)
+		}
+
+		return p.errorf("expected %q, got %q", c, p.input[p.pos])
+	}
+
+	p.pos++
+
+	return nil
+}
+
+func (p *typeConstraintParser) identifier() string {
+	p.skipSpace()
+
+	start := p.pos
+
+	for p.pos < len(p.input) {
+		r, size := utf8.DecodeRuneInString(p.input[p.pos:])
+
+		if !unicode.IsLetter(r) && r != '_' && (p.pos == start || (!unicode.IsDigit(r) && r != '-')) {
+			break
+		}
+
+		p.pos += size
+	}
+
+	return p.input[start:p.pos]
+}
+
+func (p *typeConstraintParser) parseType() (Type, error) {
+	start := p.pos
+	keyword := p.identifier()
+
+	switch keyword {
+	case "string":
+		return String, nil
+	case "number":
+		return Number, nil
+	case "bool":
+		return Bool, nil
+	case "any":
+		return DynamicPseudoType, nil
+	case "list", "set", "map":
+		if err := p.expect('('); err != nil {
+			return nil, err
+		}
+
+		elemType, err := p.parseType()
+
+		if err != nil {
+			return nil, err
+		}
+
+		if err := p.expect(')'); err != nil {
+			return nil, err
+		}
+
+		switch keyword {
+		case "list":
+			return List{ElementType: elemType}, nil
+		case "set":
+			return Set{ElementType: elemType}, nil
+		default:
+			return Map{ElementType: elemType}, nil
+		}
+	case "tuple":
+		return p.parseTuple()
+	case "object":
+		return p.parseObject()
+	case "optional":
+		p.pos = start
+
+		return nil, p.errorf("optional(...) is only allowed for object attributes")
+	case "":
+		if p.pos >= len(p.input) {
+			return nil, p.errorf("expected type, got end of input")
+		}
+
+		return nil, p.errorf("expected type, got %q", p.input[p.pos])
+	default:
+		p.pos = start
+
+		return nil, p.errorf("unknown type keyword %q", keyword)
+	}
+}
+
+func (p *typeConstraintParser) parseTuple() (Type, error) {
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+
+	if err := p.expect('['); err != nil {
+		return nil, err
+	}
+
+	elemTypes := []Type{}
+
+	for p.peek() != ']' {
+		elemType, err := p.parseType()
+
+		if err != nil {
+			return nil, err
+		}
+
+		elemTypes = append(elemTypes, elemType)
+
+		if p.peek() != ',' {
+			break
+		}
+
+		p.pos++
+	}
+
+	if err := p.expect(']'); err != nil {
+		return nil, err
+	}
+
+	if err := p.expect(')'); err != nil {
+		return nil, err
+	}
+
+	return Tuple{ElementTypes: elemTypes}, nil
+}
+
+func (p *typeConstraintParser) parseObject() (Type, error) {
+	if err := p.expect('('); err != nil {
+		return nil, err
+	}
+
+	if err := p.expect('{'); err != nil {
+		return nil, err
+	}
+
+	attrTypes := map[string]Type{}
+	optionalAttrs := map[string]struct{}{}
+
+	for p.peek() != '}' {
+		name, err := p.parseAttributeName()
+
+		if err != nil {
+			return nil, err
+		}
+
+		if _, ok := attrTypes[name]; ok {
+			return nil, p.errorf("duplicate attribute %q", name)
+		}
+
+		switch p.peek() {
+		case '=', ':':
+			p.pos++
+		default:
+			return nil, p.errorf("expected \"=\" after attribute %q", name)
+		}
+
+		optional, err := p.parseOptional()
+
+		if err != nil {
+			return nil, err
+		}
+
+		attrType, err := p.parseType()
+
+		if err != nil {
+			return nil, err
+		}
+
+		if optional {
+			if p.peek() == ',' {
+				return nil, p.errorf("default values for optional attribute %q are not supported", name)
+			}
+
+			if err := p.expect(')'); err != nil {
+				return nil, err
+			}
+
+			optionalAttrs[name] = struct{}{}
+		}
+
+		attrTypes[name] = attrType
+
+		// Attributes must be separated by commas or newlines.
+		newline := p.skipSpace()
+
+		switch {
+		case p.peek() == ',':
+			p.pos++
+		case p.peek() == '}', newline:
+		case p.pos >= len(p.input):
+			return nil, p.errorf("expected \",\" or newline after attribute %q, got end of input", name)
+		default:
+			return nil, p.errorf("expected \",\" or newline after attribute %q, got %q", name, p.input[p.pos])
+		}
+	}
+
+	if err := p.expect('}'); err != nil {
+		return nil, err
+	}
+
+	if err := p.expect(')'); err != nil {
+		return nil, err
+	}
+
+	obj := Object{AttributeTypes: attrTypes}
+
+	if len(optionalAttrs) > 0 {
+		obj.OptionalAttributes = optionalAttrs
+	}
+
+	return obj, nil
+}
+
+// parseOptional consumes an optional( prefix, returning whether it was found.
+func (p *typeConstraintParser) parseOptional() (bool, error) {
+	p.skipSpace()
+
+	start := p.pos
+
+	if p.identifier() != "optional" || p.peek() != '(' {
+		p.pos = start
+
+		return false, nil
+	}
+
+	p.pos++
+
+	return true, nil
+}
+
+func (p *typeConstraintParser) parseAttributeName() (string, error) {
+	if p.peek() != '"' {
+		name := p.identifier()
+
+		if name == "" {
+			if p.pos >= len(p.input) {
+				return "", p.errorf("expected attribute name, got end of input")
+			}
+
+			return "", p.errorf("expected attribute name, got %q", p.input[p.pos])
+		}
+
+		return name, nil
+	}
+
+	return p.parseHCLString()
+}
+
+// parseHCLString parses an HCL quoted string without template sequences, the
+// inverse of writeHCLString.
+func (p *typeConstraintParser) parseHCLString() (string, error) {
+	start := p.pos
+
+	var b strings.Builder
+
+	// Skip the opening quote.
+	p.pos++
+
+	for p.pos < len(p.input) {
+		c := p.input[p.pos]
+
+		switch {
+		case c == '"':
+			p.pos++
+
+			return b.String(), nil
+		case c == '\\':
+			if err := p.parseHCLEscape(&b); err != nil {
+				return "", err
+			}
+
+			continue
+		case (c == '$' || c == '%') && strings.HasPrefix(p.input[p.pos+1:], string(c)+"{"):
+			// Escaped template sequence.
+			b.WriteByte(c)
+			b.WriteByte('{')
+			p.pos += 3
+
+			continue
+		case (c == '$' || c == '%') && strings.HasPrefix(p.input[p.pos+1:], "{"):
+			return "", p.errorf("template sequences are not allowed in attribute names")
+		case c == '\n':
+			return "", p.errorf("unterminated attribute name")
+		}
+
+		b.WriteByte(c)
+		p.pos++
+	}
+
+	p.pos = start
+
+	return "", p.errorf("unterminated attribute name")
+}
+
+// parseHCLEscape parses the escape sequence at the current position.
+func (p *typeConstraintParser) parseHCLEscape(b *strings.Builder) error {
+	if p.pos+1 >= len(p.input) {
+		return p.errorf("unterminated attribute name")
+	}
+
+	switch c := p.input[p.pos+1]; c {
+	case 'n':
+		b.WriteByte('\n')
+	case 'r':
+		b.WriteByte('\r')
+	case 't':
+		b.WriteByte('\t')
+	case '"', '\\':
+		b.WriteByte(c)
+	case 'u', 'U':
+		digits := 4
+
+		if c == 'U' {
+			digits = 8
+		}
+
+		if p.pos+2+digits > len(p.input) {
+			return p.errorf("invalid escape sequence %q", p.input[p.pos:])
+		}
+
+		code, err := strconv.ParseUint(p.input[p.pos+2:p.pos+2+digits], 16, 32)
+
+		if err != nil || !utf8.ValidRune(rune(code)) {
+			return p.errorf("invalid escape sequence %q", p.input[p.pos:p.pos+2+digits])
+		}
+
+		b.WriteRune(rune(code))
+		p.pos += 2 + digits
+
+		return nil
+	default:
+		return p.errorf("invalid escape sequence %q", p.input[p.pos:p.pos+2])
+	}
+
+	p.pos += 2
+
+	return nil
+}
@@ -0,0 +1,249 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tftypes
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseTypeConstraint(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		constraint    string
+		expected      Type
+		expectedError string
+	}{
+		"string": {
+			constraint: "string",
+			expected:   String,
+		},
+		"any": {
+			constraint: " any ",
+			expected:   DynamicPseudoType,
+		},
+		"list-string": {
+			constraint: "list(string)",
+			expected:   List{ElementType: String},
+		},
+		"set-number": {
+			constraint: "set( number )",
+			expected:   Set{ElementType: Number},
+		},
+		"map-bool": {
+			constraint: "map(bool)",
+			expected:   Map{ElementType: Bool},
+		},
+		"tuple": {
+			constraint: "tuple([string, number, bool])",
+			expected:   Tuple{ElementTypes: []Type{String, Number, Bool}},
+		},
+		"tuple-empty": {
+			constraint: "tuple([])",
+			expected:   Tuple{ElementTypes: []Type{}},
+		},
+		"object-empty": {
+			constraint: "object({})",
+			expected:   Object{AttributeTypes: map[string]Type{}},
+		},
+		"list-object-optional": {
+			constraint: "list(object({name=string, port=optional(number)}))",
+			expected: List{
+				ElementType: Object{
+					AttributeTypes: map[string]Type{
+						"name": String,
+						"port": Number,
+					},
+					OptionalAttributes: map[string]struct{}{
+						"port": {},
+					},
+				},
+			},
+		},
+		"object-multiline-quoted": {
+			constraint: `object({
+  "with space" = list(string)
+  nested       = object({ enabled: bool })
+})`,
+			expected: Object{
+				AttributeTypes: map[string]Type{
+					"with space": List{ElementType: String},
+					"nested": Object{
+						AttributeTypes: map[string]Type{
+							"enabled": Bool,
+						},
+					},
+				},
+			},
+		},
+		"object-quoted-escapes": {
+			constraint: `object({"a\tb\u00e9$${x}%%{y}\"" = string})`,
+			expected: Object{
+				AttributeTypes: map[string]Type{
+					"a\tbé${x}%{y}\"": String,
+				},
+			},
+		},
+		"object-missing-separator": {
+			constraint:    "object({a=string b=string})",
+			expectedError: `invalid type constraint at offset 17: expected "," or newline after attribute "a", got 'b'`,
+		},
+		"object-template-sequence": {
+			constraint:    `object({"${a}"=string})`,
+			expectedError: `invalid type constraint at offset 9: template sequences are not allowed in attribute names`,
+		},
+		"object-invalid-escape": {
+			constraint:    `object({"\a"=string})`,
+			expectedError: `invalid type constraint at offset 9: invalid escape sequence "\\a"`,
+		},
+		"unknown-keyword": {
+			constraint:    "list(strin)",
+			expectedError: `invalid type constraint at offset 5: unknown type keyword "strin"`,
+		},
+		"trailing-data": {
+			constraint:    "string string",
+			expectedError: `invalid type constraint at offset 7: unexpected "string" after type constraint`,
+		},
+		"unterminated": {
+			constraint:    "list(string",
+			expectedError: `invalid type constraint at offset 11: expected ')', got end of input`,
+		},
+		"optional-default": {
+			constraint:    "object({port=optional(number, 80)})",
+			expectedError: `invalid type constraint at offset 28: default values for optional attribute "port" are not supported`,
+		},
+		"optional-outside-object": {
+			constraint:    "list(optional(string))",
+			expectedError: `invalid type constraint at offset 5: optional(...) is only allowed for object attributes`,
+		},
+		"duplicate-attribute": {
+			constraint:    "object({a=string, a=number})",
+			expectedError: `invalid type constraint at offset 19: duplicate attribute "a"`,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ParseTypeConstraint(testCase.constraint)
+
+			if err != nil {
+				if testCase.expectedError == "" {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if diff := cmp.Diff(testCase.expectedError, err.Error()); diff != "" {
+					t.Fatalf("unexpected error difference: %s", diff)
+				}
+
+				return
+			}
+
+			if testCase.expectedError != "" {
+				t.Fatalf("expected error %q, got none", testCase.expectedError)
+			}
+
+			if !got.Equal(testCase.expected) {
+				t.Errorf("expected %s, got: %s", testCase.expected, got)
+			}
+		})
+	}
+}
+
+func TestFormatTypeConstraint(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		typ           Type
+		expected      string
+		expectedError string
+	}{
+		"dynamic": {
+			typ:      DynamicPseudoType,
+			expected: "any",
+		},
+		"map-list-number": {
+			typ:      Map{ElementType: List{ElementType: Number}},
+			expected: "map(list(number))",
+		},
+		"set-bool": {
+			typ:      Set{ElementType: Bool},
+			expected: "set(bool)",
+		},
+		"tuple": {
+			typ:      Tuple{ElementTypes: []Type{String, Number}},
+			expected: "tuple([string, number])",
+		},
+		"object": {
+			typ: Object{
+				AttributeTypes: map[string]Type{
+					"port":       Number,
+					"name":       String,
+					"with space": Bool,
+				},
+				OptionalAttributes: map[string]struct{}{
+					"port": {},
+				},
+			},
+			expected: `object({name=string, port=optional(number), "with space"=bool})`,
+		},
+		"object-escapes": {
+			typ: Object{
+				AttributeTypes: map[string]Type{
+					"a\tb\x01${x}%{y}\"\\": String,
+				},
+			},
+			expected: `object({"a\tb\u0001$${x}%%{y}\"\\"=string})`,
+		},
+		"nil": {
+			typ:           nil,
+			expectedError: "cannot format missing type as type constraint",
+		},
+		"nested-nil": {
+			typ:           List{ElementType: Map{}},
+			expectedError: "cannot format missing type as type constraint",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := FormatTypeConstraint(testCase.typ)
+
+			if err != nil {
+				if testCase.expectedError == "" {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if diff := cmp.Diff(testCase.expectedError, err.Error()); diff != "" {
+					t.Fatalf("unexpected error difference: %s", diff)
+				}
+
+				return
+			}
+
+			if testCase.expectedError != "" {
+				t.Fatalf("expected error %q, got none", testCase.expectedError)
+			}
+
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Fatalf("unexpected difference: %s", diff)
+			}
+
+			roundTrip, err := ParseTypeConstraint(got)
+
+			if err != nil {
+				t.Fatalf("unexpected error parsing formatted type constraint: %s", err)
+			}
+
+			if !roundTrip.Equal(testCase.typ) {
+				t.Errorf("expected round trip to %s, got: %s", testCase.typ, roundTrip)
+			}
+		})
+	}
+}
@@ -272,6 +272,13 @@ func (val Value) Copy() Value {
 //   - Bool: bool, *bool
 //   - Map and Object: map[string]Value
 //   - Tuple, List, and Set: []Value
+//
+// The elements of a Set are used as passed, without checking them for
+// duplicates, so NewValue never panics or drops elements because of them.
+// Values decoded from Terraform are built with NewValue, and must keep every
+// element Terraform sent, such as elements with unknown values, which may
+// compare equal without being the same element. Use NewSetValue to remove or
+// reject duplicate elements.
 func NewValue(t Type, val interface{}) Value {
 	v, err := newValue(t, val)
 	if err != nil {
@@ -442,11 +449,9 @@ func (val Value) As(dst interface{}) error {
 			target.Set(big.NewFloat(0))
 			return nil
 		}
-		v, ok := val.value.(*big.Float)
-		if !ok {
+		if !setNumberBigFloat(target, val.value) {
 			return fmt.Errorf("can't unmarshal %s into %T, expected *big.Float", val.Type(), dst)
 		}
-		target.Copy(v)
 		return nil
 	case **big.Float:
 		if val.IsNull() {
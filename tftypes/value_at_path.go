@@ -0,0 +1,330 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tftypes
+
+import (
+	"bytes"
+	"encoding/json"
+
+	msgpack "github.com/vmihailenco/msgpack/v5"
+	msgpackCodes "github.com/vmihailenco/msgpack/v5/msgpcode"
+)
+
+// ValueFromMsgPackAtPath returns the Value at `path` within the
+// MsgPack-encoded bytes of a Value of Type `typ`. Only the Value at `path` is
+// decoded; elements and attributes that are not on the path are skipped
+// without creating Values for them.
+//
+// The returned Value is the same as the Value at `path` in the Value returned
+// by ValueFromMsgPack. If a step of `path` cannot be applied, because a Value
+// on the path is null or unknown or does not have the element or attribute,
+// an error wrapping ErrInvalidStep is returned, with the AttributePath of the
+// Value the step could not be applied to. Errors in the encoding of Values
+// that are skipped are not reported.
+//
+// Steps into sets require all elements of the set to be decoded, to compare
+// them to the ElementKeyValue.
+//
+// Deprecated: this function is exported for internal use in
+// terraform-plugin-go.  Third parties should not use it, and its behavior is
+// not covered under the API compatibility guarantees. Don't use this.
+func ValueFromMsgPackAtPath(data []byte, typ Type, path *AttributePath) (Value, error) {
+	dec := msgpack.NewDecoder(bytes.NewReader(data))
+	return msgpackUnmarshalAtPath(dec, typ, path.Steps(), NewAttributePath())
+}
+
+// ValueFromJSONAtPath returns the Value at `path` within the JSON-encoded bytes
+// of a Value of Type `typ`, the same way ValueFromMsgPackAtPath does for
+// MsgPack-encoded bytes. The returned Value is the same as the Value at `path`
+// in the Value returned by ValueFromJSON.
+//
+// Deprecated: this function is exported for internal use in
+// terraform-plugin-go.  Third parties should not use it, and its behavior is
+// not covered under the API compatibility guarantees. Don't use this.
+func ValueFromJSONAtPath(data []byte, typ Type, path *AttributePath) (Value, error) {
+	return jsonUnmarshalAtPath(data, typ, path.Steps(), NewAttributePath(), ValueFromJSONOpts{})
+}
+
+func msgpackUnmarshalAtPath(dec *msgpack.Decoder, typ Type, steps []AttributePathStep, p *AttributePath) (Value, error) {
+	if len(steps) == 0 {
+		return msgpackUnmarshal(dec, typ, p)
+	}
+
+	peek, err := dec.PeekCode()
+	if err != nil {
+		return Value{}, p.NewErrorf("error peeking next byte: %w", err)
+	}
+	// steps can't be applied to unknown or null values
+	if msgpackCodes.IsExt(peek) || peek == msgpackCodes.Nil {
+		return Value{}, p.NewError(ErrInvalidStep)
+	}
+
+	if typ.Is(DynamicPseudoType) {
+		length, err := dec.DecodeArrayLen()
+		if err != nil {
+			return Value{}, p.NewErrorf("error checking length of DynamicPseudoType value: %w", err)
+		}
+		if length != 2 {
+			return Value{}, p.NewErrorf("expected %d elements in DynamicPseudoType array, got %d", 2, length)
+		}
+		typeJSON, err := dec.DecodeBytes()
+		if err != nil {
+			return Value{}, p.NewErrorf("error decoding bytes: %w", err)
+		}
+		typ, err = ParseJSONType(typeJSON) //nolint:staticcheck
+		if err != nil {
+			return Value{}, p.NewErrorf("error parsing type information: %w", err)
+		}
+		return msgpackUnmarshalAtPath(dec, typ, steps, p)
+	}
+
+	switch step := steps[0].(type) {
+	case AttributeName:
+		typ, ok := typ.(Object)
+		if !ok {
+			return Value{}, p.NewError(ErrInvalidStep)
+		}
+		if _, ok := typ.AttributeTypes[string(step)]; !ok {
+			return Value{}, p.NewError(ErrInvalidStep)
+		}
+		length, err := dec.DecodeMapLen()
+		if err != nil {
+			return Value{}, p.NewErrorf("error decoding object length: %w", err)
+		}
+		if length != len(typ.AttributeTypes) {
+			return Value{}, p.NewErrorf("error decoding object; expected %d attributes, got %d", len(typ.AttributeTypes), length)
+		}
+		for i := 0; i < length; i++ {
+			key, err := dec.DecodeString()
+			if err != nil {
+				return Value{}, p.NewErrorf("error decoding object key: %w", err)
+			}
+			attrType, exists := typ.AttributeTypes[key]
+			if !exists {
+				return Value{}, p.NewErrorf("unknown attribute %q", key)
+			}
+			if key == string(step) {
+				return msgpackUnmarshalAtPath(dec, attrType, steps[1:], p.WithAttributeName(key))
+			}
+			err = dec.Skip()
+			if err != nil {
+				return Value{}, p.WithAttributeName(key).NewErrorf("error skipping value: %w", err)
+			}
+		}
+	case ElementKeyString:
+		typ, ok := typ.(Map)
+		if !ok {
+			return Value{}, p.NewError(ErrInvalidStep)
+		}
+		length, err := dec.DecodeMapLen()
+		if err != nil {
+			return Value{}, p.NewErrorf("error decoding map length: %w", err)
+		}
+		for i := 0; i < length; i++ {
+			key, err := dec.DecodeString()
+			if err != nil {
+				return Value{}, p.NewErrorf("error decoding map key: %w", err)
+			}
+			if key == string(step) {
+				return msgpackUnmarshalAtPath(dec, typ.ElementType, steps[1:], p.WithElementKeyString(key))
+			}
+			err = dec.Skip()
+			if err != nil {
+				return Value{}, p.WithElementKeyString(key).NewErrorf("error skipping value: %w", err)
+			}
+		}
+	case ElementKeyInt:
+		var elementType Type
+		switch typ := typ.(type) {
+		case List:
+			elementType = typ.ElementType
+		case Tuple:
+			if int64(step) >= 0 && int64(step) < int64(len(typ.ElementTypes)) {
+				elementType = typ.ElementTypes[step]
+			}
+		}
+		if elementType == nil || int64(step) < 0 {
+			return Value{}, p.NewError(ErrInvalidStep)
+		}
+		length, err := dec.DecodeArrayLen()
+		if err != nil {
+			return Value{}, p.NewErrorf("error decoding list length: %w", err)
+		}
+		if typ, ok := typ.(Tuple); ok && length != len(typ.ElementTypes) {
+			return Value{}, p.NewErrorf("error decoding tuple; expected %d items, got %d", len(typ.ElementTypes), length)
+		}
+		if int64(step) >= int64(length) {
+			return Value{}, p.NewError(ErrInvalidStep)
+		}
+		for i := 0; i < int(step); i++ {
+			err := dec.Skip()
+			if err != nil {
+				return Value{}, p.WithElementKeyInt(i).NewErrorf("error skipping value: %w", err)
+			}
+		}
+		return msgpackUnmarshalAtPath(dec, elementType, steps[1:], p.WithElementKeyInt(int(step)))
+	case ElementKeyValue:
+		if _, ok := typ.(Set); !ok {
+			return Value{}, p.NewError(ErrInvalidStep)
+		}
+		// set elements can only be found by comparing them, so the
+		// whole set needs to be decoded
+		val, err := msgpackUnmarshal(dec, typ, p)
+		if err != nil {
+			return Value{}, err
+		}
+		return valueAtPathSteps(val, steps, p)
+	}
+
+	return Value{}, p.NewError(ErrInvalidStep)
+}
+
+func jsonUnmarshalAtPath(buf []byte, typ Type, steps []AttributePathStep, p *AttributePath, opts ValueFromJSONOpts) (Value, error) {
+	if len(steps) == 0 {
+		return jsonUnmarshal(buf, typ, p, opts)
+	}
+
+	dec := jsonByteDecoder(buf)
+
+	tok, err := dec.Token()
+	if err != nil {
+		return Value{}, p.NewErrorf("error reading token: %w", err)
+	}
+	// steps can't be applied to null values
+	if tok == nil {
+		return Value{}, p.NewError(ErrInvalidStep)
+	}
+
+	if typ.Is(DynamicPseudoType) {
+		typ, valBody, err := jsonDynamicPseudoTypeParts(buf, p)
+		if err != nil {
+			return Value{}, err
+		}
+		return jsonUnmarshalAtPath(valBody, typ, steps, p, opts)
+	}
+
+	switch step := steps[0].(type) {
+	case AttributeName:
+		typ, ok := typ.(Object)
+		if !ok {
+			return Value{}, p.NewError(ErrInvalidStep)
+		}
+		attrType, ok := typ.AttributeTypes[string(step)]
+		if !ok {
+			return Value{}, p.NewError(ErrInvalidStep)
+		}
+		if tok != json.Delim('{') {
+			return Value{}, p.NewErrorf("invalid JSON, expected %q, got %q", json.Delim('{'), tok)
+		}
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return Value{}, p.NewErrorf("error reading object attribute key token: %w", err)
+			}
+			key, ok := tok.(string)
+			if !ok {
+				return Value{}, p.NewErrorf("object attribute key was %T with value %v, not string", tok, tok)
+			}
+			innerPath := p.WithAttributeName(key)
+			if _, ok := typ.AttributeTypes[key]; !ok && !opts.IgnoreUndefinedAttributes {
+				return Value{}, innerPath.NewErrorf("unsupported attribute %q", key)
+			}
+			var rawVal json.RawMessage
+			err = dec.Decode(&rawVal)
+			if err != nil {
+				return Value{}, innerPath.NewErrorf("error decoding value: %w", err)
+			}
+			if key == string(step) {
+				return jsonUnmarshalAtPath(rawVal, attrType, steps[1:], innerPath, opts)
+			}
+		}
+		// missing attributes are decoded as null
+		if len(steps) > 1 {
+			return Value{}, p.WithAttributeName(string(step)).NewError(ErrInvalidStep)
+		}
+		return NewValue(attrType, nil), nil
+	case ElementKeyString:
+		typ, ok := typ.(Map)
+		if !ok {
+			return Value{}, p.NewError(ErrInvalidStep)
+		}
+		if tok != json.Delim('{') {
+			return Value{}, p.NewErrorf("invalid JSON, expected %q, got %q", json.Delim('{'), tok)
+		}
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return Value{}, p.NewErrorf("error reading token: %w", err)
+			}
+			key, ok := tok.(string)
+			if !ok {
+				return Value{}, p.NewErrorf("expected map key to be a string, got %T", tok)
+			}
+			innerPath := p.WithElementKeyString(key)
+			var rawVal json.RawMessage
+			err = dec.Decode(&rawVal)
+			if err != nil {
+				return Value{}, innerPath.NewErrorf("error decoding value: %w", err)
+			}
+			if key == string(step) {
+				return jsonUnmarshalAtPath(rawVal, typ.ElementType, steps[1:], innerPath, opts)
+			}
+		}
+	case ElementKeyInt:
+		var elementType Type
+		switch typ := typ.(type) {
+		case List:
+			elementType = typ.ElementType
+		case Tuple:
+			if int64(step) >= 0 && int64(step) < int64(len(typ.ElementTypes)) {
+				elementType = typ.ElementTypes[step]
+			}
+		}
+		if elementType == nil || int64(step) < 0 {
+			return Value{}, p.NewError(ErrInvalidStep)
+		}
+		if tok != json.Delim('[') {
+			return Value{}, p.NewErrorf("invalid JSON, expected %q, got %q", json.Delim('['), tok)
+		}
+		for idx := 0; dec.More(); idx++ {
+			innerPath := p.WithElementKeyInt(idx)
+			var rawVal json.RawMessage
+			err = dec.Decode(&rawVal)
+			if err != nil {
+				return Value{}, innerPath.NewErrorf("error decoding value: %w", err)
+			}
+			if idx == int(step) {
+				return jsonUnmarshalAtPath(rawVal, elementType, steps[1:], innerPath, opts)
+			}
+		}
+	case ElementKeyValue:
+		if _, ok := typ.(Set); !ok {
+			return Value{}, p.NewError(ErrInvalidStep)
+		}
+		// set elements can only be found by comparing them, so the
+		// whole set needs to be decoded
+		val, err := jsonUnmarshal(buf, typ, p, opts)
+		if err != nil {
+			return Value{}, err
+		}
+		return valueAtPathSteps(val, steps, p)
+	}
+
+	return Value{}, p.NewError(ErrInvalidStep)
+}
+
+// valueAtPathSteps returns the Value at `steps` within the decoded Value
+// `val`, which is at the path `p`.
+func valueAtPathSteps(val Value, steps []AttributePathStep, p *AttributePath) (Value, error) {
+	res, remaining, err := WalkAttributePath(val, NewAttributePathWithSteps(steps))
+	if err != nil {
+		applied := len(steps) - len(remaining.Steps())
+		return Value{}, NewAttributePathWithSteps(append(p.Steps(), steps[:applied]...)).NewError(err)
+	}
+	resVal, ok := res.(Value)
+	if !ok {
+		return Value{}, p.NewErrorf("unexpected result type %T", res)
+	}
+	return resVal, nil
+}
@@ -0,0 +1,307 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tftypes
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestValueFromMsgPackAtPath(t *testing.T) {
+	t.Parallel()
+
+	ruleType := Object{AttributeTypes: map[string]Type{"port": Number}}
+	typ := Object{
+		AttributeTypes: map[string]Type{
+			"id":       String,
+			"region":   String,
+			"unknown":  String,
+			"tags":     Map{ElementType: String},
+			"names":    List{ElementType: String},
+			"rules":    Set{ElementType: ruleType},
+			"pair":     Tuple{ElementTypes: []Type{String, Number}},
+			"dynamic":  DynamicPseudoType,
+			"dynamics": List{ElementType: DynamicPseudoType},
+			"nested": Object{
+				AttributeTypes:     map[string]Type{"a": String, "b": Bool},
+				OptionalAttributes: map[string]struct{}{"b": {}},
+			},
+		},
+	}
+
+	data, err := NewValue(typ, map[string]Value{
+		"id":      NewValue(String, "abc123"),
+		"region":  NewValue(String, nil),
+		"unknown": NewValue(String, UnknownValue),
+		"tags": NewValue(Map{ElementType: String}, map[string]Value{
+			"env":  NewValue(String, "test"),
+			"team": NewValue(String, "infra"),
+		}),
+		"names": NewValue(List{ElementType: String}, []Value{
+			NewValue(String, "a"),
+			NewValue(String, "b"),
+		}),
+		"rules": NewValue(Set{ElementType: ruleType}, []Value{
+			NewValue(ruleType, map[string]Value{"port": NewValue(Number, 80)}),
+			NewValue(ruleType, map[string]Value{"port": NewValue(Number, 443)}),
+		}),
+		"pair": NewValue(Tuple{ElementTypes: []Type{String, Number}}, []Value{
+			NewValue(String, "a"),
+			NewValue(Number, 1),
+		}),
+		"dynamic": NewValue(Object{AttributeTypes: map[string]Type{"x": Number}}, map[string]Value{
+			"x": NewValue(Number, 5),
+		}),
+		"dynamics": NewValue(List{ElementType: Bool}, []Value{
+			NewValue(Bool, true),
+		}),
+		"nested": NewValue(Object{AttributeTypes: map[string]Type{"a": String, "b": Bool}}, map[string]Value{
+			"a": NewValue(String, "one"),
+			"b": NewValue(Bool, nil),
+		}),
+	}).MarshalMsgPack(typ) //nolint:staticcheck
+
+	if err != nil {
+		t.Fatalf("unexpected MarshalMsgPack error: %s", err)
+	}
+
+	full, err := ValueFromMsgPack(data, typ) //nolint:staticcheck
+
+	if err != nil {
+		t.Fatalf("unexpected ValueFromMsgPack error: %s", err)
+	}
+
+	testCases := map[string]struct {
+		path         *AttributePath
+		expectedPath *AttributePath
+	}{
+		"root": {
+			path: NewAttributePath(),
+		},
+		"attribute": {
+			path: NewAttributePath().WithAttributeName("id"),
+		},
+		"attribute-null": {
+			path: NewAttributePath().WithAttributeName("region"),
+		},
+		"attribute-unknown": {
+			path: NewAttributePath().WithAttributeName("unknown"),
+		},
+		"attribute-missing": {
+			path:         NewAttributePath().WithAttributeName("missing"),
+			expectedPath: NewAttributePath(),
+		},
+		"map-element": {
+			path: NewAttributePath().WithAttributeName("tags").WithElementKeyString("team"),
+		},
+		"map-element-missing": {
+			path:         NewAttributePath().WithAttributeName("tags").WithElementKeyString("missing"),
+			expectedPath: NewAttributePath().WithAttributeName("tags"),
+		},
+		"list-element": {
+			path: NewAttributePath().WithAttributeName("names").WithElementKeyInt(1),
+		},
+		"list-element-out-of-range": {
+			path:         NewAttributePath().WithAttributeName("names").WithElementKeyInt(2),
+			expectedPath: NewAttributePath().WithAttributeName("names"),
+		},
+		"set": {
+			path: NewAttributePath().WithAttributeName("rules"),
+		},
+		"set-element-attribute": {
+			path: NewAttributePath().WithAttributeName("rules").WithElementKeyValue(
+				NewValue(ruleType, map[string]Value{"port": NewValue(Number, 443)}),
+			).WithAttributeName("port"),
+		},
+		"set-element-missing": {
+			path: NewAttributePath().WithAttributeName("rules").WithElementKeyValue(
+				NewValue(ruleType, map[string]Value{"port": NewValue(Number, 22)}),
+			),
+			expectedPath: NewAttributePath().WithAttributeName("rules"),
+		},
+		"tuple-element": {
+			path: NewAttributePath().WithAttributeName("pair").WithElementKeyInt(1),
+		},
+		"dynamic": {
+			path: NewAttributePath().WithAttributeName("dynamic"),
+		},
+		"dynamic-attribute": {
+			path: NewAttributePath().WithAttributeName("dynamic").WithAttributeName("x"),
+		},
+		"dynamic-list-element": {
+			path: NewAttributePath().WithAttributeName("dynamics").WithElementKeyInt(0),
+		},
+		"optional-attributes": {
+			path: NewAttributePath().WithAttributeName("nested"),
+		},
+		"through-null": {
+			path:         NewAttributePath().WithAttributeName("region").WithElementKeyInt(0),
+			expectedPath: NewAttributePath().WithAttributeName("region"),
+		},
+		"through-unknown": {
+			path:         NewAttributePath().WithAttributeName("unknown").WithElementKeyInt(0),
+			expectedPath: NewAttributePath().WithAttributeName("unknown"),
+		},
+		"wrong-step": {
+			path:         NewAttributePath().WithAttributeName("names").WithElementKeyString("a"),
+			expectedPath: NewAttributePath().WithAttributeName("names"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ValueFromMsgPackAtPath(data, typ, testCase.path) //nolint:staticcheck
+
+			expected, _, expectedErr := WalkAttributePath(full, testCase.path)
+
+			if expectedErr != nil {
+				if !errors.Is(err, expectedErr) {
+					t.Fatalf("expected error %q, got %v", expectedErr, err)
+				}
+
+				var pathErr AttributePathError
+
+				if !errors.As(err, &pathErr) {
+					t.Fatalf("expected AttributePathError, got %#v", err)
+				}
+
+				if !pathErr.Path.Equal(testCase.expectedPath) {
+					t.Errorf("expected error path %s, got %s", testCase.expectedPath, pathErr.Path)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(expected, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestValueFromJSONAtPath(t *testing.T) {
+	t.Parallel()
+
+	typ := Object{
+		AttributeTypes: map[string]Type{
+			"id":      String,
+			"region":  String,
+			"missing": List{ElementType: String},
+			"tags":    Map{ElementType: String},
+			"names":   List{ElementType: String},
+			"rules":   Set{ElementType: Number},
+			"pair":    Tuple{ElementTypes: []Type{String, Number}},
+			"dynamic": DynamicPseudoType,
+		},
+	}
+
+	data := []byte(`{
+		"id": "abc123",
+		"region": null,
+		"tags": {"env": "test", "team": "infra"},
+		"names": ["a", "b"],
+		"rules": [80, 443],
+		"pair": ["a", 1],
+		"dynamic": {"type": ["object", {"x": "number"}], "value": {"x": 5}}
+	}`)
+
+	full, err := ValueFromJSON(data, typ) //nolint:staticcheck
+
+	if err != nil {
+		t.Fatalf("unexpected ValueFromJSON error: %s", err)
+	}
+
+	testCases := map[string]struct {
+		path         *AttributePath
+		expectedPath *AttributePath
+	}{
+		"root": {
+			path: NewAttributePath(),
+		},
+		"attribute": {
+			path: NewAttributePath().WithAttributeName("id"),
+		},
+		"attribute-null": {
+			path: NewAttributePath().WithAttributeName("region"),
+		},
+		"attribute-absent": {
+			path: NewAttributePath().WithAttributeName("missing"),
+		},
+		"attribute-absent-element": {
+			path:         NewAttributePath().WithAttributeName("missing").WithElementKeyInt(0),
+			expectedPath: NewAttributePath().WithAttributeName("missing"),
+		},
+		"attribute-undefined": {
+			path:         NewAttributePath().WithAttributeName("undefined"),
+			expectedPath: NewAttributePath(),
+		},
+		"map-element": {
+			path: NewAttributePath().WithAttributeName("tags").WithElementKeyString("team"),
+		},
+		"list-element": {
+			path: NewAttributePath().WithAttributeName("names").WithElementKeyInt(1),
+		},
+		"list-element-out-of-range": {
+			path:         NewAttributePath().WithAttributeName("names").WithElementKeyInt(5),
+			expectedPath: NewAttributePath().WithAttributeName("names"),
+		},
+		"set-element": {
+			path: NewAttributePath().WithAttributeName("rules").WithElementKeyValue(NewValue(Number, 443)),
+		},
+		"tuple-element": {
+			path: NewAttributePath().WithAttributeName("pair").WithElementKeyInt(0),
+		},
+		"dynamic-attribute": {
+			path: NewAttributePath().WithAttributeName("dynamic").WithAttributeName("x"),
+		},
+		"through-null": {
+			path:         NewAttributePath().WithAttributeName("region").WithElementKeyInt(0),
+			expectedPath: NewAttributePath().WithAttributeName("region"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ValueFromJSONAtPath(data, typ, testCase.path) //nolint:staticcheck
+
+			expected, _, expectedErr := WalkAttributePath(full, testCase.path)
+
+			if expectedErr != nil {
+				if !errors.Is(err, expectedErr) {
+					t.Fatalf("expected error %q, got %v", expectedErr, err)
+				}
+
+				var pathErr AttributePathError
+
+				if !errors.As(err, &pathErr) {
+					t.Fatalf("expected AttributePathError, got %#v", err)
+				}
+
+				if !pathErr.Path.Equal(testCase.expectedPath) {
+					t.Errorf("expected error path %s, got %s", testCase.expectedPath, pathErr.Path)
+				}
+
+				return
+			}
+
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(expected, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
@@ -51,3 +51,109 @@ func benchmarkValueApplyTerraform5AttributePathStep(b *testing.B, elements int)
 		}
 	}
 }
+
+func BenchmarkValueEqualSet1000(b *testing.B) {
+	value1, value2 := benchmarkSetValues(1000)
+
+	for n := 0; n < b.N; n++ {
+		if !value1.Equal(value2) {
+			b.Fatal("expected values to be equal")
+		}
+	}
+}
+
+func BenchmarkValueDiffSet1000(b *testing.B) {
+	value1, value2 := benchmarkSetValues(1000)
+
+	for n := 0; n < b.N; n++ {
+		diffs, err := value1.Diff(value2)
+
+		if err != nil {
+			b.Fatalf("unexpected Diff error: %s", err)
+		}
+
+		if len(diffs) != 0 {
+			b.Fatalf("unexpected diffs: %v", diffs)
+		}
+	}
+}
+
+// benchmarkSetValues returns two equal sets of objects, with the elements of
+// the second set in reverse order.
+func benchmarkSetValues(elements int) (Value, Value) {
+	objectType := Object{
+		AttributeTypes: map[string]Type{
+			"element_index": Number, // guaranteed to be different each element
+			"test_string":   String,
+		},
+	}
+	setType := Set{
+		ElementType: objectType,
+	}
+
+	setElements1 := make([]Value, elements)
+	setElements2 := make([]Value, elements)
+
+	for index := range setElements1 {
+		setElements1[index] = NewValue(
+			objectType,
+			map[string]Value{
+				"element_index": NewValue(Number, index),
+				"test_string":   NewValue(String, "test value"),
+			},
+		)
+		setElements2[elements-index-1] = setElements1[index].Copy()
+	}
+
+	return NewValue(setType, setElements1), NewValue(setType, setElements2)
+}
+
+func BenchmarkValueFromMsgPack1000(b *testing.B) {
+	benchmarkValueFromMsgPack(b, 1000)
+}
+
+// This benchmark decodes a state with a list of objects with number
+// attributes, such as ports and counts, which are mostly small integers.
+func benchmarkValueFromMsgPack(b *testing.B, elements int) {
+	objectType := Object{
+		AttributeTypes: map[string]Type{
+			"count": Number,
+			"port":  Number,
+			"ratio": Number,
+			"name":  String,
+		},
+	}
+	listType := List{
+		ElementType: objectType,
+	}
+
+	listElements := make([]Value, elements)
+
+	for index := range listElements {
+		listElements[index] = NewValue(
+			objectType,
+			map[string]Value{
+				"count": NewValue(Number, index),
+				"port":  NewValue(Number, 8000+index),
+				"ratio": NewValue(Number, 0.5),
+				"name":  NewValue(String, "test value"),
+			},
+		)
+	}
+
+	data, err := NewValue(listType, listElements).MarshalMsgPack(listType) //nolint:staticcheck
+
+	if err != nil {
+		b.Fatalf("unexpected MarshalMsgPack error: %s", err)
+	}
+
+	b.ReportAllocs()
+
+	for n := 0; n < b.N; n++ {
+		_, err := ValueFromMsgPack(data, listType) //nolint:staticcheck
+
+		if err != nil {
+			b.Fatalf("unexpected ValueFromMsgPack error: %s", err)
+		}
+	}
+}
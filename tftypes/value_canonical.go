@@ -0,0 +1,203 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tftypes
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"encoding/binary"
+	"math/big"
+	"slices"
+	"sort"
+)
+
+// CanonicalEncoding returns a deterministic binary encoding of the Value and
+// its Type, suitable for use as a cache key. Two Values have the same
+// canonical encoding if and only if they have the same Type and their data is
+// considered equal: attributes of maps and objects are encoded in key order,
+// set elements are encoded in a sorted order, and numerically equal numbers
+// are encoded the same, regardless of their precision. Null and unknown
+// Values are encoded differently from each other and from any known Value.
+//
+// The encoding is only intended for comparison and hashing. It cannot be
+// decoded back into a Value.
+func (val Value) CanonicalEncoding() []byte {
+	return val.canonicalEncoding(true)
+}
+
+// canonicalEncoding returns the canonical encoding of the Value. If
+// `dynamicTypes` is false, the Types of Values where the Type of the parent
+// allows any type are left out, as Equal does not compare them if the Values
+// are null, unknown, or empty collections.
+func (val Value) canonicalEncoding(dynamicTypes bool) []byte {
+	var buf bytes.Buffer
+
+	writeCanonicalType(&buf, val)
+	writeCanonicalValue(&buf, val, dynamicTypes)
+
+	return buf.Bytes()
+}
+
+// ContentHash returns the SHA-256 hash of the CanonicalEncoding of the Value.
+// Unlike Hash, the hash includes the Types of all Values within the Value and
+// collisions are not expected in practice, so it can be used in place of the
+// Value as a cache key.
+func (val Value) ContentHash() [sha256.Size]byte {
+	return sha256.Sum256(val.CanonicalEncoding())
+}
+
+// Tags written before the data of each Value in its canonical encoding.
+const (
+	canonicalTagUnknown byte = iota
+	canonicalTagNull
+	canonicalTagString
+	canonicalTagNumber
+	canonicalTagBool
+	canonicalTagElements
+	canonicalTagAttributes
+)
+
+func writeCanonicalLen(buf *bytes.Buffer, n int) {
+	var lenBuf [binary.MaxVarintLen64]byte
+
+	buf.Write(lenBuf[:binary.PutUvarint(lenBuf[:], uint64(n))])
+}
+
+func writeCanonicalString(buf *bytes.Buffer, s string) {
+	writeCanonicalLen(buf, len(s))
+	buf.WriteString(s)
+}
+
+// writeCanonicalType writes the Type of the Value, in type constraint syntax.
+// It is written for the root Value and any Value where the Type of the parent
+// allows any type, as the Type of other Values follows from the root.
+func writeCanonicalType(buf *bytes.Buffer, val Value) {
+	if val.Type() == nil {
+		writeCanonicalString(buf, "")
+
+		return
+	}
+
+	constraint, err := FormatTypeConstraint(val.Type())
+
+	// Only incomplete Types and Types from outside this package cannot
+	// be formatted.
+	if err != nil {
+		constraint = val.Type().String()
+	}
+
+	writeCanonicalString(buf, constraint)
+}
+
+// writeCanonicalElement writes an element or attribute of a Value, with its
+// Type if `declared`, the Type expected by its parent, does not determine it
+// and `dynamicTypes` is true.
+func writeCanonicalElement(buf *bytes.Buffer, declared Type, val Value, dynamicTypes bool) {
+	if dynamicTypes && (declared == nil || declared.Is(DynamicPseudoType)) {
+		writeCanonicalType(buf, val)
+	}
+
+	writeCanonicalValue(buf, val, dynamicTypes)
+}
+
+func writeCanonicalValue(buf *bytes.Buffer, val Value, dynamicTypes bool) {
+	if !val.IsKnown() {
+		buf.WriteByte(canonicalTagUnknown)
+
+		return
+	}
+
+	if val.IsNull() {
+		buf.WriteByte(canonicalTagNull)
+
+		return
+	}
+
+	switch v := val.value.(type) {
+	case string:
+		buf.WriteByte(canonicalTagString)
+		writeCanonicalString(buf, v)
+	case *big.Float, int64, uint64, float64:
+		key, _ := numberKey(v)
+
+		buf.WriteByte(canonicalTagNumber)
+		writeCanonicalString(buf, key)
+	case bool:
+		buf.WriteByte(canonicalTagBool)
+
+		if v {
+			buf.WriteByte(1)
+		} else {
+			buf.WriteByte(0)
+		}
+	case []Value:
+		buf.WriteByte(canonicalTagElements)
+		writeCanonicalLen(buf, len(v))
+
+		switch typ := val.Type().(type) {
+		case List:
+			for _, el := range v {
+				writeCanonicalElement(buf, typ.ElementType, el, dynamicTypes)
+			}
+		case Tuple:
+			for pos, el := range v {
+				var elemType Type
+
+				if pos < len(typ.ElementTypes) {
+					elemType = typ.ElementTypes[pos]
+				}
+
+				writeCanonicalElement(buf, elemType, el, dynamicTypes)
+			}
+		case Set:
+			// Set elements are unordered, so their encodings are
+			// sorted to make the encoding independent of the order.
+			encodings := make([][]byte, 0, len(v))
+
+			for _, el := range v {
+				var elBuf bytes.Buffer
+
+				writeCanonicalElement(&elBuf, typ.ElementType, el, dynamicTypes)
+
+				encodings = append(encodings, elBuf.Bytes())
+			}
+
+			slices.SortFunc(encodings, bytes.Compare)
+
+			for _, encoding := range encodings {
+				writeCanonicalLen(buf, len(encoding))
+				buf.Write(encoding)
+			}
+		default:
+			for _, el := range v {
+				writeCanonicalElement(buf, nil, el, dynamicTypes)
+			}
+		}
+	case map[string]Value:
+		keys := make([]string, 0, len(v))
+
+		for k := range v {
+			keys = append(keys, k)
+		}
+
+		sort.Strings(keys)
+
+		buf.WriteByte(canonicalTagAttributes)
+		writeCanonicalLen(buf, len(keys))
+
+		for _, k := range keys {
+			var elemType Type
+
+			switch typ := val.Type().(type) {
+			case Map:
+				elemType = typ.ElementType
+			case Object:
+				elemType = typ.AttributeTypes[k]
+			}
+
+			writeCanonicalString(buf, k)
+			writeCanonicalElement(buf, elemType, v[k], dynamicTypes)
+		}
+	}
+}
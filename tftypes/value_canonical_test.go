@@ -0,0 +1,89 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tftypes
+
+import (
+	"bytes"
+	"math/big"
+	"testing"
+)
+
+func TestValueCanonicalEncoding(t *testing.T) {
+	t.Parallel()
+
+	objType := Object{AttributeTypes: map[string]Type{"name": String, "tags": Set{ElementType: String}}}
+
+	testCases := map[string]struct {
+		val1  Value
+		val2  Value
+		equal bool
+	}{
+		"number-precision": {
+			val1:  NewValue(Number, big.NewFloat(0.5)),
+			val2:  NewValue(Number, new(big.Float).SetPrec(512).SetFloat64(0.5)),
+			equal: true,
+		},
+		"number-zero-sign": {
+			val1:  NewValue(Number, big.NewFloat(0)),
+			val2:  NewValue(Number, new(big.Float).Neg(big.NewFloat(0))),
+			equal: true,
+		},
+		"object-set-order": {
+			val1: NewValue(objType, map[string]Value{
+				"name": NewValue(String, "a"),
+				"tags": NewValue(Set{ElementType: String}, []Value{NewValue(String, "x"), NewValue(String, "y")}),
+			}),
+			val2: NewValue(objType, map[string]Value{
+				"tags": NewValue(Set{ElementType: String}, []Value{NewValue(String, "y"), NewValue(String, "x")}),
+				"name": NewValue(String, "a"),
+			}),
+			equal: true,
+		},
+		"null-unknown": {
+			val1: NewValue(objType, nil),
+			val2: NewValue(objType, UnknownValue),
+		},
+		"null-different-types": {
+			val1: NewValue(String, nil),
+			val2: NewValue(Number, nil),
+		},
+		"list-set": {
+			val1: NewValue(List{ElementType: String}, []Value{NewValue(String, "x")}),
+			val2: NewValue(Set{ElementType: String}, []Value{NewValue(String, "x")}),
+		},
+		"string-boundary": {
+			val1: NewValue(List{ElementType: String}, []Value{NewValue(String, "ab"), NewValue(String, "c")}),
+			val2: NewValue(List{ElementType: String}, []Value{NewValue(String, "a"), NewValue(String, "bc")}),
+		},
+		"dynamic-element-types": {
+			val1: NewValue(Tuple{ElementTypes: []Type{DynamicPseudoType}}, []Value{NewValue(String, "1")}),
+			val2: NewValue(Tuple{ElementTypes: []Type{DynamicPseudoType}}, []Value{NewValue(Number, 1)}),
+		},
+		"dynamic-null-types": {
+			val1: NewValue(List{ElementType: DynamicPseudoType}, []Value{NewValue(String, nil)}),
+			val2: NewValue(List{ElementType: DynamicPseudoType}, []Value{NewValue(Number, nil)}),
+		},
+		"dynamic-equal": {
+			val1:  NewValue(Map{ElementType: DynamicPseudoType}, map[string]Value{"a": NewValue(Bool, true)}),
+			val2:  NewValue(Map{ElementType: DynamicPseudoType}, map[string]Value{"a": NewValue(Bool, true)}),
+			equal: true,
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got := bytes.Equal(testCase.val1.CanonicalEncoding(), testCase.val2.CanonicalEncoding())
+
+			if got != testCase.equal {
+				t.Errorf("expected canonical encodings of %s and %s to be equal: %t, got: %t", testCase.val1, testCase.val2, testCase.equal, got)
+			}
+
+			if got := testCase.val1.ContentHash() == testCase.val2.ContentHash(); got != testCase.equal {
+				t.Errorf("expected content hashes of %s and %s to be equal: %t, got: %t", testCase.val1, testCase.val2, testCase.equal, got)
+			}
+		})
+	}
+}
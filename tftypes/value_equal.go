@@ -6,7 +6,6 @@ package tftypes
 import (
 	"errors"
 	"fmt"
-	"math/big"
 )
 
 // deepEqual walks both Value to ensure any underlying Value are equal. This
@@ -34,8 +33,8 @@ func (val1 Value) deepEqual(val2 Value) (bool, error) {
 	var hasDiff bool
 
 	// make sure everything in val2 is also in val1
-	err := Walk(val2, func(path *AttributePath, _ Value) (bool, error) {
-		_, _, err := val1.walkAttributePath(path)
+	err := Walk(val2, func(path *AttributePath, value2 Value) (bool, error) {
+		value1, _, err := val1.walkAttributePath(path)
 
 		if err != nil && err != ErrInvalidStep {
 			return false, fmt.Errorf("Error walking %q: %w", path, err)
@@ -45,6 +44,24 @@ func (val1 Value) deepEqual(val2 Value) (bool, error) {
 			return false, stopWalkError
 		}
 
+		// Looking up each set element by path would compare it to every
+		// element of the other set, so sets are compared by hash instead.
+		if _, ok := value2.Type().(Set); ok {
+			missing, err := missingSetElements(value2, value1)
+
+			if err != nil {
+				return false, fmt.Errorf("Error comparing %q: %w", path, err)
+			}
+
+			if len(missing) > 0 {
+				hasDiff = true
+
+				return false, stopWalkError
+			}
+
+			return false, nil
+		}
+
 		return true, nil
 	})
 
@@ -117,19 +134,15 @@ func (val1 Value) deepEqual(val2 Value) (bool, error) {
 					return false, stopWalkError
 				}
 			case Number.name:
-				n1, ok := value1.value.(*big.Float)
-
-				if !ok {
+				if !isNumberValue(value1.value) {
 					return false, fmt.Errorf("cannot convert %T into *big.Float", value1.value)
 				}
 
-				n2, ok := value2.value.(*big.Float)
-
-				if !ok {
+				if !isNumberValue(value2.value) {
 					return false, fmt.Errorf("cannot convert %T into *big.Float", value2.value)
 				}
 
-				if n1.Cmp(n2) != 0 {
+				if cmp, _ := compareNumbers(value1.value, value2.value); cmp != 0 {
 					hasDiff = true
 
 					return false, stopWalkError
@@ -158,7 +171,40 @@ func (val1 Value) deepEqual(val2 Value) (bool, error) {
 			}
 
 			return false, nil
-		case List, Set, Tuple:
+		case Set:
+			s1, ok := value1.value.([]Value)
+
+			if !ok {
+				return false, fmt.Errorf("cannot convert %T into []tftypes.Value", value1.value)
+			}
+
+			s2, ok := value2.value.([]Value)
+
+			if !ok {
+				return false, fmt.Errorf("cannot convert %T into []tftypes.Value", value2.value)
+			}
+
+			if len(s1) != len(s2) {
+				hasDiff = true
+
+				return false, stopWalkError
+			}
+
+			missing, err := missingSetElements(value1, value2)
+
+			if err != nil {
+				return false, fmt.Errorf("Error comparing %q: %w", path, err)
+			}
+
+			if len(missing) > 0 {
+				hasDiff = true
+
+				return false, stopWalkError
+			}
+
+			// The elements were compared in full by missingSetElements.
+			return false, nil
+		case List, Tuple:
 			s1, ok := value1.value.([]Value)
 
 			if !ok {
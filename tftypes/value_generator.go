@@ -0,0 +1,387 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tftypes
+
+import (
+	"encoding/binary"
+	"math"
+	"math/big"
+	"math/rand"
+	"reflect"
+	"sort"
+	"strings"
+	"unicode/utf8"
+)
+
+// ValueGenerator generates random, valid Values of a Type, for property-based
+// testing and fuzzing of code that handles Values. Generated Values include
+// null and unknown values, empty collections, and strings and numbers that are
+// commonly mishandled, such as empty strings, multi-byte characters, and
+// numbers that cannot be represented by an int64 or float64.
+//
+// Where the Type contains DynamicPseudoType, a random Type is generated for
+// the Value. Generated Values have the same Types as the Values decoded from
+// their MsgPack or JSON encoding, so they can be compared directly after a
+// round trip.
+//
+// A ValueGenerator can be used with a *rand.Rand, with testing/quick through
+// QuickValues, and with native Go fuzzing through GenerateFromBytes.
+type ValueGenerator struct {
+	// Type is the Type of the generated Values.
+	Type Type
+
+	// NoNulls, when set to true, only generates Values that are not null.
+	NoNulls bool
+
+	// NoUnknowns, when set to true, only generates Values that are known,
+	// such as for Values that are encoded as JSON.
+	NoUnknowns bool
+
+	// Nullable, when set, is called with the AttributePath of every Value
+	// before it is generated. Returning false prevents that Value from
+	// being null. The ElementKeyValue of set elements is an unknown Value,
+	// as the element has not been generated yet.
+	Nullable func(*AttributePath) bool
+
+	// MaxElements is the maximum number of elements of generated lists,
+	// sets, and maps. It defaults to 4.
+	MaxElements int
+
+	// MaxDepth is the maximum depth of Types generated for
+	// DynamicPseudoType. It defaults to 3.
+	MaxDepth int
+}
+
+// Generate returns a random Value of the Type of the ValueGenerator, using
+// `r` as the source of randomness.
+func (g ValueGenerator) Generate(r *rand.Rand) Value {
+	return g.generate(r, g.Type, NewAttributePath())
+}
+
+// GenerateFromBytes returns a Value of the Type of the ValueGenerator,
+// determined by `data`. The same data always generates the same Value, and
+// small changes to the data make small changes to the Value, which makes it
+// suitable for use with native Go fuzzing:
+//
+//	f.Fuzz(func(t *testing.T, data []byte) {
+//		val := generator.GenerateFromBytes(data)
+//		// ...
+//	})
+func (g ValueGenerator) GenerateFromBytes(data []byte) Value {
+	return g.Generate(rand.New(&byteSource{data: data}))
+}
+
+// QuickValues sets every argument to a random Value of the Type of the
+// ValueGenerator. It can be used as the Values of a quick.Config, for
+// functions that only have Value arguments:
+//
+//	err := quick.Check(func(val tftypes.Value) bool {
+//		// ...
+//	}, &quick.Config{
+//		Values: generator.QuickValues,
+//	})
+func (g ValueGenerator) QuickValues(args []reflect.Value, r *rand.Rand) {
+	for pos := range args {
+		args[pos] = reflect.ValueOf(g.Generate(r))
+	}
+}
+
+// byteSource is a rand.Source that returns the bytes of data, followed by
+// zeros once the data is exhausted.
+type byteSource struct {
+	data []byte
+}
+
+func (s *byteSource) Int63() int64 {
+	var buf [8]byte
+
+	n := copy(buf[:], s.data)
+	s.data = s.data[n:]
+
+	return int64(binary.LittleEndian.Uint64(buf[:]) & math.MaxInt64)
+}
+
+func (s *byteSource) Seed(int64) {}
+
+func (g ValueGenerator) maxElements() int {
+	if g.MaxElements <= 0 {
+		return 4
+	}
+
+	return g.MaxElements
+}
+
+func (g ValueGenerator) maxDepth() int {
+	if g.MaxDepth <= 0 {
+		return 3
+	}
+
+	return g.MaxDepth
+}
+
+func (g ValueGenerator) generate(r *rand.Rand, typ Type, path *AttributePath) Value {
+	// One in eight Values is null and one in eight is unknown.
+	switch r.Intn(8) {
+	case 0:
+		if !g.NoNulls && (g.Nullable == nil || g.Nullable(path)) {
+			return NewValue(typ, nil)
+		}
+	case 1:
+		if !g.NoUnknowns {
+			return NewValue(typ, UnknownValue)
+		}
+	}
+
+	switch typ := typ.(type) {
+	case primitive:
+		switch typ.name {
+		case String.name:
+			return NewValue(String, g.generateString(r))
+		case Number.name:
+			return NewValue(Number, g.generateNumber(r))
+		case Bool.name:
+			return NewValue(Bool, r.Intn(2) == 0)
+		case DynamicPseudoType.name:
+			return g.generate(r, g.generateType(r, g.maxDepth()), path)
+		}
+	case List:
+		elemType := typ.ElementType
+		length := r.Intn(g.maxElements() + 1)
+
+		// Lists of DynamicPseudoType are decoded with the type of
+		// their elements, unless they are empty.
+		if elemType.Is(DynamicPseudoType) && length > 0 {
+			elemType = g.generateType(r, g.maxDepth())
+			typ = List{ElementType: elemType}
+		}
+
+		elems := make([]Value, 0, length)
+
+		for pos := 0; pos < length; pos++ {
+			elems = append(elems, g.generate(r, elemType, path.WithElementKeyInt(pos)))
+		}
+
+		return NewValue(typ, elems)
+	case Set:
+		elemType := typ.ElementType
+		length := r.Intn(g.maxElements() + 1)
+
+		// Like lists, sets of DynamicPseudoType are decoded with the
+		// type of their elements.
+		if elemType.Is(DynamicPseudoType) && length > 0 {
+			elemType = g.generateType(r, g.maxDepth())
+			typ = Set{ElementType: elemType}
+		}
+
+		elems := make([]Value, 0, length)
+
+		for pos := 0; pos < length; pos++ {
+			elems = append(elems, g.generate(r, elemType, path.WithElementKeyValue(NewValue(elemType, UnknownValue))))
+		}
+
+		// Generated elements may be equal, but set elements are unique.
+		elems, _ = uniqueSetElements(elems, false)
+
+		return NewValue(typ, elems)
+	case Map:
+		elemType := typ.ElementType
+
+		// The elements of maps of DynamicPseudoType must all have the
+		// same type.
+		if elemType.Is(DynamicPseudoType) {
+			elemType = g.generateType(r, g.maxDepth())
+		}
+
+		length := r.Intn(g.maxElements() + 1)
+		elems := make(map[string]Value, length)
+
+		for pos := 0; pos < length; pos++ {
+			key := g.generateString(r)
+			elems[key] = g.generate(r, elemType, path.WithElementKeyString(key))
+		}
+
+		return NewValue(typ, elems)
+	case Tuple:
+		elems := make([]Value, 0, len(typ.ElementTypes))
+
+		for pos, elemType := range typ.ElementTypes {
+			elems = append(elems, g.generate(r, elemType, path.WithElementKeyInt(pos)))
+		}
+
+		return NewValue(typ, elems)
+	case Object:
+		attrs := make(map[string]Value, len(typ.AttributeTypes))
+
+		for _, name := range sortedTypeKeys(typ.AttributeTypes) {
+			attrs[name] = g.generate(r, typ.AttributeTypes[name], path.WithAttributeName(name))
+		}
+
+		// Values cannot have optional attributes.
+		return NewValue(Object{AttributeTypes: typ.AttributeTypes}, attrs)
+	}
+
+	panic("tftypes.ValueGenerator: unsupported type " + typ.String())
+}
+
+// sortedTypeKeys returns the keys of the map in order, so Values are generated
+// in a deterministic order.
+func sortedTypeKeys(m map[string]Type) []string {
+	keys := make([]string, 0, len(m))
+
+	for k := range m {
+		keys = append(keys, k)
+	}
+
+	sort.Strings(keys)
+
+	return keys
+}
+
+// generateType returns a random Type, other than DynamicPseudoType, nested at
+// most `depth` levels deep.
+func (g ValueGenerator) generateType(r *rand.Rand, depth int) Type {
+	kinds := 3
+
+	if depth > 1 {
+		kinds = 8
+	}
+
+	switch r.Intn(kinds) {
+	case 0:
+		return String
+	case 1:
+		return Number
+	case 2:
+		return Bool
+	case 3:
+		return List{ElementType: g.generateType(r, depth-1)}
+	case 4:
+		return Set{ElementType: g.generateType(r, depth-1)}
+	case 5:
+		return Map{ElementType: g.generateType(r, depth-1)}
+	case 6:
+		elemTypes := make([]Type, r.Intn(3))
+
+		for pos := range elemTypes {
+			elemTypes[pos] = g.generateType(r, depth-1)
+		}
+
+		return Tuple{ElementTypes: elemTypes}
+	default:
+		attrTypes := make(map[string]Type)
+
+		for pos := r.Intn(4); pos > 0; pos-- {
+			attrTypes[generatedAttributeNames[r.Intn(len(generatedAttributeNames))]] = g.generateType(r, depth-1)
+		}
+
+		return Object{AttributeTypes: attrTypes}
+	}
+}
+
+var generatedAttributeNames = []string{"a", "id", "name", "nested_attribute", "_", "attribute-with-dashes", "UPPER"}
+
+// generatedStrings are strings that are commonly mishandled.
+var generatedStrings = []string{
+	"",
+	" ",
+	"null",
+	"true",
+	"0",
+	"-1.5",
+	`"quoted"`,
+	`back\slash`,
+	"line\nbreak",
+	"tab\tseparated",
+	"\x00",
+	"été",
+	"日本語",
+	"\U0001f600",
+	"e\u0301",
+	"\ufeffbyte order mark",
+	"${interpolation}",
+	"%{directive}",
+	strings.Repeat("long", 256),
+}
+
+func (g ValueGenerator) generateString(r *rand.Rand) string {
+	if r.Intn(2) == 0 {
+		return generatedStrings[r.Intn(len(generatedStrings))]
+	}
+
+	var res strings.Builder
+
+	for pos := r.Intn(16); pos > 0; pos-- {
+		var c rune
+
+		switch r.Intn(4) {
+		case 0:
+			// any valid rune
+			c = rune(r.Intn(utf8.MaxRune + 1))
+		case 1:
+			// control characters
+			c = rune(r.Intn(0x20))
+		default:
+			// printable ASCII
+			c = rune(0x20 + r.Intn(0x5f))
+		}
+
+		if !utf8.ValidRune(c) {
+			c = utf8.RuneError
+		}
+
+		res.WriteRune(c)
+	}
+
+	return res.String()
+}
+
+// generatedNumbers are numbers that are commonly mishandled, as the strings
+// Terraform would decode them from.
+var generatedNumbers = []string{
+	"0",
+	"1",
+	"-1",
+	"0.5",
+	"0.1",
+	"9007199254740993",
+	"9223372036854775807",
+	"-9223372036854775808",
+	"9223372036854775808",
+	"18446744073709551615",
+	"18446744073709551616",
+	"1e300",
+	"-1e-300",
+	"123456789012345678901234567890.123456789",
+	"0.000000000000000000000000000001",
+}
+
+func (g ValueGenerator) generateNumber(r *rand.Rand) interface{} {
+	switch r.Intn(4) {
+	case 0:
+		// Numbers are decoded with the same precision Terraform uses.
+		// See msgpackUnmarshal.
+		n, _, _ := big.ParseFloat(generatedNumbers[r.Intn(len(generatedNumbers))], 10, 512, big.ToNearestEven)
+
+		return n
+	case 1:
+		return r.Int63() - r.Int63()
+	case 2:
+		return int64(r.Intn(201) - 100)
+	default:
+		// Integral float64s outside of the int64 range are encoded
+		// as decimal strings, which are not exact, so only generate
+		// float64s with a fractional part.
+		f := float64(r.Int63()) / (1 << 63) * math.Pow(10, float64(r.Intn(21)-10))
+
+		if r.Intn(2) == 0 {
+			f = -f
+		}
+
+		if math.Trunc(f) == f {
+			f += 0.5
+		}
+
+		return f
+	}
+}
@@ -0,0 +1,238 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tftypes
+
+import (
+	"math/rand"
+	"testing"
+	"testing/quick"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+// generatorTestTypes are the Types used for round trip tests with generated
+// Values, covering every kind of Type and the positions DynamicPseudoType is
+// handled differently in.
+var generatorTestTypes = map[string]Type{
+	"string":       String,
+	"number":       Number,
+	"bool":         Bool,
+	"dynamic":      DynamicPseudoType,
+	"list-dynamic": List{ElementType: DynamicPseudoType},
+	"set-dynamic":  Set{ElementType: DynamicPseudoType},
+	"map-dynamic":  Map{ElementType: DynamicPseudoType},
+	"object": Object{
+		AttributeTypes: map[string]Type{
+			"id":      String,
+			"count":   Number,
+			"enabled": Bool,
+			"tags":    Map{ElementType: String},
+			"rules": Set{ElementType: Object{
+				AttributeTypes: map[string]Type{
+					"port":  Number,
+					"extra": DynamicPseudoType,
+				},
+			}},
+			"pairs": List{ElementType: Tuple{ElementTypes: []Type{String, Number}}},
+			"any":   DynamicPseudoType,
+		},
+	},
+}
+
+func TestValueGenerator_deterministic(t *testing.T) {
+	t.Parallel()
+
+	g := ValueGenerator{Type: generatorTestTypes["object"]}
+
+	for seed := int64(0); seed < 10; seed++ {
+		val1 := g.Generate(rand.New(rand.NewSource(seed)))
+		val2 := g.Generate(rand.New(rand.NewSource(seed)))
+
+		if diff := cmp.Diff(val1, val2); diff != "" {
+			t.Errorf("seed %d: unexpected difference: %s", seed, diff)
+		}
+	}
+
+	data := []byte("some fuzzer input")
+
+	if diff := cmp.Diff(g.GenerateFromBytes(data), g.GenerateFromBytes(data)); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+func TestValueGenerator_options(t *testing.T) {
+	t.Parallel()
+
+	g := ValueGenerator{
+		Type:        generatorTestTypes["object"],
+		NoUnknowns:  true,
+		MaxElements: 2,
+		Nullable: func(path *AttributePath) bool {
+			return len(path.Steps()) > 1
+		},
+	}
+
+	err := quick.Check(func(val Value) bool {
+		if val.IsNull() {
+			return false
+		}
+
+		ok := true
+
+		_ = Walk(val, func(path *AttributePath, v Value) (bool, error) {
+			if !v.IsKnown() {
+				ok = false
+			}
+
+			if v.IsNull() && len(path.Steps()) == 1 {
+				ok = false
+			}
+
+			switch elems := v.value.(type) {
+			case []Value:
+				if !v.Type().Is(Tuple{}) && len(elems) > 2 {
+					ok = false
+				}
+			case map[string]Value:
+				if v.Type().Is(Map{}) && len(elems) > 2 {
+					ok = false
+				}
+			}
+
+			return true, nil
+		})
+
+		return ok
+	}, &quick.Config{
+		Values: g.QuickValues,
+	})
+
+	if err != nil {
+		t.Error(err)
+	}
+}
+
+func TestValueGenerator_msgPackRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for name, typ := range generatorTestTypes {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := quick.Check(func(val Value) bool {
+				return msgPackRoundTrips(t, val, typ)
+			}, &quick.Config{
+				MaxCount: 200,
+				Values:   ValueGenerator{Type: typ}.QuickValues,
+			})
+
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func TestValueGenerator_jsonRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for name, typ := range generatorTestTypes {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			err := quick.Check(func(val Value) bool {
+				return jsonRoundTrips(t, val)
+			}, &quick.Config{
+				MaxCount: 200,
+				Values:   ValueGenerator{Type: typ, NoUnknowns: true}.QuickValues,
+			})
+
+			if err != nil {
+				t.Error(err)
+			}
+		})
+	}
+}
+
+func FuzzValueMsgPackRoundTrip(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte("a seed with enough bytes for a few nested values"))
+
+	g := ValueGenerator{Type: DynamicPseudoType}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		val := g.GenerateFromBytes(data)
+
+		if !msgPackRoundTrips(t, val, DynamicPseudoType) {
+			t.Fail()
+		}
+	})
+}
+
+func FuzzValueJSONRoundTrip(f *testing.F) {
+	f.Add([]byte{})
+	f.Add([]byte("a seed with enough bytes for a few nested values"))
+
+	g := ValueGenerator{Type: DynamicPseudoType, NoUnknowns: true}
+
+	f.Fuzz(func(t *testing.T, data []byte) {
+		val := g.GenerateFromBytes(data)
+
+		if !jsonRoundTrips(t, val) {
+			t.Fail()
+		}
+	})
+}
+
+func msgPackRoundTrips(t *testing.T, val Value, typ Type) bool {
+	t.Helper()
+
+	data, err := val.MarshalMsgPack(typ) //nolint:staticcheck
+	if err != nil {
+		t.Logf("unexpected error marshaling %s: %s", val, err)
+
+		return false
+	}
+
+	got, err := ValueFromMsgPack(data, typ) //nolint:staticcheck
+	if err != nil {
+		t.Logf("unexpected error unmarshaling %s: %s", val, err)
+
+		return false
+	}
+
+	if diff := cmp.Diff(val, got); diff != "" {
+		t.Logf("unexpected difference: %s", diff)
+
+		return false
+	}
+
+	return true
+}
+
+func jsonRoundTrips(t *testing.T, val Value) bool {
+	t.Helper()
+
+	data, err := jsonMarshalDynamic(val, NewAttributePath())
+	if err != nil {
+		t.Logf("unexpected error marshaling %s: %s", val, err)
+
+		return false
+	}
+
+	got, err := ValueFromJSON(data, DynamicPseudoType) //nolint:staticcheck
+	if err != nil {
+		t.Logf("unexpected error unmarshaling %s: %s", data, err)
+
+		return false
+	}
+
+	if diff := cmp.Diff(val, got); diff != "" {
+		t.Logf("unexpected difference: %s", diff)
+
+		return false
+	}
+
+	return true
+}
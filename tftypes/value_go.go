@@ -0,0 +1,482 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tftypes
+
+import (
+	"bytes"
+	"encoding/json"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+)
+
+// GoUnknownMode controls how ToGoWithOpts converts unknown values.
+type GoUnknownMode int
+
+const (
+	// GoUnknownError returns an error for unknown values. This is the
+	// default.
+	GoUnknownError GoUnknownMode = 0
+
+	// GoUnknownNil converts unknown values to nil, like null values.
+	GoUnknownNil GoUnknownMode = 1
+
+	// GoUnknownPlaceholder converts unknown values to the
+	// UnknownPlaceholder of the ToGoOpts.
+	GoUnknownPlaceholder GoUnknownMode = 2
+)
+
+// GoNumberMode controls which Go type ToGoWithOpts converts numbers to.
+type GoNumberMode int
+
+const (
+	// GoNumberFloat64 converts numbers to float64, the type used by
+	// encoding/json. Numbers are rounded to the nearest float64, and
+	// numbers outside of the range of float64 result in an error. This is
+	// the default.
+	GoNumberFloat64 GoNumberMode = 0
+
+	// GoNumberJSONNumber converts numbers to json.Number, which represents
+	// them exactly.
+	GoNumberJSONNumber GoNumberMode = 1
+
+	// GoNumberBigFloat converts numbers to *big.Float, which represents
+	// them exactly.
+	GoNumberBigFloat GoNumberMode = 2
+)
+
+// GoSetMode controls how ToGoWithOpts orders the elements of sets.
+type GoSetMode int
+
+const (
+	// GoSetSlice converts sets to a []interface{} with the elements in the
+	// order of the Value. This is the default.
+	GoSetSlice GoSetMode = 0
+
+	// GoSetSortedSlice converts sets to a []interface{} with the elements
+	// in a deterministic order, so equal sets are converted to equal
+	// slices.
+	GoSetSortedSlice GoSetMode = 1
+)
+
+// ToGoOpts contains options that can be used to modify the behaviour of
+// ToGoWithOpts.
+type ToGoOpts struct {
+	// Unknown controls how unknown values are converted.
+	Unknown GoUnknownMode
+
+	// UnknownPlaceholder is the Go value unknown values are converted to
+	// when Unknown is GoUnknownPlaceholder.
+	UnknownPlaceholder interface{}
+
+	// Number controls which Go type numbers are converted to.
+	Number GoNumberMode
+
+	// Set controls the order of the elements of sets.
+	Set GoSetMode
+}
+
+// ToGo returns the plain Go value of the Value, using the shapes of
+// encoding/json:
+//
+//   - null values are nil
+//   - String values are string
+//   - Number values are float64
+//   - Bool values are bool
+//   - List, Set, and Tuple values are []interface{}
+//   - Map and Object values are map[string]interface{}
+//
+// Unknown values result in an error. Use ToGoWithOpts to convert unknown
+// values, represent numbers exactly, or order the elements of sets.
+func ToGo(val Value) (interface{}, error) {
+	return ToGoWithOpts(val, ToGoOpts{})
+}
+
+// ToGoWithOpts is identical to ToGo with the exception that it accepts
+// ToGoOpts, which can be used to modify how unknown values, numbers, and sets
+// are converted.
+func ToGoWithOpts(val Value, opts ToGoOpts) (interface{}, error) {
+	return toGo(val, NewAttributePath(), opts)
+}
+
+func toGo(val Value, p *AttributePath, opts ToGoOpts) (interface{}, error) {
+	if !val.IsKnown() {
+		switch opts.Unknown {
+		case GoUnknownNil:
+			return nil, nil
+		case GoUnknownPlaceholder:
+			return opts.UnknownPlaceholder, nil
+		default:
+			return nil, p.NewErrorf("unknown values cannot be converted to Go values")
+		}
+	}
+
+	if val.IsNull() {
+		return nil, nil
+	}
+
+	switch v := val.value.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return v, nil
+	case []Value:
+		elems := v
+
+		if val.Type().Is(Set{}) && opts.Set == GoSetSortedSlice {
+			elems = sortedSetElements(v)
+		}
+
+		res := make([]interface{}, 0, len(elems))
+
+		for pos, el := range elems {
+			elPath := p.WithElementKeyInt(pos)
+
+			if val.Type().Is(Set{}) {
+				elPath = p.WithElementKeyValue(el)
+			}
+
+			goEl, err := toGo(el, elPath, opts)
+			if err != nil {
+				return nil, err
+			}
+
+			res = append(res, goEl)
+		}
+
+		return res, nil
+	case map[string]Value:
+		res := make(map[string]interface{}, len(v))
+
+		for key, el := range v {
+			elPath := p.WithElementKeyString(key)
+
+			if val.Type().Is(Object{}) {
+				elPath = p.WithAttributeName(key)
+			}
+
+			goEl, err := toGo(el, elPath, opts)
+			if err != nil {
+				return nil, err
+			}
+
+			res[key] = goEl
+		}
+
+		return res, nil
+	}
+
+	n, ok := numberBigFloat(val.value)
+	if !ok {
+		return nil, p.NewErrorf("can't convert %s to a Go value", val.Type())
+	}
+
+	switch opts.Number {
+	case GoNumberJSONNumber:
+		if n.IsInf() {
+			return nil, p.NewErrorf("%s cannot be represented as json.Number", n)
+		}
+
+		return json.Number(jsonNumberText(n)), nil
+	case GoNumberBigFloat:
+		return new(big.Float).Copy(n), nil
+	}
+
+	f, _ := n.Float64()
+
+	if math.IsInf(f, 0) && !n.IsInf() {
+		return nil, p.NewErrorf("%s cannot be represented as float64", n.Text('g', -1))
+	}
+
+	return f, nil
+}
+
+// sortedSetElements returns the elements of a set ordered by their canonical
+// encoding.
+func sortedSetElements(elems []Value) []Value {
+	encodings := make([][]byte, len(elems))
+	order := make([]int, len(elems))
+
+	for pos, el := range elems {
+		encodings[pos] = el.CanonicalEncoding()
+		order[pos] = pos
+	}
+
+	sort.Slice(order, func(i, j int) bool {
+		return bytes.Compare(encodings[order[i]], encodings[order[j]]) < 0
+	})
+
+	res := make([]Value, 0, len(elems))
+
+	for _, pos := range order {
+		res = append(res, elems[pos])
+	}
+
+	return res
+}
+
+// FromGoOpts contains options that can be used to modify the behaviour of
+// FromGoWithOpts.
+type FromGoOpts struct {
+	// UnknownPlaceholder, when not nil, is the Go value that is converted
+	// to unknown values, such as the UnknownPlaceholder of ToGoOpts.
+	UnknownPlaceholder interface{}
+
+	// SetDuplicates controls whether duplicate elements of a
+	// []interface{} converted to a Set are rejected, which is the
+	// default, or removed.
+	SetDuplicates SetDuplicates
+}
+
+// isUnknownPlaceholder returns true if `in` is the UnknownPlaceholder. Go values
+// of types that cannot be compared are never the placeholder.
+func (o FromGoOpts) isUnknownPlaceholder(in interface{}) bool {
+	if o.UnknownPlaceholder == nil || in == nil || !reflect.TypeOf(in).Comparable() {
+		return false
+	}
+
+	return in == o.UnknownPlaceholder
+}
+
+// FromGo returns the Value of Type `typ` for the plain Go value `in`, the
+// inverse of ToGo. In addition to the Go types returned by ToGo, numbers can be
+// any integer or floating point type, json.Number, or *big.Float, and
+// tftypes.Value can be used for any Value. nil is converted to a null value.
+// Objects can be converted from maps that do not have all attributes of the
+// Object, with the missing attributes being null.
+//
+// Where `typ` is DynamicPseudoType, the Type of the Value is determined from
+// the Go value, with []interface{} being a Tuple and map[string]interface{}
+// being an Object.
+func FromGo(in interface{}, typ Type) (Value, error) {
+	return FromGoWithOpts(in, typ, FromGoOpts{})
+}
+
+// FromGoWithOpts is identical to FromGo with the exception that it accepts
+// FromGoOpts, which can be used to convert unknown values and to modify how
+// sets are converted.
+func FromGoWithOpts(in interface{}, typ Type, opts FromGoOpts) (Value, error) {
+	return fromGo(in, typ, NewAttributePath(), opts)
+}
+
+func fromGo(in interface{}, typ Type, p *AttributePath, opts FromGoOpts) (Value, error) {
+	if opts.isUnknownPlaceholder(in) {
+		return NewValue(typ, UnknownValue), nil
+	}
+
+	if in == nil {
+		return NewValue(typ, nil), nil
+	}
+
+	if val, ok := in.(Value); ok {
+		if !val.Type().UsableAs(typ) {
+			return Value{}, p.NewErrorf("can't use %s as %s", val.Type(), typ)
+		}
+
+		return val, nil
+	}
+
+	if typ.Is(DynamicPseudoType) {
+		impliedType, err := goImpliedType(in, p, opts)
+		if err != nil {
+			return Value{}, err
+		}
+
+		typ = impliedType
+	}
+
+	switch typ := typ.(type) {
+	case primitive:
+		switch typ.name {
+		case String.name:
+			if v, ok := in.(string); ok {
+				return NewValue(String, v), nil
+			}
+		case Bool.name:
+			if v, ok := in.(bool); ok {
+				return NewValue(Bool, v), nil
+			}
+		case Number.name:
+			return fromGoNumber(in, p)
+		}
+	case List, Set, Tuple:
+		elems, ok := in.([]interface{})
+		if !ok {
+			break
+		}
+
+		if tuple, ok := typ.(Tuple); ok && len(elems) != len(tuple.ElementTypes) {
+			return Value{}, p.NewErrorf("can't use []interface {} with %d elements as %s", len(elems), typ)
+		}
+
+		vals := make([]Value, 0, len(elems))
+
+		for pos, el := range elems {
+			var elType Type
+			elPath := p.WithElementKeyInt(pos)
+
+			switch typ := typ.(type) {
+			case List:
+				elType = typ.ElementType
+			case Set:
+				elType = typ.ElementType
+				elPath = p.WithElementKeyValue(NewValue(elType, UnknownValue))
+			case Tuple:
+				elType = typ.ElementTypes[pos]
+			}
+
+			val, err := fromGo(el, elType, elPath, opts)
+			if err != nil {
+				return Value{}, err
+			}
+
+			vals = append(vals, val)
+		}
+
+		if typ.Is(Set{}) {
+			unique, _ := uniqueSetElements(vals, false)
+
+			if len(unique) != len(vals) && opts.SetDuplicates == SetDuplicatesReject {
+				return Value{}, p.NewErrorf("duplicate set elements")
+			}
+
+			vals = unique
+		}
+
+		val, err := newValue(typ, vals)
+		if err != nil {
+			return Value{}, p.NewError(err)
+		}
+
+		return val, nil
+	case Map, Object:
+		elems, ok := in.(map[string]interface{})
+		if !ok {
+			break
+		}
+
+		vals := make(map[string]Value, len(elems))
+
+		for key, el := range elems {
+			var elType Type
+			elPath := p.WithElementKeyString(key)
+
+			switch typ := typ.(type) {
+			case Map:
+				elType = typ.ElementType
+			case Object:
+				elType, ok = typ.AttributeTypes[key]
+				elPath = p.WithAttributeName(key)
+
+				if !ok {
+					return Value{}, elPath.NewErrorf("unexpected attribute %q, %s has no such attribute", key, typ)
+				}
+			}
+
+			val, err := fromGo(el, elType, elPath, opts)
+			if err != nil {
+				return Value{}, err
+			}
+
+			vals[key] = val
+		}
+
+		if obj, ok := typ.(Object); ok {
+			for name, attrType := range obj.AttributeTypes {
+				if _, ok := vals[name]; !ok {
+					vals[name] = NewValue(attrType, nil)
+				}
+			}
+
+			typ = Object{AttributeTypes: obj.AttributeTypes}
+		}
+
+		val, err := newValue(typ, vals)
+		if err != nil {
+			return Value{}, p.NewError(err)
+		}
+
+		return val, nil
+	}
+
+	return Value{}, p.NewErrorf("can't use %T as %s", in, typ)
+}
+
+func fromGoNumber(in interface{}, p *AttributePath) (Value, error) {
+	switch v := in.(type) {
+	case json.Number:
+		// See jsonUnmarshalNumber for the choice of base, precision, and
+		// rounding mode.
+		n, _, err := big.ParseFloat(string(v), 10, 512, big.ToNearestEven)
+		if err != nil {
+			return Value{}, p.NewErrorf("error parsing number %q: %w", v, err)
+		}
+
+		return NewValue(Number, n), nil
+	case *big.Float:
+		return NewValue(Number, new(big.Float).Copy(v)), nil
+	case float64:
+		if math.IsNaN(v) || math.IsInf(v, 0) {
+			return Value{}, p.NewErrorf("%v cannot be used as %s", v, Number)
+		}
+
+		return NewValue(Number, v), nil
+	case float32:
+		return fromGoNumber(float64(v), p)
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return NewValue(Number, v), nil
+	}
+
+	return Value{}, p.NewErrorf("can't use %T as %s", in, Number)
+}
+
+// goImpliedType returns the Type of the Value FromGo converts `in` to where
+// its Type is DynamicPseudoType.
+func goImpliedType(in interface{}, p *AttributePath, opts FromGoOpts) (Type, error) {
+	if opts.isUnknownPlaceholder(in) {
+		return DynamicPseudoType, nil
+	}
+
+	switch v := in.(type) {
+	case nil:
+		return DynamicPseudoType, nil
+	case Value:
+		return v.Type(), nil
+	case string:
+		return String, nil
+	case bool:
+		return Bool, nil
+	case json.Number, *big.Float, float64, float32, int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		return Number, nil
+	case []interface{}:
+		elemTypes := make([]Type, 0, len(v))
+
+		for pos, el := range v {
+			elemType, err := goImpliedType(el, p.WithElementKeyInt(pos), opts)
+			if err != nil {
+				return nil, err
+			}
+
+			elemTypes = append(elemTypes, elemType)
+		}
+
+		return Tuple{ElementTypes: elemTypes}, nil
+	case map[string]interface{}:
+		attrTypes := make(map[string]Type, len(v))
+
+		for key, el := range v {
+			attrType, err := goImpliedType(el, p.WithAttributeName(key), opts)
+			if err != nil {
+				return nil, err
+			}
+
+			attrTypes[key] = attrType
+		}
+
+		return Object{AttributeTypes: attrTypes}, nil
+	}
+
+	return nil, p.NewErrorf("can't determine the type of %T", in)
+}
@@ -0,0 +1,336 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tftypes
+
+import (
+	"encoding/json"
+	"math/big"
+	"testing"
+	"testing/quick"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestToGo(t *testing.T) {
+	t.Parallel()
+
+	bigNumber, _, _ := big.ParseFloat("123456789012345678901234567890.5", 10, 512, big.ToNearestEven)
+	setType := Set{ElementType: String}
+	set := NewValue(setType, []Value{
+		NewValue(String, "b"),
+		NewValue(String, "a"),
+	})
+
+	testCases := map[string]struct {
+		val           Value
+		opts          ToGoOpts
+		expected      interface{}
+		expectedError string
+	}{
+		"null": {
+			val:      NewValue(String, nil),
+			expected: nil,
+		},
+		"string": {
+			val:      NewValue(String, "hello"),
+			expected: "hello",
+		},
+		"bool": {
+			val:      NewValue(Bool, true),
+			expected: true,
+		},
+		"number": {
+			val:      NewValue(Number, 1.5),
+			expected: 1.5,
+		},
+		"number-json": {
+			val:      NewValue(Number, bigNumber),
+			opts:     ToGoOpts{Number: GoNumberJSONNumber},
+			expected: json.Number("123456789012345678901234567890.5"),
+		},
+		"number-big-float": {
+			val:      NewValue(Number, bigNumber),
+			opts:     ToGoOpts{Number: GoNumberBigFloat},
+			expected: bigNumber,
+		},
+		"list": {
+			val: NewValue(List{ElementType: Number}, []Value{
+				NewValue(Number, 1),
+				NewValue(Number, nil),
+			}),
+			expected: []interface{}{float64(1), nil},
+		},
+		"set": {
+			val:      set,
+			expected: []interface{}{"b", "a"},
+		},
+		"set-sorted": {
+			val:      set,
+			opts:     ToGoOpts{Set: GoSetSortedSlice},
+			expected: []interface{}{"a", "b"},
+		},
+		"object": {
+			val: NewValue(Object{AttributeTypes: map[string]Type{
+				"name": String,
+				"tags": Map{ElementType: Bool},
+			}}, map[string]Value{
+				"name": NewValue(String, "example"),
+				"tags": NewValue(Map{ElementType: Bool}, map[string]Value{
+					"a": NewValue(Bool, false),
+				}),
+			}),
+			expected: map[string]interface{}{
+				"name": "example",
+				"tags": map[string]interface{}{"a": false},
+			},
+		},
+		"unknown": {
+			val: NewValue(List{ElementType: String}, []Value{
+				NewValue(String, UnknownValue),
+			}),
+			expectedError: "ElementKeyInt(0): unknown values cannot be converted to Go values",
+		},
+		"unknown-nil": {
+			val: NewValue(List{ElementType: String}, []Value{
+				NewValue(String, UnknownValue),
+			}),
+			opts:     ToGoOpts{Unknown: GoUnknownNil},
+			expected: []interface{}{nil},
+		},
+		"unknown-placeholder": {
+			val: NewValue(List{ElementType: String}, []Value{
+				NewValue(String, UnknownValue),
+			}),
+			opts:     ToGoOpts{Unknown: GoUnknownPlaceholder, UnknownPlaceholder: "(unknown)"},
+			expected: []interface{}{"(unknown)"},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := ToGoWithOpts(testCase.val, testCase.opts)
+
+			if err != nil {
+				if testCase.expectedError == "" {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if diff := cmp.Diff(testCase.expectedError, err.Error()); diff != "" {
+					t.Fatalf("unexpected error difference: %s", diff)
+				}
+
+				return
+			}
+
+			if testCase.expectedError != "" {
+				t.Fatalf("expected error %q, got none", testCase.expectedError)
+			}
+
+			if diff := cmp.Diff(testCase.expected, got, cmp.Comparer(func(a, b *big.Float) bool {
+				return a.Cmp(b) == 0
+			})); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestFromGo(t *testing.T) {
+	t.Parallel()
+
+	bigNumber, _, _ := big.ParseFloat("123456789012345678901234567890.5", 10, 512, big.ToNearestEven)
+	objectType := Object{AttributeTypes: map[string]Type{
+		"name": String,
+		"port": Number,
+	}}
+
+	testCases := map[string]struct {
+		in            interface{}
+		typ           Type
+		opts          FromGoOpts
+		expected      Value
+		expectedError string
+	}{
+		"null": {
+			in:       nil,
+			typ:      String,
+			expected: NewValue(String, nil),
+		},
+		"string": {
+			in:       "hello",
+			typ:      String,
+			expected: NewValue(String, "hello"),
+		},
+		"float64": {
+			in:       1.5,
+			typ:      Number,
+			expected: NewValue(Number, 1.5),
+		},
+		"int": {
+			in:       42,
+			typ:      Number,
+			expected: NewValue(Number, 42),
+		},
+		"json-number": {
+			in:       json.Number("123456789012345678901234567890.5"),
+			typ:      Number,
+			expected: NewValue(Number, bigNumber),
+		},
+		"wrong-type": {
+			in:            "hello",
+			typ:           Number,
+			expectedError: "can't use string as tftypes.Number",
+		},
+		"list": {
+			in:  []interface{}{"a", nil},
+			typ: List{ElementType: String},
+			expected: NewValue(List{ElementType: String}, []Value{
+				NewValue(String, "a"),
+				NewValue(String, nil),
+			}),
+		},
+		"set-duplicates": {
+			in:            []interface{}{"a", "a"},
+			typ:           Set{ElementType: String},
+			expectedError: "duplicate set elements",
+		},
+		"set-duplicates-remove": {
+			in:   []interface{}{"a", "a"},
+			typ:  Set{ElementType: String},
+			opts: FromGoOpts{SetDuplicates: SetDuplicatesRemove},
+			expected: NewValue(Set{ElementType: String}, []Value{
+				NewValue(String, "a"),
+			}),
+		},
+		"object-missing-attribute": {
+			in:  map[string]interface{}{"name": "example"},
+			typ: objectType,
+			expected: NewValue(objectType, map[string]Value{
+				"name": NewValue(String, "example"),
+				"port": NewValue(Number, nil),
+			}),
+		},
+		"object-unexpected-attribute": {
+			in:            map[string]interface{}{"host": "example.com"},
+			typ:           objectType,
+			expectedError: `AttributeName("host"): unexpected attribute "host", tftypes.Object["name":tftypes.String, "port":tftypes.Number] has no such attribute`,
+		},
+		"unknown-placeholder": {
+			in:   map[string]interface{}{"name": "(unknown)", "port": 22},
+			typ:  objectType,
+			opts: FromGoOpts{UnknownPlaceholder: "(unknown)"},
+			expected: NewValue(objectType, map[string]Value{
+				"name": NewValue(String, UnknownValue),
+				"port": NewValue(Number, 22),
+			}),
+		},
+		"dynamic": {
+			in: map[string]interface{}{
+				"name":  "example",
+				"ports": []interface{}{22, json.Number("443")},
+				"extra": nil,
+			},
+			typ: DynamicPseudoType,
+			expected: NewValue(Object{AttributeTypes: map[string]Type{
+				"name":  String,
+				"ports": Tuple{ElementTypes: []Type{Number, Number}},
+				"extra": DynamicPseudoType,
+			}}, map[string]Value{
+				"name": NewValue(String, "example"),
+				"ports": NewValue(Tuple{ElementTypes: []Type{Number, Number}}, []Value{
+					NewValue(Number, 22),
+					NewValue(Number, 443),
+				}),
+				"extra": NewValue(DynamicPseudoType, nil),
+			}),
+		},
+		"value": {
+			in:       NewValue(String, UnknownValue),
+			typ:      String,
+			expected: NewValue(String, UnknownValue),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := FromGoWithOpts(testCase.in, testCase.typ, testCase.opts)
+
+			if err != nil {
+				if testCase.expectedError == "" {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if diff := cmp.Diff(testCase.expectedError, err.Error()); diff != "" {
+					t.Fatalf("unexpected error difference: %s", diff)
+				}
+
+				return
+			}
+
+			if testCase.expectedError != "" {
+				t.Fatalf("expected error %q, got none", testCase.expectedError)
+			}
+
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestGoRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	typ := Object{
+		AttributeTypes: map[string]Type{
+			"id":    String,
+			"count": Number,
+			"tags":  Map{ElementType: String},
+			"rules": Set{ElementType: Object{
+				AttributeTypes: map[string]Type{"port": Number},
+			}},
+			"pairs": List{ElementType: Tuple{ElementTypes: []Type{String, Bool}}},
+		},
+	}
+	placeholder := struct{ unknown bool }{true}
+
+	err := quick.Check(func(val Value) bool {
+		goVal, err := ToGoWithOpts(val, ToGoOpts{
+			Unknown:            GoUnknownPlaceholder,
+			UnknownPlaceholder: placeholder,
+			Number:             GoNumberJSONNumber,
+		})
+		if err != nil {
+			t.Logf("unexpected error converting %s: %s", val, err)
+
+			return false
+		}
+
+		got, err := FromGoWithOpts(goVal, typ, FromGoOpts{UnknownPlaceholder: placeholder})
+		if err != nil {
+			t.Logf("unexpected error converting %#v: %s", goVal, err)
+
+			return false
+		}
+
+		if diff := cmp.Diff(val, got); diff != "" {
+			t.Logf("unexpected difference: %s", diff)
+
+			return false
+		}
+
+		return true
+	}, &quick.Config{
+		MaxCount: 200,
+		Values:   ValueGenerator{Type: typ}.QuickValues,
+	})
+
+	if err != nil {
+		t.Error(err)
+	}
+}
@@ -0,0 +1,129 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tftypes
+
+import (
+	"hash/fnv"
+)
+
+// Hash returns a structural hash of the Value. Values that are considered
+// equal by Equal always have the same hash, regardless of the order of their
+// set elements or the precision of their numbers. Values with the same hash
+// are not necessarily equal, so Equal should be used to confirm a match.
+//
+// The hash is the 64-bit FNV-1a hash of the CanonicalEncoding of the Value,
+// except that the Types of Values within it are only included where they
+// follow from the Type of the Value, as Equal does not compare the Types of
+// null, unknown, and empty Values where any type is allowed. It only depends
+// on the data in the Value, not on memory addresses or map iteration order,
+// so it is stable across processes.
+func (val Value) Hash() uint64 {
+	h := fnv.New64a()
+
+	_, _ = h.Write(val.canonicalEncoding(false))
+
+	return h.Sum64()
+}
+
+// setIndex indexes set elements by their Hash, so finding an element in a set
+// does not require comparing it to every other element.
+type setIndex map[uint64][]Value
+
+func newSetIndex(elems []Value) setIndex {
+	idx := make(setIndex, len(elems))
+
+	for _, el := range elems {
+		elHash := el.Hash()
+		idx[elHash] = append(idx[elHash], el)
+	}
+
+	return idx
+}
+
+// contains returns true if an element equal to `val` is in the index.
+func (idx setIndex) contains(val Value) (bool, error) {
+	return idx.containsHash(val.Hash(), val)
+}
+
+func (idx setIndex) containsHash(valHash uint64, val Value) (bool, error) {
+	for _, el := range idx[valHash] {
+		deepEqual, err := val.deepEqual(el)
+
+		if err != nil {
+			return false, err
+		}
+
+		if deepEqual {
+			return true, nil
+		}
+	}
+
+	return false, nil
+}
+
+// uniqueSetElements returns the elements without duplicates, keeping the first
+// instance of each element. If `reject` is true, an error is returned for the
+// first duplicate element instead.
+func uniqueSetElements(elems []Value, reject bool) ([]Value, error) {
+	result := make([]Value, 0, len(elems))
+	idx := make(setIndex, len(elems))
+
+	for _, el := range elems {
+		elHash := el.Hash()
+
+		duplicate, err := idx.containsHash(elHash, el)
+
+		if err != nil {
+			return nil, NewAttributePath().WithElementKeyValue(el).NewError(err)
+		}
+
+		if duplicate {
+			if reject {
+				return nil, NewAttributePath().WithElementKeyValue(el).NewErrorf("duplicate set element")
+			}
+
+			continue
+		}
+
+		idx[elHash] = append(idx[elHash], el)
+		result = append(result, el)
+	}
+
+	return result, nil
+}
+
+// missingSetElements returns the elements of the set `val` that are not
+// elements of `other`. If `other` is not a known, non-null set, all elements
+// of `val` are returned.
+func missingSetElements(val, other Value) ([]Value, error) {
+	elems, ok := val.value.([]Value)
+
+	if !ok || len(elems) == 0 {
+		return nil, nil
+	}
+
+	otherElems, ok := other.value.([]Value)
+
+	if _, isSet := other.Type().(Set); !isSet || !ok || !other.IsKnown() {
+		return elems, nil
+	}
+
+	idx := newSetIndex(otherElems)
+
+	var missing []Value
+
+	for _, el := range elems {
+		found, err := idx.contains(el)
+
+		if err != nil {
+			return nil, err
+		}
+
+		if !found {
+			missing = append(missing, el)
+		}
+	}
+
+	return missing, nil
+}
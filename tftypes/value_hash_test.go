@@ -0,0 +1,199 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tftypes
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestValueHash(t *testing.T) {
+	t.Parallel()
+
+	objType := Object{AttributeTypes: map[string]Type{"name": String, "port": Number}}
+
+	testCases := map[string]struct {
+		val1  Value
+		val2  Value
+		equal bool
+	}{
+		"string-equal": {
+			val1:  NewValue(String, "hello"),
+			val2:  NewValue(String, "hello"),
+			equal: true,
+		},
+		"string-different": {
+			val1: NewValue(String, "hello"),
+			val2: NewValue(String, "world"),
+		},
+		"number-precision": {
+			val1:  NewValue(Number, big.NewFloat(1.5)),
+			val2:  NewValue(Number, new(big.Float).SetPrec(512).SetFloat64(1.5)),
+			equal: true,
+		},
+		"number-zero-sign": {
+			val1:  NewValue(Number, big.NewFloat(0)),
+			val2:  NewValue(Number, new(big.Float).Neg(big.NewFloat(0))),
+			equal: true,
+		},
+		"number-different": {
+			val1: NewValue(Number, 1),
+			val2: NewValue(Number, 2),
+		},
+		"string-number": {
+			val1: NewValue(String, "1"),
+			val2: NewValue(Number, 1),
+		},
+		"list-order": {
+			val1: NewValue(List{ElementType: String}, []Value{NewValue(String, "a"), NewValue(String, "b")}),
+			val2: NewValue(List{ElementType: String}, []Value{NewValue(String, "b"), NewValue(String, "a")}),
+		},
+		"list-element-boundary": {
+			val1: NewValue(List{ElementType: String}, []Value{NewValue(String, "ab"), NewValue(String, "c")}),
+			val2: NewValue(List{ElementType: String}, []Value{NewValue(String, "a"), NewValue(String, "bc")}),
+		},
+		"set-order": {
+			val1: NewValue(Set{ElementType: objType}, []Value{
+				NewValue(objType, map[string]Value{"name": NewValue(String, "a"), "port": NewValue(Number, 80)}),
+				NewValue(objType, map[string]Value{"name": NewValue(String, "b"), "port": NewValue(Number, 443)}),
+			}),
+			val2: NewValue(Set{ElementType: objType}, []Value{
+				NewValue(objType, map[string]Value{"name": NewValue(String, "b"), "port": NewValue(Number, 443)}),
+				NewValue(objType, map[string]Value{"name": NewValue(String, "a"), "port": NewValue(Number, 80)}),
+			}),
+			equal: true,
+		},
+		"set-list": {
+			val1: NewValue(Set{ElementType: String}, []Value{NewValue(String, "a")}),
+			val2: NewValue(List{ElementType: String}, []Value{NewValue(String, "a")}),
+		},
+		"map-object": {
+			val1: NewValue(Map{ElementType: String}, map[string]Value{"name": NewValue(String, "a")}),
+			val2: NewValue(Object{AttributeTypes: map[string]Type{"name": String}}, map[string]Value{"name": NewValue(String, "a")}),
+		},
+		"null-unknown": {
+			val1: NewValue(String, nil),
+			val2: NewValue(String, UnknownValue),
+		},
+		"dynamic-null-types": {
+			val1:  NewValue(List{ElementType: DynamicPseudoType}, []Value{NewValue(String, nil)}),
+			val2:  NewValue(List{ElementType: DynamicPseudoType}, []Value{NewValue(Number, nil)}),
+			equal: true,
+		},
+		"dynamic-element-types": {
+			val1: NewValue(Tuple{ElementTypes: []Type{DynamicPseudoType}}, []Value{NewValue(String, "1")}),
+			val2: NewValue(Tuple{ElementTypes: []Type{DynamicPseudoType}}, []Value{NewValue(Number, 1)}),
+		},
+		"null-string-empty": {
+			val1: NewValue(String, nil),
+			val2: NewValue(String, ""),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := testCase.val1.Hash() == testCase.val2.Hash(); got != testCase.equal {
+				t.Errorf("expected hashes of %s and %s to be equal: %t, got: %t", testCase.val1, testCase.val2, testCase.equal, got)
+			}
+
+			if got := testCase.val1.Equal(testCase.val2); got != testCase.equal {
+				t.Errorf("expected %s and %s to be equal: %t, got: %t", testCase.val1, testCase.val2, testCase.equal, got)
+			}
+		})
+	}
+}
+
+func TestNewSetValue(t *testing.T) {
+	t.Parallel()
+
+	objType := Object{AttributeTypes: map[string]Type{"port": Number}}
+
+	testCases := map[string]struct {
+		elementType   Type
+		elements      []Value
+		duplicates    SetDuplicates
+		expected      Value
+		expectedError error
+	}{
+		"unique": {
+			elementType: String,
+			elements:    []Value{NewValue(String, "a"), NewValue(String, "b")},
+			duplicates:  SetDuplicatesReject,
+			expected:    NewValue(Set{ElementType: String}, []Value{NewValue(String, "a"), NewValue(String, "b")}),
+		},
+		"empty": {
+			elementType: String,
+			elements:    []Value{},
+			duplicates:  SetDuplicatesReject,
+			expected:    NewValue(Set{ElementType: String}, []Value{}),
+		},
+		"reject": {
+			elementType: objType,
+			elements: []Value{
+				NewValue(objType, map[string]Value{"port": NewValue(Number, 80)}),
+				NewValue(objType, map[string]Value{"port": NewValue(Number, 443)}),
+				NewValue(objType, map[string]Value{"port": NewValue(Number, 80)}),
+			},
+			duplicates:    SetDuplicatesReject,
+			expectedError: NewAttributePath().WithElementKeyValue(NewValue(objType, map[string]Value{"port": NewValue(Number, 80)})).NewErrorf("duplicate set element"),
+		},
+		"remove": {
+			elementType: objType,
+			elements: []Value{
+				NewValue(objType, map[string]Value{"port": NewValue(Number, 80)}),
+				NewValue(objType, map[string]Value{"port": NewValue(Number, 443)}),
+				NewValue(objType, map[string]Value{"port": NewValue(Number, 80)}),
+			},
+			duplicates: SetDuplicatesRemove,
+			expected: NewValue(Set{ElementType: objType}, []Value{
+				NewValue(objType, map[string]Value{"port": NewValue(Number, 80)}),
+				NewValue(objType, map[string]Value{"port": NewValue(Number, 443)}),
+			}),
+		},
+		"remove-unknown": {
+			elementType: String,
+			elements:    []Value{NewValue(String, UnknownValue), NewValue(String, UnknownValue)},
+			duplicates:  SetDuplicatesRemove,
+			expected:    NewValue(Set{ElementType: String}, []Value{NewValue(String, UnknownValue)}),
+		},
+		"wrong-type": {
+			elementType:   String,
+			elements:      []Value{NewValue(Number, 1)},
+			duplicates:    SetDuplicatesRemove,
+			expectedError: NewAttributePath().WithElementKeyValue(NewValue(Number, 1)).NewErrorf("can't use tftypes.Number as tftypes.String"),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := NewSetValue(testCase.elementType, testCase.elements, testCase.duplicates)
+
+			if err != nil {
+				if testCase.expectedError == nil {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if diff := cmp.Diff(testCase.expectedError, err); diff != "" {
+					t.Fatalf("unexpected error difference: %s", diff)
+				}
+
+				return
+			}
+
+			if testCase.expectedError != nil {
+				t.Fatalf("expected error %q, got none", testCase.expectedError)
+			}
+
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
@@ -165,56 +165,66 @@ func jsonUnmarshalBool(buf []byte, _ Type, p *AttributePath) (Value, error) {
 }
 
 func jsonUnmarshalDynamicPseudoType(buf []byte, _ Type, p *AttributePath, opts ValueFromJSONOpts) (Value, error) {
+	t, valBody, err := jsonDynamicPseudoTypeParts(buf, p)
+	if err != nil {
+		return Value{}, err
+	}
+	return jsonUnmarshal(valBody, t, p, opts)
+}
+
+// jsonDynamicPseudoTypeParts returns the type and the JSON-encoded value of a
+// dynamically-typed value.
+func jsonDynamicPseudoTypeParts(buf []byte, p *AttributePath) (Type, []byte, error) {
 	dec := jsonByteDecoder(buf)
 	tok, err := dec.Token()
 	if err != nil {
-		return Value{}, p.NewErrorf("error reading token: %w", err)
+		return nil, nil, p.NewErrorf("error reading token: %w", err)
 	}
 	if tok != json.Delim('{') {
-		return Value{}, p.NewErrorf("invalid JSON, expected %q, got %q", json.Delim('{'), tok)
+		return nil, nil, p.NewErrorf("invalid JSON, expected %q, got %q", json.Delim('{'), tok)
 	}
 	var t Type
 	var valBody []byte
 	for dec.More() {
 		tok, err = dec.Token()
 		if err != nil {
-			return Value{}, p.NewErrorf("error reading token: %w", err)
+			return nil, nil, p.NewErrorf("error reading token: %w", err)
 		}
 		key, ok := tok.(string)
 		if !ok {
-			return Value{}, p.NewErrorf("expected key to be a string, got %T", tok)
+			return nil, nil, p.NewErrorf("expected key to be a string, got %T", tok)
 		}
 		var rawVal json.RawMessage
 		err = dec.Decode(&rawVal)
 		if err != nil {
-			return Value{}, p.NewErrorf("error decoding value: %w", err)
+			return nil, nil, p.NewErrorf("error decoding value: %w", err)
 		}
 		switch key {
 		case "type":
 			t, err = ParseJSONType(rawVal) //nolint:staticcheck
 			if err != nil {
-				return Value{}, p.NewErrorf("error decoding type information: %w", err)
+				return nil, nil, p.NewErrorf("error decoding type information: %w", err)
 			}
 		case "value":
 			valBody = rawVal
 		default:
-			return Value{}, p.NewErrorf("invalid key %q in dynamically-typed value", key)
+			return nil, nil, p.NewErrorf("invalid key %q in dynamically-typed value", key)
 		}
 	}
 	tok, err = dec.Token()
 	if err != nil {
-		return Value{}, p.NewErrorf("error reading token: %w", err)
+		return nil, nil, p.NewErrorf("error reading token: %w", err)
 	}
 	if tok != json.Delim('}') {
-		return Value{}, p.NewErrorf("invalid JSON, expected %q, got %q", json.Delim('}'), tok)
+		return nil, nil, p.NewErrorf("invalid JSON, expected %q, got %q", json.Delim('}'), tok)
 	}
 	if t == nil {
-		return Value{}, p.NewErrorf("missing type in dynamically-typed value")
+		return nil, nil, p.NewErrorf("missing type in dynamically-typed value")
 	}
 	if valBody == nil {
-		return Value{}, p.NewErrorf("missing value in dynamically-typed value")
+		return nil, nil, p.NewErrorf("missing value in dynamically-typed value")
 	}
-	return jsonUnmarshal(valBody, t, p, opts)
+	return t, valBody, nil
 }
 
 func jsonUnmarshalList(buf []byte, elementType Type, p *AttributePath, opts ValueFromJSONOpts) (Value, error) {
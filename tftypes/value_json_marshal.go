@@ -0,0 +1,181 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tftypes
+
+import (
+	"bytes"
+	"encoding/json"
+	"math/big"
+	"sort"
+)
+
+// jsonMarshalDynamic returns the JSON encoding of the Value together with its
+// Type, the way dynamically-typed values are encoded, so it can be decoded by
+// ValueFromJSON with DynamicPseudoType. Unknown values cannot be represented
+// in JSON, and result in an error.
+func jsonMarshalDynamic(val Value, p *AttributePath) ([]byte, error) {
+	var buf bytes.Buffer
+
+	err := jsonMarshal(&buf, val, DynamicPseudoType, p)
+	if err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+func jsonMarshal(buf *bytes.Buffer, val Value, typ Type, p *AttributePath) error {
+	if !val.IsKnown() {
+		return p.NewErrorf("unknown values cannot be encoded as JSON")
+	}
+
+	if typ.Is(DynamicPseudoType) && !val.Type().Is(DynamicPseudoType) {
+		typeJSON, err := val.Type().MarshalJSON()
+		if err != nil {
+			return p.NewErrorf("error generating JSON for type %s: %w", val.Type(), err)
+		}
+
+		buf.WriteString(`{"type":`)
+		buf.Write(typeJSON)
+		buf.WriteString(`,"value":`)
+
+		err = jsonMarshal(buf, val, val.Type(), p)
+		if err != nil {
+			return err
+		}
+
+		buf.WriteByte('}')
+
+		return nil
+	}
+
+	if val.IsNull() {
+		buf.WriteString("null")
+
+		return nil
+	}
+
+	switch v := val.value.(type) {
+	case string:
+		// Encoding a string cannot fail
+		s, _ := json.Marshal(v)
+		buf.Write(s)
+	case bool:
+		if v {
+			buf.WriteString("true")
+		} else {
+			buf.WriteString("false")
+		}
+	case []Value:
+		buf.WriteByte('[')
+
+		for pos, el := range v {
+			if pos > 0 {
+				buf.WriteByte(',')
+			}
+
+			var elemType Type
+			var elemPath *AttributePath
+
+			switch typ := typ.(type) {
+			case List:
+				elemType = typ.ElementType
+				elemPath = p.WithElementKeyInt(pos)
+			case Set:
+				elemType = typ.ElementType
+				elemPath = p.WithElementKeyValue(el)
+			case Tuple:
+				if pos >= len(typ.ElementTypes) {
+					return p.NewErrorf("unexpected tuple element %d, %s has %d elements", pos, typ, len(typ.ElementTypes))
+				}
+				elemType = typ.ElementTypes[pos]
+				elemPath = p.WithElementKeyInt(pos)
+			default:
+				return unexpectedValueTypeError(p, nil, val.value, typ)
+			}
+
+			err := jsonMarshal(buf, el, elemType, elemPath)
+			if err != nil {
+				return err
+			}
+		}
+
+		buf.WriteByte(']')
+	case map[string]Value:
+		keys := make([]string, 0, len(v))
+		for k := range v {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+
+		buf.WriteByte('{')
+
+		for pos, k := range keys {
+			if pos > 0 {
+				buf.WriteByte(',')
+			}
+
+			var elemType Type
+			var elemPath *AttributePath
+
+			switch typ := typ.(type) {
+			case Map:
+				elemType = typ.ElementType
+				elemPath = p.WithElementKeyString(k)
+			case Object:
+				elemType = typ.AttributeTypes[k]
+				elemPath = p.WithAttributeName(k)
+			default:
+				return unexpectedValueTypeError(p, nil, val.value, typ)
+			}
+
+			if elemType == nil {
+				return elemPath.NewErrorf("unexpected attribute %q", k)
+			}
+
+			// Encoding a string cannot fail
+			key, _ := json.Marshal(k)
+			buf.Write(key)
+			buf.WriteByte(':')
+
+			err := jsonMarshal(buf, v[k], elemType, elemPath)
+			if err != nil {
+				return err
+			}
+		}
+
+		buf.WriteByte('}')
+	default:
+		n, ok := numberBigFloat(val.value)
+		if !ok {
+			return unexpectedValueTypeError(p, n, val.value, typ)
+		}
+		if n.IsInf() {
+			return p.NewErrorf("infinity cannot be encoded as JSON")
+		}
+		buf.WriteString(jsonNumberText(n))
+	}
+
+	return nil
+}
+
+// jsonNumberText returns the decimal representation of a number, such that it
+// is decoded by ValueFromJSON into an equal number. The shortest
+// representation is used if it decodes into an equal number, which is
+// usually the case, otherwise the exact representation is used.
+func jsonNumberText(n *big.Float) string {
+	s := n.Text('f', -1)
+
+	// See jsonUnmarshalNumber for the choice of base, precision, and
+	// rounding mode.
+	parsed, _, err := big.ParseFloat(s, 10, 512, big.ToNearestEven)
+	if err == nil && parsed.Cmp(n) == 0 {
+		return s
+	}
+
+	// Every binary fraction has a finite decimal representation, with as
+	// many digits after the decimal point as there are bits after the
+	// binary point.
+	return n.Text('f', max(0, int(n.MinPrec())-n.MantExp(nil)))
+}
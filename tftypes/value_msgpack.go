@@ -77,7 +77,7 @@ func msgpackUnmarshal(dec *msgpack.Decoder, typ Type, path *AttributePath) (Valu
 			if err != nil {
 				return Value{}, path.NewErrorf("couldn't decode number as int64: %w", err)
 			}
-			return NewValue(Number, new(big.Float).SetInt64(rv)), nil
+			return NewValue(Number, rv), nil
 		}
 		switch peek {
 		case msgpackCodes.Int8, msgpackCodes.Int16, msgpackCodes.Int32, msgpackCodes.Int64:
@@ -85,19 +85,22 @@ func msgpackUnmarshal(dec *msgpack.Decoder, typ Type, path *AttributePath) (Valu
 			if err != nil {
 				return Value{}, path.NewErrorf("couldn't decode number as int64: %w", err)
 			}
-			return NewValue(Number, new(big.Float).SetInt64(rv)), nil
+			return NewValue(Number, rv), nil
 		case msgpackCodes.Uint8, msgpackCodes.Uint16, msgpackCodes.Uint32, msgpackCodes.Uint64:
 			rv, err := dec.DecodeUint64()
 			if err != nil {
 				return Value{}, path.NewErrorf("couldn't decode number as uint64: %w", err)
 			}
-			return NewValue(Number, new(big.Float).SetUint64(rv)), nil
+			return NewValue(Number, rv), nil
 		case msgpackCodes.Float, msgpackCodes.Double:
 			rv, err := dec.DecodeFloat64()
 			if err != nil {
 				return Value{}, path.NewErrorf("couldn't decode number as float64: %w", err)
 			}
-			return NewValue(Number, big.NewFloat(rv)), nil
+			if math.IsNaN(rv) {
+				return Value{}, path.NewErrorf("couldn't decode number: NaN is not a valid number")
+			}
+			return NewValue(Number, rv), nil
 		default:
 			rv, err := dec.DecodeString()
 			if err != nil {
@@ -423,7 +426,23 @@ func marshalMsgPackString(val Value, typ Type, p *AttributePath, enc *msgpack.En
 }
 
 func marshalMsgPackNumber(val Value, typ Type, p *AttributePath, enc *msgpack.Encoder) error {
-	n, ok := val.value.(*big.Float)
+	// Compact numbers are encoded without promoting them to *big.Float,
+	// the same way as the equivalent *big.Float would be.
+	if iv, ok := numberInt64(val.value); ok {
+		err := enc.EncodeInt(iv)
+		if err != nil {
+			return p.NewErrorf("error encoding int value: %w", err)
+		}
+		return nil
+	}
+	if fv, ok := val.value.(float64); ok && (math.IsInf(fv, 0) || math.Trunc(fv) != fv) {
+		err := enc.EncodeFloat64(fv)
+		if err != nil {
+			return p.NewErrorf("error encoding float value: %w", err)
+		}
+		return nil
+	}
+	n, ok := numberBigFloat(val.value)
 	if !ok {
 		return unexpectedValueTypeError(p, n, val.value, typ)
 	}
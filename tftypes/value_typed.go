@@ -0,0 +1,601 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tftypes
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Optional is the Go value of a Value that may be null or unknown, as returned
+// by GetOptional and accepted by NewValueFromOptional.
+type Optional[T any] struct {
+	// Value is the Go value of a known, non-null Value. It is the zero
+	// value of T if the Value is null or unknown.
+	Value T
+
+	// Null is true if the Value is null.
+	Null bool
+
+	// Unknown is true if the Value is unknown.
+	Unknown bool
+}
+
+// GetAs returns the Go value of the Value at `path` within `val`. A nil or
+// empty path refers to `val` itself.
+//
+// The following Go types are supported, including named types with these
+// underlying types:
+//
+//   - string, for String values
+//   - bool, for Bool values
+//   - all integer and floating point types, and *big.Float, for Number
+//     values. Numbers must be exactly representable by integer types, and
+//     within the range of floating point types.
+//   - slices, for List, Set, and Tuple values, with an element type that is
+//     supported
+//   - maps with string keys, for Map and Object values, with an element type
+//     that is supported
+//   - structs, for Object values, with each attribute set on the exported
+//     field whose `tf` struct tag has the attribute name before any comma,
+//     as in `tf:"name"`. Every attribute must have a field, while fields for
+//     attributes the Object does not have are left unchanged.
+//   - Value, for any Value
+//   - pointers to any supported type, with a pointer to a type
+//     implementing ValueConverter being used to convert the Value
+//
+// Null values can only be converted to pointers, slices, maps, and Value, and
+// result in nil. Unknown values can only be converted to Value; use
+// GetOptional for values that may be null or unknown.
+func GetAs[T any](val Value, path *AttributePath) (T, error) {
+	var res T
+
+	v, err := valueAtPathSteps(val, path.Steps(), NewAttributePath())
+	if err != nil {
+		return res, err
+	}
+
+	err = valueToGo(v, reflect.ValueOf(&res).Elem(), NewAttributePathWithSteps(path.Steps()))
+
+	return res, err
+}
+
+// GetOptional returns the Go value of the Value at `path` within `val`, like
+// GetAs, or whether that Value is null or unknown. Values nested within the
+// Value must still be convertible by GetAs.
+func GetOptional[T any](val Value, path *AttributePath) (Optional[T], error) {
+	v, err := valueAtPathSteps(val, path.Steps(), NewAttributePath())
+	if err != nil {
+		return Optional[T]{}, err
+	}
+
+	if !v.IsKnown() {
+		return Optional[T]{Unknown: true}, nil
+	}
+
+	if v.IsNull() {
+		return Optional[T]{Null: true}, nil
+	}
+
+	var res Optional[T]
+
+	err = valueToGo(v, reflect.ValueOf(&res.Value).Elem(), NewAttributePathWithSteps(path.Steps()))
+
+	return res, err
+}
+
+// NewValueFrom returns a Value of Type `typ` from the Go value `in`, the
+// inverse of GetAs. The Go types supported by GetAs are supported, and nil
+// pointers, slices, and maps result in null values. Go slices can be used for
+// List, Set, and Tuple values, with duplicate elements of sets being removed.
+// Go maps and structs can be used for Object values, with attributes that are
+// not in the map, or have no field in the struct, being null. Types
+// implementing ValueCreator are used as they would be by NewValue.
+//
+// The zero Value has no Type, and results in an error, except in a Value
+// field of a struct, where it is treated as a field that was never set and
+// results in a null value.
+//
+// Where `typ` is DynamicPseudoType, the Type of the Value is String, Bool, or
+// Number for the corresponding Go types. The Type of other Go values cannot be
+// determined, and results in an error.
+func NewValueFrom[T any](typ Type, in T) (Value, error) {
+	return goToValue(reflect.ValueOf(&in).Elem(), typ, NewAttributePath())
+}
+
+// NewValueFromOptional returns a Value of Type `typ` from `in`, which is a null
+// or unknown Value if the Null or Unknown fields are set, and otherwise the
+// Value NewValueFrom returns for the Value field.
+func NewValueFromOptional[T any](typ Type, in Optional[T]) (Value, error) {
+	switch {
+	case in.Unknown:
+		return newValue(typ, UnknownValue)
+	case in.Null:
+		return newValue(typ, nil)
+	}
+
+	return NewValueFrom(typ, in.Value)
+}
+
+var (
+	valueReflectType        = reflect.TypeOf(Value{})
+	bigFloatReflectType     = reflect.TypeOf(&big.Float{})
+	valueCreatorReflectType = reflect.TypeOf((*ValueCreator)(nil)).Elem()
+)
+
+// valueToGo sets `dst`, which must be settable, to the Go value of `val`,
+// which is at the path `p`.
+func valueToGo(val Value, dst reflect.Value, p *AttributePath) error {
+	dstType := dst.Type()
+
+	if dstType == valueReflectType {
+		dst.Set(reflect.ValueOf(val))
+		return nil
+	}
+
+	if dst.CanAddr() {
+		if converter, ok := dst.Addr().Interface().(ValueConverter); ok {
+			err := converter.FromTerraform5Value(val)
+			if err != nil {
+				return p.NewError(err)
+			}
+			return nil
+		}
+	}
+
+	if !val.IsKnown() {
+		return p.NewErrorf("unknown values cannot be converted to %s", dstType)
+	}
+
+	if val.IsNull() {
+		switch dstType.Kind() {
+		case reflect.Pointer, reflect.Slice, reflect.Map:
+			dst.Set(reflect.Zero(dstType))
+			return nil
+		}
+
+		return p.NewErrorf("null values cannot be converted to %s", dstType)
+	}
+
+	if dstType == bigFloatReflectType {
+		n, ok := numberBigFloat(val.value)
+		if !ok {
+			return p.NewErrorf("can't convert %s to %s", val.Type(), dstType)
+		}
+		dst.Set(reflect.ValueOf(new(big.Float).Copy(n)))
+		return nil
+	}
+
+	switch dstType.Kind() {
+	case reflect.Pointer:
+		elem := reflect.New(dstType.Elem())
+
+		err := valueToGo(val, elem.Elem(), p)
+		if err != nil {
+			return err
+		}
+
+		dst.Set(elem)
+		return nil
+	case reflect.String:
+		v, ok := val.value.(string)
+		if !ok {
+			return p.NewErrorf("can't convert %s to %s", val.Type(), dstType)
+		}
+		dst.SetString(v)
+		return nil
+	case reflect.Bool:
+		v, ok := val.value.(bool)
+		if !ok {
+			return p.NewErrorf("can't convert %s to %s", val.Type(), dstType)
+		}
+		dst.SetBool(v)
+		return nil
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		n, ok := numberBigFloat(val.value)
+		if !ok {
+			return p.NewErrorf("can't convert %s to %s", val.Type(), dstType)
+		}
+		i, acc := n.Int64()
+		if !n.IsInt() || acc != big.Exact || dst.OverflowInt(i) {
+			return p.NewErrorf("%s cannot be represented as %s", n.Text('g', -1), dstType)
+		}
+		dst.SetInt(i)
+		return nil
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		n, ok := numberBigFloat(val.value)
+		if !ok {
+			return p.NewErrorf("can't convert %s to %s", val.Type(), dstType)
+		}
+		u, acc := n.Uint64()
+		if !n.IsInt() || acc != big.Exact || dst.OverflowUint(u) {
+			return p.NewErrorf("%s cannot be represented as %s", n.Text('g', -1), dstType)
+		}
+		dst.SetUint(u)
+		return nil
+	case reflect.Float32, reflect.Float64:
+		n, ok := numberBigFloat(val.value)
+		if !ok {
+			return p.NewErrorf("can't convert %s to %s", val.Type(), dstType)
+		}
+		f, _ := n.Float64()
+		if (math.IsInf(f, 0) && !n.IsInf()) || dst.OverflowFloat(f) {
+			return p.NewErrorf("%s cannot be represented as %s", n.Text('g', -1), dstType)
+		}
+		dst.SetFloat(f)
+		return nil
+	case reflect.Slice:
+		elems, ok := val.value.([]Value)
+		if !ok {
+			return p.NewErrorf("can't convert %s to %s", val.Type(), dstType)
+		}
+
+		res := reflect.MakeSlice(dstType, len(elems), len(elems))
+
+		for pos, el := range elems {
+			elPath := p.WithElementKeyInt(pos)
+
+			if val.Type().Is(Set{}) {
+				elPath = p.WithElementKeyValue(el)
+			}
+
+			err := valueToGo(el, res.Index(pos), elPath)
+			if err != nil {
+				return err
+			}
+		}
+
+		dst.Set(res)
+		return nil
+	case reflect.Map:
+		elems, ok := val.value.(map[string]Value)
+		if !ok || dstType.Key().Kind() != reflect.String {
+			return p.NewErrorf("can't convert %s to %s", val.Type(), dstType)
+		}
+
+		res := reflect.MakeMapWithSize(dstType, len(elems))
+
+		for key, el := range elems {
+			elPath := p.WithElementKeyString(key)
+
+			if val.Type().Is(Object{}) {
+				elPath = p.WithAttributeName(key)
+			}
+
+			elem := reflect.New(dstType.Elem()).Elem()
+
+			err := valueToGo(el, elem, elPath)
+			if err != nil {
+				return err
+			}
+
+			res.SetMapIndex(reflect.ValueOf(key).Convert(dstType.Key()), elem)
+		}
+
+		dst.Set(res)
+		return nil
+	case reflect.Struct:
+		elems, ok := val.value.(map[string]Value)
+		if !ok || !val.Type().Is(Object{}) {
+			return p.NewErrorf("can't convert %s to %s", val.Type(), dstType)
+		}
+
+		fields, err := structFields(dstType)
+		if err != nil {
+			return p.NewError(err)
+		}
+
+		keys := make([]string, 0, len(elems))
+
+		for key := range elems {
+			keys = append(keys, key)
+		}
+
+		sort.Strings(keys)
+
+		for _, key := range keys {
+			index, ok := fields[key]
+			if !ok {
+				return p.WithAttributeName(key).NewErrorf("%s has no field for attribute %q", dstType, key)
+			}
+
+			err := valueToGo(elems[key], dst.Field(index), p.WithAttributeName(key))
+			if err != nil {
+				return err
+			}
+		}
+
+		return nil
+	}
+
+	return p.NewErrorf("can't convert %s to unsupported Go type %s", val.Type(), dstType)
+}
+
+// goToValue returns the Value of Type `typ` for the Go value `in`, which is at
+// the path `p`.
+func goToValue(in reflect.Value, typ Type, p *AttributePath) (Value, error) {
+	if !in.IsValid() {
+		return newValue(typ, nil)
+	}
+
+	inType := in.Type()
+
+	if val, ok := in.Interface().(Value); ok {
+		if val.Type() == nil {
+			return Value{}, p.NewErrorf("can't use the zero Value as %s, it has no type", typ)
+		}
+
+		if !val.Type().UsableAs(typ) {
+			return Value{}, p.NewErrorf("can't use %s as %s", val.Type(), typ)
+		}
+
+		return val, nil
+	}
+
+	switch inType.Kind() {
+	case reflect.Pointer, reflect.Interface, reflect.Slice, reflect.Map:
+		if in.IsNil() {
+			return newValue(typ, nil)
+		}
+	}
+
+	if inType.Implements(valueCreatorReflectType) {
+		val, err := newValue(typ, in.Interface())
+		if err != nil {
+			return Value{}, p.NewError(err)
+		}
+
+		return val, nil
+	}
+
+	if n, ok := in.Interface().(*big.Float); ok {
+		// A nil *big.Float within an interface is not caught by the
+		// IsNil check above.
+		if n == nil {
+			return newValue(typ, nil)
+		}
+
+		if !typ.Is(Number) && !typ.Is(DynamicPseudoType) {
+			return Value{}, p.NewErrorf("can't use %s as %s", inType, typ)
+		}
+
+		return newValue(Number, new(big.Float).Copy(n))
+	}
+
+	switch inType.Kind() {
+	case reflect.Pointer, reflect.Interface:
+		return goToValue(in.Elem(), typ, p)
+	case reflect.String:
+		if !typ.Is(String) && !typ.Is(DynamicPseudoType) {
+			return Value{}, p.NewErrorf("can't use %s as %s", inType, typ)
+		}
+
+		return newValue(String, in.String())
+	case reflect.Bool:
+		if !typ.Is(Bool) && !typ.Is(DynamicPseudoType) {
+			return Value{}, p.NewErrorf("can't use %s as %s", inType, typ)
+		}
+
+		return newValue(Bool, in.Bool())
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr,
+		reflect.Float32, reflect.Float64:
+		if !typ.Is(Number) && !typ.Is(DynamicPseudoType) {
+			return Value{}, p.NewErrorf("can't use %s as %s", inType, typ)
+		}
+
+		switch {
+		case in.CanInt():
+			return newValue(Number, in.Int())
+		case in.CanUint():
+			return newValue(Number, in.Uint())
+		}
+
+		f := in.Float()
+
+		if math.IsNaN(f) || math.IsInf(f, 0) {
+			return Value{}, p.NewErrorf("%v cannot be used as %s", f, typ)
+		}
+
+		return newValue(Number, f)
+	case reflect.Slice, reflect.Array:
+		switch typ := typ.(type) {
+		case List, Set:
+		case Tuple:
+			if in.Len() != len(typ.ElementTypes) {
+				return Value{}, p.NewErrorf("can't use %s with %d elements as %s", inType, in.Len(), typ)
+			}
+		default:
+			return Value{}, p.NewErrorf("can't use %s as %s", inType, typ)
+		}
+
+		elems := make([]Value, 0, in.Len())
+
+		for pos := 0; pos < in.Len(); pos++ {
+			var elemType Type
+			elemPath := p.WithElementKeyInt(pos)
+
+			switch typ := typ.(type) {
+			case List:
+				elemType = typ.ElementType
+			case Set:
+				elemType = typ.ElementType
+				elemPath = p.WithElementKeyValue(NewValue(elemType, UnknownValue))
+			case Tuple:
+				elemType = typ.ElementTypes[pos]
+			}
+
+			el, err := goToValue(in.Index(pos), elemType, elemPath)
+			if err != nil {
+				return Value{}, err
+			}
+
+			elems = append(elems, el)
+		}
+
+		// Go slices may contain duplicate elements, which are a single
+		// element of a set.
+		if typ.Is(Set{}) {
+			elems, _ = uniqueSetElements(elems, false)
+		}
+
+		val, err := newValue(typ, elems)
+		if err != nil {
+			return Value{}, p.NewError(err)
+		}
+
+		return val, nil
+	case reflect.Map:
+		if inType.Key().Kind() != reflect.String {
+			return Value{}, p.NewErrorf("can't use %s as %s, map keys must be strings", inType, typ)
+		}
+
+		keys := make([]string, 0, in.Len())
+		goKeys := make(map[string]reflect.Value, in.Len())
+
+		iter := in.MapRange()
+
+		for iter.Next() {
+			key := iter.Key().String()
+			keys = append(keys, key)
+			goKeys[key] = iter.Value()
+		}
+
+		sort.Strings(keys)
+
+		elems := make(map[string]Value, len(keys))
+
+		switch typ := typ.(type) {
+		case Map:
+			for _, key := range keys {
+				el, err := goToValue(goKeys[key], typ.ElementType, p.WithElementKeyString(key))
+				if err != nil {
+					return Value{}, err
+				}
+
+				elems[key] = el
+			}
+		case Object:
+			for _, key := range keys {
+				attrType, ok := typ.AttributeTypes[key]
+				if !ok {
+					return Value{}, p.WithAttributeName(key).NewErrorf("unexpected attribute %q, %s has no such attribute", key, typ)
+				}
+
+				el, err := goToValue(goKeys[key], attrType, p.WithAttributeName(key))
+				if err != nil {
+					return Value{}, err
+				}
+
+				elems[key] = el
+			}
+
+			for name, attrType := range typ.AttributeTypes {
+				if _, ok := elems[name]; !ok {
+					elems[name] = NewValue(attrType, nil)
+				}
+			}
+
+			typ = Object{AttributeTypes: typ.AttributeTypes}
+
+			val, err := newValue(typ, elems)
+			if err != nil {
+				return Value{}, p.NewError(err)
+			}
+
+			return val, nil
+		default:
+			return Value{}, p.NewErrorf("can't use %s as %s", inType, typ)
+		}
+
+		val, err := newValue(typ, elems)
+		if err != nil {
+			return Value{}, p.NewError(err)
+		}
+
+		return val, nil
+	case reflect.Struct:
+		objType, ok := typ.(Object)
+		if !ok {
+			return Value{}, p.NewErrorf("can't use %s as %s", inType, typ)
+		}
+
+		fields, err := structFields(inType)
+		if err != nil {
+			return Value{}, p.NewError(err)
+		}
+
+		elems := make(map[string]Value, len(objType.AttributeTypes))
+
+		for name, index := range fields {
+			attrType, ok := objType.AttributeTypes[name]
+			if !ok {
+				return Value{}, p.WithAttributeName(name).NewErrorf("unexpected attribute %q, %s has no such attribute", name, typ)
+			}
+
+			field := in.Field(index)
+
+			// Value fields that were never set, such as computed
+			// attributes, are null rather than an error.
+			if v, ok := field.Interface().(Value); ok && v.Type() == nil {
+				elems[name] = NewValue(attrType, nil)
+				continue
+			}
+
+			el, err := goToValue(field, attrType, p.WithAttributeName(name))
+			if err != nil {
+				return Value{}, err
+			}
+
+			elems[name] = el
+		}
+
+		for name, attrType := range objType.AttributeTypes {
+			if _, ok := elems[name]; !ok {
+				elems[name] = NewValue(attrType, nil)
+			}
+		}
+
+		val, err := newValue(Object{AttributeTypes: objType.AttributeTypes}, elems)
+		if err != nil {
+			return Value{}, p.NewError(err)
+		}
+
+		return val, nil
+	}
+
+	return Value{}, p.NewErrorf("can't use unsupported Go type %s as %s", inType, typ)
+}
+
+// structFields returns the index of each exported field of the struct type
+// `t` with a `tf` struct tag, by the attribute name in the tag.
+func structFields(t reflect.Type) (map[string]int, error) {
+	fields := make(map[string]int, t.NumField())
+
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+
+		if !field.IsExported() {
+			continue
+		}
+
+		name, _, _ := strings.Cut(field.Tag.Get("tf"), ",")
+
+		if name == "" || name == "-" {
+			continue
+		}
+
+		if _, ok := fields[name]; ok {
+			return nil, fmt.Errorf("%s has multiple fields for attribute %q", t, name)
+		}
+
+		fields[name] = i
+	}
+
+	return fields, nil
+}
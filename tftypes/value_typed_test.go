@@ -0,0 +1,577 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tftypes
+
+import (
+	"errors"
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+type typedTestName string
+
+type typedTestRule struct {
+	Port     int     `tf:"port"`
+	Protocol *string `tf:"protocol,optional"`
+	Ignored  string
+}
+
+type typedTestExtra struct {
+	Extra Value `tf:"extra"`
+}
+
+func TestGetAs(t *testing.T) {
+	t.Parallel()
+
+	ruleType := Object{AttributeTypes: map[string]Type{"port": Number}}
+	typ := Object{
+		AttributeTypes: map[string]Type{
+			"name":     String,
+			"enabled":  Bool,
+			"count":    Number,
+			"ratio":    Number,
+			"big":      Number,
+			"tags":     Map{ElementType: String},
+			"names":    List{ElementType: String},
+			"ports":    Set{ElementType: Number},
+			"rules":    List{ElementType: ruleType},
+			"optional": String,
+			"computed": String,
+		},
+	}
+	bigNumber, _, _ := big.ParseFloat("123456789012345678901234567890", 10, 512, big.ToNearestEven)
+	val := NewValue(typ, map[string]Value{
+		"name":    NewValue(String, "example"),
+		"enabled": NewValue(Bool, true),
+		"count":   NewValue(Number, 300),
+		"ratio":   NewValue(Number, 0.5),
+		"big":     NewValue(Number, bigNumber),
+		"tags": NewValue(Map{ElementType: String}, map[string]Value{
+			"env": NewValue(String, "test"),
+		}),
+		"names": NewValue(List{ElementType: String}, []Value{
+			NewValue(String, "a"),
+			NewValue(String, "b"),
+		}),
+		"ports": NewValue(Set{ElementType: Number}, []Value{
+			NewValue(Number, 22),
+		}),
+		"rules": NewValue(List{ElementType: ruleType}, []Value{
+			NewValue(ruleType, map[string]Value{"port": NewValue(Number, 443)}),
+		}),
+		"optional": NewValue(String, nil),
+		"computed": NewValue(String, UnknownValue),
+	})
+
+	name := NewAttributePath().WithAttributeName("name")
+	count := NewAttributePath().WithAttributeName("count")
+
+	t.Run("string", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := GetAs[string](val, name)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got != "example" {
+			t.Errorf("expected %q, got %q", "example", got)
+		}
+	})
+
+	t.Run("named-string", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := GetAs[typedTestName](val, name)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if got != "example" {
+			t.Errorf("expected %q, got %q", "example", got)
+		}
+	})
+
+	t.Run("bool", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := GetAs[bool](val, NewAttributePath().WithAttributeName("enabled"))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if !got {
+			t.Error("expected true, got false")
+		}
+	})
+
+	t.Run("integers", func(t *testing.T) {
+		t.Parallel()
+
+		i64, err := GetAs[int64](val, count)
+		if err != nil || i64 != 300 {
+			t.Errorf("expected 300, got %d (%v)", i64, err)
+		}
+
+		u16, err := GetAs[uint16](val, count)
+		if err != nil || u16 != 300 {
+			t.Errorf("expected 300, got %d (%v)", u16, err)
+		}
+
+		_, err = GetAs[int8](val, count)
+		if err == nil || err.Error() != `AttributeName("count"): 300 cannot be represented as int8` {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		_, err = GetAs[int](val, NewAttributePath().WithAttributeName("ratio"))
+		if err == nil || err.Error() != `AttributeName("ratio"): 0.5 cannot be represented as int` {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		_, err = GetAs[uint64](val, NewAttributePath().WithAttributeName("big"))
+		if err == nil {
+			t.Error("expected error, got none")
+		}
+	})
+
+	t.Run("floats", func(t *testing.T) {
+		t.Parallel()
+
+		f32, err := GetAs[float32](val, NewAttributePath().WithAttributeName("ratio"))
+		if err != nil || f32 != 0.5 {
+			t.Errorf("expected 0.5, got %v (%v)", f32, err)
+		}
+
+		f64, err := GetAs[float64](val, NewAttributePath().WithAttributeName("big"))
+		if err != nil || f64 != 1.2345678901234568e29 {
+			t.Errorf("expected 1.2345678901234568e29, got %v (%v)", f64, err)
+		}
+
+		bf, err := GetAs[*big.Float](val, NewAttributePath().WithAttributeName("big"))
+		if err != nil || bf.Cmp(bigNumber) != 0 {
+			t.Errorf("expected %s, got %s (%v)", bigNumber, bf, err)
+		}
+	})
+
+	t.Run("collections", func(t *testing.T) {
+		t.Parallel()
+
+		names, err := GetAs[[]string](val, NewAttributePath().WithAttributeName("names"))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if diff := cmp.Diff([]string{"a", "b"}, names); diff != "" {
+			t.Errorf("unexpected difference: %s", diff)
+		}
+
+		ports, err := GetAs[[]int](val, NewAttributePath().WithAttributeName("ports"))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if diff := cmp.Diff([]int{22}, ports); diff != "" {
+			t.Errorf("unexpected difference: %s", diff)
+		}
+
+		tags, err := GetAs[map[string]string](val, NewAttributePath().WithAttributeName("tags"))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if diff := cmp.Diff(map[string]string{"env": "test"}, tags); diff != "" {
+			t.Errorf("unexpected difference: %s", diff)
+		}
+
+		rules, err := GetAs[[]map[string]int](val, NewAttributePath().WithAttributeName("rules"))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if diff := cmp.Diff([]map[string]int{{"port": 443}}, rules); diff != "" {
+			t.Errorf("unexpected difference: %s", diff)
+		}
+
+		port, err := GetAs[int](val, NewAttributePath().WithAttributeName("rules").WithElementKeyInt(0).WithAttributeName("port"))
+		if err != nil || port != 443 {
+			t.Errorf("expected 443, got %d (%v)", port, err)
+		}
+	})
+
+	t.Run("null", func(t *testing.T) {
+		t.Parallel()
+
+		optional := NewAttributePath().WithAttributeName("optional")
+
+		ptr, err := GetAs[*string](val, optional)
+		if err != nil || ptr != nil {
+			t.Errorf("expected nil, got %v (%v)", ptr, err)
+		}
+
+		_, err = GetAs[string](val, optional)
+		if err == nil || err.Error() != `AttributeName("optional"): null values cannot be converted to string` {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		got, err := GetOptional[string](val, optional)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if diff := cmp.Diff(Optional[string]{Null: true}, got); diff != "" {
+			t.Errorf("unexpected difference: %s", diff)
+		}
+	})
+
+	t.Run("unknown", func(t *testing.T) {
+		t.Parallel()
+
+		computed := NewAttributePath().WithAttributeName("computed")
+
+		_, err := GetAs[*string](val, computed)
+		if err == nil || err.Error() != `AttributeName("computed"): unknown values cannot be converted to *string` {
+			t.Errorf("unexpected error: %v", err)
+		}
+
+		got, err := GetOptional[string](val, computed)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if diff := cmp.Diff(Optional[string]{Unknown: true}, got); diff != "" {
+			t.Errorf("unexpected difference: %s", diff)
+		}
+
+		v, err := GetAs[Value](val, computed)
+		if err != nil || !v.Equal(NewValue(String, UnknownValue)) {
+			t.Errorf("expected unknown Value, got %s (%v)", v, err)
+		}
+	})
+
+	t.Run("known-optional", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := GetOptional[string](val, name)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if diff := cmp.Diff(Optional[string]{Value: "example"}, got); diff != "" {
+			t.Errorf("unexpected difference: %s", diff)
+		}
+	})
+
+	t.Run("wrong-type", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := GetAs[bool](val, name)
+		if err == nil || err.Error() != `AttributeName("name"): can't convert tftypes.String to bool` {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("invalid-path", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := GetAs[string](val, NewAttributePath().WithAttributeName("missing"))
+		if !errors.Is(err, ErrInvalidStep) {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("struct", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := GetAs[[]typedTestRule](val, NewAttributePath().WithAttributeName("rules"))
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if diff := cmp.Diff([]typedTestRule{{Port: 443}}, got); diff != "" {
+			t.Errorf("unexpected difference: %s", diff)
+		}
+
+		_, err = GetAs[typedTestRule](val, nil)
+		if err == nil || err.Error() != `AttributeName("big"): tftypes.typedTestRule has no field for attribute "big"` {
+			t.Errorf("unexpected error: %v", err)
+		}
+	})
+
+	t.Run("root", func(t *testing.T) {
+		t.Parallel()
+
+		got, err := GetAs[map[string]Value](val, nil)
+		if err != nil {
+			t.Fatalf("unexpected error: %s", err)
+		}
+		if len(got) != len(typ.AttributeTypes) {
+			t.Errorf("expected %d attributes, got %d", len(typ.AttributeTypes), len(got))
+		}
+	})
+}
+
+func TestNewValueFrom(t *testing.T) {
+	t.Parallel()
+
+	ruleType := Object{AttributeTypes: map[string]Type{"port": Number, "protocol": String}}
+	bigNumber, _, _ := big.ParseFloat("123456789012345678901234567890", 10, 512, big.ToNearestEven)
+	name := "example"
+
+	testCases := map[string]struct {
+		typ           Type
+		in            interface{}
+		expected      Value
+		expectedError string
+	}{
+		"string": {
+			typ:      String,
+			in:       "hello",
+			expected: NewValue(String, "hello"),
+		},
+		"named-string": {
+			typ:      String,
+			in:       typedTestName("hello"),
+			expected: NewValue(String, "hello"),
+		},
+		"string-pointer": {
+			typ:      String,
+			in:       &name,
+			expected: NewValue(String, "example"),
+		},
+		"nil-pointer": {
+			typ:      String,
+			in:       (*string)(nil),
+			expected: NewValue(String, nil),
+		},
+		"bool": {
+			typ:      Bool,
+			in:       true,
+			expected: NewValue(Bool, true),
+		},
+		"int8": {
+			typ:      Number,
+			in:       int8(-5),
+			expected: NewValue(Number, -5),
+		},
+		"uint64": {
+			typ:      Number,
+			in:       uint64(math.MaxUint64),
+			expected: NewValue(Number, uint64(math.MaxUint64)),
+		},
+		"float32": {
+			typ:      Number,
+			in:       float32(0.5),
+			expected: NewValue(Number, 0.5),
+		},
+		"big-float": {
+			typ:      Number,
+			in:       bigNumber,
+			expected: NewValue(Number, bigNumber),
+		},
+		"nan": {
+			typ:           Number,
+			in:            math.NaN(),
+			expectedError: "NaN cannot be used as tftypes.Number",
+		},
+		"dynamic": {
+			typ:      DynamicPseudoType,
+			in:       "hello",
+			expected: NewValue(String, "hello"),
+		},
+		"dynamic-collection": {
+			typ:           DynamicPseudoType,
+			in:            []string{"hello"},
+			expectedError: "can't use []string as tftypes.DynamicPseudoType",
+		},
+		"list": {
+			typ: List{ElementType: String},
+			in:  []string{"a", "b"},
+			expected: NewValue(List{ElementType: String}, []Value{
+				NewValue(String, "a"),
+				NewValue(String, "b"),
+			}),
+		},
+		"nil-list": {
+			typ:      List{ElementType: String},
+			in:       []string(nil),
+			expected: NewValue(List{ElementType: String}, nil),
+		},
+		"empty-list": {
+			typ:      List{ElementType: String},
+			in:       []string{},
+			expected: NewValue(List{ElementType: String}, []Value{}),
+		},
+		"set-duplicates": {
+			typ: Set{ElementType: Number},
+			in:  []int{1, 2, 1},
+			expected: NewValue(Set{ElementType: Number}, []Value{
+				NewValue(Number, 1),
+				NewValue(Number, 2),
+			}),
+		},
+		"tuple": {
+			typ: Tuple{ElementTypes: []Type{String, Number}},
+			in:  []interface{}{"a", 1},
+			expected: NewValue(Tuple{ElementTypes: []Type{String, Number}}, []Value{
+				NewValue(String, "a"),
+				NewValue(Number, 1),
+			}),
+		},
+		"tuple-length": {
+			typ:           Tuple{ElementTypes: []Type{String, Number}},
+			in:            []interface{}{"a"},
+			expectedError: "can't use []interface {} with 1 elements as tftypes.Tuple[tftypes.String, tftypes.Number]",
+		},
+		"map": {
+			typ: Map{ElementType: Bool},
+			in:  map[string]bool{"a": true},
+			expected: NewValue(Map{ElementType: Bool}, map[string]Value{
+				"a": NewValue(Bool, true),
+			}),
+		},
+		"object": {
+			typ: List{ElementType: ruleType},
+			in:  []map[string]interface{}{{"port": 22}},
+			expected: NewValue(List{ElementType: ruleType}, []Value{
+				NewValue(ruleType, map[string]Value{
+					"port":     NewValue(Number, 22),
+					"protocol": NewValue(String, nil),
+				}),
+			}),
+		},
+		"object-unexpected-attribute": {
+			typ:           ruleType,
+			in:            map[string]interface{}{"host": "example.com"},
+			expectedError: `AttributeName("host"): unexpected attribute "host", tftypes.Object["port":tftypes.Number, "protocol":tftypes.String] has no such attribute`,
+		},
+		"struct": {
+			typ: List{ElementType: ruleType},
+			in:  []typedTestRule{{Port: 22}, {Port: 443, Protocol: &name}},
+			expected: NewValue(List{ElementType: ruleType}, []Value{
+				NewValue(ruleType, map[string]Value{
+					"port":     NewValue(Number, 22),
+					"protocol": NewValue(String, nil),
+				}),
+				NewValue(ruleType, map[string]Value{
+					"port":     NewValue(Number, 443),
+					"protocol": NewValue(String, "example"),
+				}),
+			}),
+		},
+		"struct-missing-attribute": {
+			typ: Object{AttributeTypes: map[string]Type{"port": Number, "protocol": String, "host": String}},
+			in:  typedTestRule{Port: 22},
+			expected: NewValue(Object{AttributeTypes: map[string]Type{"port": Number, "protocol": String, "host": String}}, map[string]Value{
+				"port":     NewValue(Number, 22),
+				"protocol": NewValue(String, nil),
+				"host":     NewValue(String, nil),
+			}),
+		},
+		"struct-unexpected-attribute": {
+			typ:           Object{AttributeTypes: map[string]Type{"port": Number}},
+			in:            typedTestRule{Port: 22},
+			expectedError: `AttributeName("protocol"): unexpected attribute "protocol", tftypes.Object["port":tftypes.Number] has no such attribute`,
+		},
+		"struct-wrong-type": {
+			typ:           Map{ElementType: Number},
+			in:            typedTestRule{Port: 22},
+			expectedError: "can't use tftypes.typedTestRule as tftypes.Map[tftypes.Number]",
+		},
+		"value": {
+			typ: List{ElementType: String},
+			in:  []Value{NewValue(String, UnknownValue)},
+			expected: NewValue(List{ElementType: String}, []Value{
+				NewValue(String, UnknownValue),
+			}),
+		},
+		"zero-value": {
+			typ:           List{ElementType: String},
+			in:            []Value{{}},
+			expectedError: "ElementKeyInt(0): can't use the zero Value as tftypes.String, it has no type",
+		},
+		"struct-zero-value-field": {
+			typ: Object{AttributeTypes: map[string]Type{"extra": DynamicPseudoType}},
+			in:  typedTestExtra{},
+			expected: NewValue(Object{AttributeTypes: map[string]Type{"extra": DynamicPseudoType}}, map[string]Value{
+				"extra": NewValue(DynamicPseudoType, nil),
+			}),
+		},
+		"big-float-nil-interface": {
+			typ:      List{ElementType: Number},
+			in:       []interface{}{(*big.Float)(nil)},
+			expected: NewValue(List{ElementType: Number}, []Value{NewValue(Number, nil)}),
+		},
+		"wrong-type": {
+			typ:           List{ElementType: Number},
+			in:            []string{"a"},
+			expectedError: "ElementKeyInt(0): can't use string as tftypes.Number",
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := NewValueFrom(testCase.typ, testCase.in)
+
+			if err != nil {
+				if testCase.expectedError == "" {
+					t.Fatalf("unexpected error: %s", err)
+				}
+
+				if diff := cmp.Diff(testCase.expectedError, err.Error()); diff != "" {
+					t.Fatalf("unexpected error difference: %s", diff)
+				}
+
+				return
+			}
+
+			if testCase.expectedError != "" {
+				t.Fatalf("expected error %q, got none", testCase.expectedError)
+			}
+
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestNewValueFromOptional(t *testing.T) {
+	t.Parallel()
+
+	testCases := map[string]struct {
+		in       Optional[int]
+		expected Value
+	}{
+		"known": {
+			in:       Optional[int]{Value: 1},
+			expected: NewValue(Number, 1),
+		},
+		"null": {
+			in:       Optional[int]{Null: true},
+			expected: NewValue(Number, nil),
+		},
+		"unknown": {
+			in:       Optional[int]{Unknown: true},
+			expected: NewValue(Number, UnknownValue),
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := NewValueFromOptional(Number, testCase.in)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+
+			roundTrip, err := GetOptional[int](got, nil)
+			if err != nil {
+				t.Fatalf("unexpected error: %s", err)
+			}
+
+			if diff := cmp.Diff(testCase.in, roundTrip); diff != "" {
+				t.Errorf("unexpected round trip difference: %s", diff)
+			}
+		})
+	}
+}
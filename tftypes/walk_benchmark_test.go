@@ -3,7 +3,10 @@
 
 package tftypes
 
-import "testing"
+import (
+	"iter"
+	"testing"
+)
 
 func BenchmarkTransform1000(b *testing.B) {
 	benchmarkTransform(b, 1000)
@@ -53,3 +56,47 @@ func benchmarkTransform(b *testing.B, elements int) {
 		}
 	}
 }
+
+func BenchmarkWalkDepthFirst1000(b *testing.B) {
+	benchmarkWalkSeq(b, 1000, WalkDepthFirst)
+}
+
+func BenchmarkWalkBreadthFirst1000(b *testing.B) {
+	benchmarkWalkSeq(b, 1000, WalkBreadthFirst)
+}
+
+func benchmarkWalkSeq(b *testing.B, elements int, walk func(Value, WalkOpts) iter.Seq2[*AttributePath, Value]) {
+	objectType := Object{
+		AttributeTypes: map[string]Type{
+			"element_index": Number,
+			"test_string":   String,
+		},
+	}
+	setType := Set{
+		ElementType: objectType,
+	}
+
+	setElements := make([]Value, elements)
+
+	for index := range setElements {
+		setElements[index] = NewValue(
+			objectType,
+			map[string]Value{
+				"element_index": NewValue(Number, index),
+				"test_string":   NewValue(String, "test value"),
+			},
+		)
+	}
+
+	value := NewValue(
+		setType,
+		setElements,
+	)
+
+	b.ReportAllocs()
+
+	for n := 0; n < b.N; n++ {
+		for range walk(value, WalkOpts{}) {
+		}
+	}
+}
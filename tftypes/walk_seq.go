@@ -0,0 +1,251 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tftypes
+
+import (
+	"iter"
+	"sort"
+)
+
+// WalkOpts contains options that can be used to modify the behaviour of
+// WalkDepthFirst and WalkBreadthFirst.
+type WalkOpts struct {
+	// LeavesOnly, when set to true, only surfaces Values without any
+	// elements or attributes: primitives, null and unknown values, and
+	// empty collections.
+	LeavesOnly bool
+
+	// SkipChildren, when set, is called for every Value with elements or
+	// attributes. Returning true stops the walk from descending into the
+	// elements or attributes of that Value. The Value itself is still
+	// surfaced, unless LeavesOnly is set.
+	SkipChildren func(*AttributePath, Value) bool
+
+	// StopAtUnknown, when set to true, ends the walk after the first
+	// unknown Value has been surfaced.
+	StopAtUnknown bool
+}
+
+// WalkDepthFirst returns an iterator over every element and attribute in a
+// Value, including the Value itself, in depth-first order. Each iteration
+// surfaces the AttributePath of the element or attribute, relative to `val`,
+// and its Value. Elements of lists, sets, and tuples are surfaced in order
+// and attributes of maps and objects are surfaced sorted by key, so the
+// iteration order is deterministic.
+//
+// To avoid allocating a new AttributePath for every element and attribute,
+// the surfaced AttributePath is reused and is only valid until the next
+// iteration. Callers that need to keep an AttributePath must copy it, for
+// example with NewAttributePathWithSteps(path.Steps()).
+//
+// Unlike Walk, breaking out of the loop stops the walk entirely. Use the
+// options in WalkOpts to skip descending into specific Values.
+func WalkDepthFirst(val Value, opts WalkOpts) iter.Seq2[*AttributePath, Value] {
+	return func(yield func(*AttributePath, Value) bool) {
+		w := &seqWalker{
+			opts:  opts,
+			yield: yield,
+			path:  &AttributePath{},
+		}
+
+		w.depthFirst(val)
+	}
+}
+
+// WalkBreadthFirst returns an iterator over every element and attribute in a
+// Value, including the Value itself, in breadth-first order: all elements and
+// attributes at one depth are surfaced before any at the next depth. The
+// iteration order within each Value and the validity of the surfaced
+// AttributePath are the same as for WalkDepthFirst.
+func WalkBreadthFirst(val Value, opts WalkOpts) iter.Seq2[*AttributePath, Value] {
+	return func(yield func(*AttributePath, Value) bool) {
+		w := &seqWalker{
+			opts:  opts,
+			yield: yield,
+			path:  &AttributePath{},
+		}
+
+		w.breadthFirst(val)
+	}
+}
+
+// seqWalker holds the state of a WalkDepthFirst or WalkBreadthFirst
+// iteration. The steps buffer and path are reused between iterations.
+type seqWalker struct {
+	opts  WalkOpts
+	yield func(*AttributePath, Value) bool
+	steps []AttributePathStep
+	path  *AttributePath
+}
+
+// visit surfaces the Value at the current steps, if required. It returns
+// whether to descend into the elements or attributes of the Value and
+// whether to continue the walk at all.
+func (w *seqWalker) visit(val Value) (bool, bool) {
+	w.path.steps = w.steps
+
+	leaf := !hasWalkChildren(val)
+
+	if !w.opts.LeavesOnly || leaf {
+		if !w.yield(w.path, val) {
+			return false, false
+		}
+	}
+
+	if w.opts.StopAtUnknown && !val.IsKnown() {
+		return false, false
+	}
+
+	if leaf {
+		return false, true
+	}
+
+	if w.opts.SkipChildren != nil && w.opts.SkipChildren(w.path, val) {
+		return false, true
+	}
+
+	return true, true
+}
+
+func (w *seqWalker) depthFirst(val Value) bool {
+	descend, cont := w.visit(val)
+
+	if !cont {
+		return false
+	}
+
+	if !descend {
+		return true
+	}
+
+	depth := len(w.steps)
+
+	cont = eachWalkChild(val, func(step AttributePathStep, child Value) bool {
+		w.steps = append(w.steps[:depth], step)
+
+		return w.depthFirst(child)
+	})
+
+	w.steps = w.steps[:depth]
+
+	return cont
+}
+
+// seqNode is a Value waiting to be surfaced by a breadth-first walk. Instead
+// of a full AttributePath, it holds the index of its parent node and the
+// step from the parent, so the path is only built when it is surfaced.
+type seqNode struct {
+	parent int
+	step   AttributePathStep
+	val    Value
+}
+
+func (w *seqWalker) breadthFirst(val Value) {
+	nodes := []seqNode{{parent: -1, val: val}}
+
+	for current := 0; current < len(nodes); current++ {
+		node := nodes[current]
+
+		w.steps = w.steps[:0]
+
+		for idx := current; nodes[idx].parent >= 0; idx = nodes[idx].parent {
+			w.steps = append(w.steps, nodes[idx].step)
+		}
+
+		// The steps were collected from the leaf to the root.
+		for i, j := 0, len(w.steps)-1; i < j; i, j = i+1, j-1 {
+			w.steps[i], w.steps[j] = w.steps[j], w.steps[i]
+		}
+
+		descend, cont := w.visit(node.val)
+
+		if !cont {
+			return
+		}
+
+		if !descend {
+			continue
+		}
+
+		eachWalkChild(node.val, func(step AttributePathStep, child Value) bool {
+			nodes = append(nodes, seqNode{
+				parent: current,
+				step:   step,
+				val:    child,
+			})
+
+			return true
+		})
+	}
+}
+
+// hasWalkChildren returns true if the Value has elements or attributes.
+func hasWalkChildren(val Value) bool {
+	if val.IsNull() || !val.IsKnown() {
+		return false
+	}
+
+	switch val.Type().(type) {
+	case List, Set, Tuple:
+		v, ok := val.value.([]Value)
+
+		return ok && len(v) > 0
+	case Map, Object:
+		v, ok := val.value.(map[string]Value)
+
+		return ok && len(v) > 0
+	}
+
+	return false
+}
+
+// eachWalkChild calls `cb` for every element or attribute of the Value, in a
+// deterministic order, until `cb` returns false. It returns false if `cb`
+// did.
+func eachWalkChild(val Value, cb func(AttributePathStep, Value) bool) bool {
+	switch val.Type().(type) {
+	case List, Tuple:
+		v, _ := val.value.([]Value)
+
+		for pos, el := range v {
+			if !cb(ElementKeyInt(pos), el) {
+				return false
+			}
+		}
+	case Set:
+		v, _ := val.value.([]Value)
+
+		for _, el := range v {
+			if !cb(ElementKeyValue(el), el) {
+				return false
+			}
+		}
+	case Map, Object:
+		v, _ := val.value.(map[string]Value)
+
+		keys := make([]string, 0, len(v))
+
+		for k := range v {
+			keys = append(keys, k)
+		}
+
+		sort.Strings(keys)
+
+		_, isObject := val.Type().(Object)
+
+		for _, k := range keys {
+			var step AttributePathStep = ElementKeyString(k)
+
+			if isObject {
+				step = AttributeName(k)
+			}
+
+			if !cb(step, v[k]) {
+				return false
+			}
+		}
+	}
+
+	return true
+}
@@ -0,0 +1,215 @@
+// Copyright IBM Corp. 2020, 2026
+// SPDX-License-Identifier: MPL-2.0
+
+package tftypes
+
+import (
+	"iter"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestWalkSeq(t *testing.T) {
+	t.Parallel()
+
+	objType := Object{
+		AttributeTypes: map[string]Type{
+			"list":    List{ElementType: String},
+			"map":     Map{ElementType: Bool},
+			"nested":  Object{AttributeTypes: map[string]Type{"number": Number}},
+			"unknown": String,
+		},
+	}
+
+	val := NewValue(objType, map[string]Value{
+		"list": NewValue(List{ElementType: String}, []Value{
+			NewValue(String, "a"),
+			NewValue(String, "b"),
+		}),
+		"map": NewValue(Map{ElementType: Bool}, map[string]Value{
+			"y": NewValue(Bool, true),
+			"x": NewValue(Bool, false),
+		}),
+		"nested": NewValue(Object{AttributeTypes: map[string]Type{"number": Number}}, map[string]Value{
+			"number": NewValue(Number, 1),
+		}),
+		"unknown": NewValue(String, UnknownValue),
+	})
+
+	testCases := map[string]struct {
+		walk     func(Value, WalkOpts) iter.Seq2[*AttributePath, Value]
+		opts     WalkOpts
+		expected []string
+	}{
+		"depth-first": {
+			walk: WalkDepthFirst,
+			expected: []string{
+				"",
+				`AttributeName("list")`,
+				`AttributeName("list").ElementKeyInt(0)`,
+				`AttributeName("list").ElementKeyInt(1)`,
+				`AttributeName("map")`,
+				`AttributeName("map").ElementKeyString("x")`,
+				`AttributeName("map").ElementKeyString("y")`,
+				`AttributeName("nested")`,
+				`AttributeName("nested").AttributeName("number")`,
+				`AttributeName("unknown")`,
+			},
+		},
+		"breadth-first": {
+			walk: WalkBreadthFirst,
+			expected: []string{
+				"",
+				`AttributeName("list")`,
+				`AttributeName("map")`,
+				`AttributeName("nested")`,
+				`AttributeName("unknown")`,
+				`AttributeName("list").ElementKeyInt(0)`,
+				`AttributeName("list").ElementKeyInt(1)`,
+				`AttributeName("map").ElementKeyString("x")`,
+				`AttributeName("map").ElementKeyString("y")`,
+				`AttributeName("nested").AttributeName("number")`,
+			},
+		},
+		"depth-first-leaves-only": {
+			walk: WalkDepthFirst,
+			opts: WalkOpts{LeavesOnly: true},
+			expected: []string{
+				`AttributeName("list").ElementKeyInt(0)`,
+				`AttributeName("list").ElementKeyInt(1)`,
+				`AttributeName("map").ElementKeyString("x")`,
+				`AttributeName("map").ElementKeyString("y")`,
+				`AttributeName("nested").AttributeName("number")`,
+				`AttributeName("unknown")`,
+			},
+		},
+		"breadth-first-leaves-only": {
+			walk: WalkBreadthFirst,
+			opts: WalkOpts{LeavesOnly: true},
+			expected: []string{
+				`AttributeName("unknown")`,
+				`AttributeName("list").ElementKeyInt(0)`,
+				`AttributeName("list").ElementKeyInt(1)`,
+				`AttributeName("map").ElementKeyString("x")`,
+				`AttributeName("map").ElementKeyString("y")`,
+				`AttributeName("nested").AttributeName("number")`,
+			},
+		},
+		"depth-first-skip-children": {
+			walk: WalkDepthFirst,
+			opts: WalkOpts{
+				SkipChildren: func(path *AttributePath, _ Value) bool {
+					return path.Equal(NewAttributePath().WithAttributeName("list")) ||
+						path.Equal(NewAttributePath().WithAttributeName("map"))
+				},
+			},
+			expected: []string{
+				"",
+				`AttributeName("list")`,
+				`AttributeName("map")`,
+				`AttributeName("nested")`,
+				`AttributeName("nested").AttributeName("number")`,
+				`AttributeName("unknown")`,
+			},
+		},
+		"breadth-first-skip-children": {
+			walk: WalkBreadthFirst,
+			opts: WalkOpts{
+				SkipChildren: func(path *AttributePath, _ Value) bool {
+					return path.Equal(NewAttributePath().WithAttributeName("nested"))
+				},
+			},
+			expected: []string{
+				"",
+				`AttributeName("list")`,
+				`AttributeName("map")`,
+				`AttributeName("nested")`,
+				`AttributeName("unknown")`,
+				`AttributeName("list").ElementKeyInt(0)`,
+				`AttributeName("list").ElementKeyInt(1)`,
+				`AttributeName("map").ElementKeyString("x")`,
+				`AttributeName("map").ElementKeyString("y")`,
+			},
+		},
+		"breadth-first-stop-at-unknown": {
+			walk: WalkBreadthFirst,
+			opts: WalkOpts{StopAtUnknown: true},
+			expected: []string{
+				"",
+				`AttributeName("list")`,
+				`AttributeName("map")`,
+				`AttributeName("nested")`,
+				`AttributeName("unknown")`,
+			},
+		},
+	}
+
+	for name, testCase := range testCases {
+		t.Run(name, func(t *testing.T) {
+			t.Parallel()
+
+			var got []string
+
+			for path := range testCase.walk(val, testCase.opts) {
+				got = append(got, path.String())
+			}
+
+			if diff := cmp.Diff(testCase.expected, got); diff != "" {
+				t.Errorf("unexpected difference: %s", diff)
+			}
+		})
+	}
+}
+
+func TestWalkSeq_break(t *testing.T) {
+	t.Parallel()
+
+	val := NewValue(List{ElementType: String}, []Value{
+		NewValue(String, "a"),
+		NewValue(String, "b"),
+		NewValue(String, "c"),
+	})
+
+	var got []Value
+
+	for _, v := range WalkDepthFirst(val, WalkOpts{LeavesOnly: true}) {
+		got = append(got, v)
+
+		if len(got) == 2 {
+			break
+		}
+	}
+
+	expected := []Value{
+		NewValue(String, "a"),
+		NewValue(String, "b"),
+	}
+
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}
+
+func TestWalkSeq_set(t *testing.T) {
+	t.Parallel()
+
+	val := NewValue(Set{ElementType: String}, []Value{
+		NewValue(String, "a"),
+	})
+
+	var got []*AttributePath
+
+	for path := range WalkBreadthFirst(val, WalkOpts{LeavesOnly: true}) {
+		// Paths are reused between iterations, so they must be copied.
+		got = append(got, NewAttributePathWithSteps(path.Steps()))
+	}
+
+	expected := []*AttributePath{
+		NewAttributePath().WithElementKeyValue(NewValue(String, "a")),
+	}
+
+	if diff := cmp.Diff(expected, got); diff != "" {
+		t.Errorf("unexpected difference: %s", diff)
+	}
+}